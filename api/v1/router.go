@@ -15,12 +15,91 @@ func RegisterRoutes(r *gin.Engine) {
 		v1.GET("/ws-system", controllers.SystemInfoWS)  // ✅ ws推送状态
 
 		// 容器管理
+		v1.GET("/docker/hosts", controllers.ListDockerHosts)
+		v1.GET("/docker/info", controllers.DockerInfo)
 		v1.GET("/containers", controllers.ListContainers)
+		v1.GET("/container/:id", controllers.InspectContainer)
 		v1.POST("/container/start/:id", controllers.StartContainer)
 		v1.POST("/container/stop/:id", controllers.StopContainer)
+		v1.POST("/container/restart/:id", controllers.RestartContainer)
+		v1.POST("/container/pause/:id", controllers.PauseContainer)
+		v1.POST("/container/unpause/:id", controllers.UnpauseContainer)
+		v1.POST("/container/kill/:id", controllers.KillContainer)
+		v1.POST("/container/rename/:id", controllers.RenameContainer)
+		v1.POST("/container/remove/:id", controllers.RemoveContainer)
+		v1.POST("/container/update/:id", controllers.UpdateContainer)
+		v1.POST("/container/redeploy/:id", controllers.RedeployContainer)
 		v1.GET("/ws/container-logs/:id", controllers.ContainerLogsWS)
+		v1.GET("/container/logs/:id", controllers.ExportContainerLogs)
+		v1.GET("/container/files/list/:id", controllers.ListContainerFiles)
+		v1.GET("/container/files/download/:id", controllers.DownloadContainerFiles)
+		v1.POST("/container/files/upload/:id", controllers.UploadContainerFile)
+		v1.GET("/ws/container-exec/:id", controllers.ContainerExecWS)
+		v1.GET("/ws/container-stats", controllers.ContainerStatsWS)
+		v1.GET("/ws/docker-events", controllers.DockerEventsWS)
 		v1.POST("/container/create", controllers.CreateContainer)
 
+		// 容器模板
+		v1.GET("/templates", controllers.ListTemplates)
+		v1.GET("/template/inspect", controllers.InspectTemplate)
+		v1.POST("/template/create", controllers.CreateTemplate)
+		v1.POST("/template/update", controllers.UpdateTemplate)
+		v1.POST("/template/delete", controllers.DeleteTemplate)
+		v1.POST("/template/instantiate", controllers.InstantiateTemplate)
+
+		// 镜像管理
+		v1.GET("/images", controllers.ListImages)
+		v1.GET("/image/inspect", controllers.InspectImage)
+		v1.POST("/image/remove", controllers.RemoveImage)
+		v1.POST("/image/tag", controllers.TagImage)
+		v1.POST("/image/prune", controllers.PruneImages)
+		v1.GET("/ws/image-pull", controllers.ImagePullWS)
+
+		// 网络管理
+		v1.GET("/networks", controllers.ListNetworks)
+		v1.GET("/network/inspect", controllers.InspectNetwork)
+		v1.POST("/network/create", controllers.CreateNetwork)
+		v1.POST("/network/remove", controllers.RemoveNetwork)
+		v1.POST("/network/connect", controllers.ConnectNetwork)
+		v1.POST("/network/disconnect", controllers.DisconnectNetwork)
+
+		// 数据卷管理
+		v1.GET("/volumes", controllers.ListVolumes)
+		v1.GET("/volume/inspect", controllers.InspectVolume)
+		v1.POST("/volume/create", controllers.CreateVolume)
+		v1.POST("/volume/remove", controllers.RemoveVolume)
+		v1.POST("/volume/prune", controllers.PruneVolumes)
+
+		// 备份恢复
+		v1.GET("/backups", controllers.ListBackups)
+		v1.POST("/backup/create", controllers.CreateBackup)
+		v1.POST("/backup/restore", controllers.RestoreBackup)
+		v1.POST("/backup/delete", controllers.DeleteBackup)
+		v1.GET("/backup/download/:id", controllers.DownloadBackup)
+
+		// 容器快照
+		v1.GET("/snapshots", controllers.ListSnapshots)
+		v1.POST("/snapshot/create", controllers.CreateSnapshot)
+		v1.POST("/snapshot/rollback", controllers.RollbackSnapshot)
+		v1.POST("/snapshot/delete", controllers.DeleteSnapshot)
+
+		// 镜像自动更新
+		v1.GET("/autoupdate/reports", controllers.GetAutoUpdateReports)
+		v1.GET("/autoupdate/report/:id", controllers.GetAutoUpdateReport)
+		v1.POST("/autoupdate/run", controllers.RunAutoUpdate)
+
+		// 蓝绿部署
+		v1.GET("/bluegreen/apps", controllers.ListBlueGreenApps)
+		v1.POST("/bluegreen/deploy", controllers.BlueGreenDeploy)
+		v1.POST("/bluegreen/rollback", controllers.BlueGreenRollback)
+
+		// 镜像仓库凭据
+		v1.GET("/registry/credentials", controllers.ListRegistryCredentials)
+		v1.POST("/registry/credential/create", controllers.CreateRegistryCredential)
+		v1.POST("/registry/credential/update", controllers.UpdateRegistryCredential)
+		v1.POST("/registry/credential/delete", controllers.DeleteRegistryCredential)
+		v1.POST("/registry/credential/test", controllers.TestRegistryCredential)
+
 		// 🧩 Compose 管理
 		v1.POST("/compose/upload", controllers.UploadCompose)
 		v1.GET("/compose/list", controllers.ListCompose)
@@ -0,0 +1,14 @@
+package v2
+
+import (
+	"auto-deploy-platform/controllers"
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterRoutes(r *gin.Engine) {
+	v2 := r.Group("/api/v2")
+	{
+		// 容器管理
+		v2.POST("/container/create", controllers.CreateContainerV2) // ✅ 结构化创建请求
+	}
+}
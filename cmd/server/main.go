@@ -2,9 +2,13 @@ package main
 
 import (
 	"auto-deploy-platform/api/v1"
+	"auto-deploy-platform/api/v2"
 	"auto-deploy-platform/config"
+	"auto-deploy-platform/controllers"
 	_ "auto-deploy-platform/docs"
 	"auto-deploy-platform/middlewares"
+	"auto-deploy-platform/services"
+	"context"
 	"log"
 	"net/http"
 
@@ -30,6 +34,58 @@ import (
 func main() {
 	config.InitConfig()
 
+	// Docker 多主机注册表：每个主机一个长连接客户端，自动协商 API 版本
+	dockerHosts := services.NewHostRegistry(config.Conf.Docker, nil)
+	defer dockerHosts.Close()
+	controllers.InitDockerHosts(dockerHosts)
+
+	// 私有镜像仓库凭据，加密存储，拉取镜像时按仓库地址自动选用
+	registryCredentials, err := services.NewCredentialStore(config.Conf.Registry.CredentialsFile, config.Conf.Registry.SecretKey)
+	if err != nil {
+		log.Fatalf("❌ 镜像仓库凭据加载失败: %v", err)
+	}
+	controllers.InitRegistryCredentials(registryCredentials)
+
+	// 数据卷备份目录
+	backupStore, err := services.NewBackupStore(config.Conf.Backup.Dir)
+	if err != nil {
+		log.Fatalf("❌ 备份目录初始化失败: %v", err)
+	}
+	controllers.InitBackups(backupStore, config.Conf.Backup.HelperImage)
+
+	// 容器快照记录，快照镜像保存在各自的 Docker 主机上
+	snapshotStore, err := services.NewSnapshotStore(config.Conf.Backup.SnapshotsFile)
+	if err != nil {
+		log.Fatalf("❌ 容器快照记录加载失败: %v", err)
+	}
+	controllers.InitSnapshots(snapshotStore)
+
+	// 镜像自动更新：定时比较带标签容器的镜像摘要，有新版本时重建
+	updateSchedule, err := services.NewUpdateSchedule(config.Conf.AutoUpdate.Interval, config.Conf.AutoUpdate.Window)
+	if err != nil {
+		log.Fatalf("❌ 自动更新配置无效: %v", err)
+	}
+	updateReports, err := services.NewUpdateReportStore(config.Conf.AutoUpdate.ReportsFile, config.Conf.AutoUpdate.KeepReports)
+	if err != nil {
+		log.Fatalf("❌ 自动更新报告加载失败: %v", err)
+	}
+	controllers.InitAutoUpdate(updateReports, updateSchedule, config.Conf.AutoUpdate.Label, config.Conf.AutoUpdate.Enabled)
+	controllers.StartAutoUpdate(context.Background())
+
+	// 蓝绿部署记录：各应用当前在线的颜色和两个颜色的容器
+	blueGreenStore, err := services.NewBlueGreenStore(config.Conf.BlueGreen.RecordsFile)
+	if err != nil {
+		log.Fatalf("❌ 蓝绿部署记录加载失败: %v", err)
+	}
+	controllers.InitBlueGreen(blueGreenStore)
+
+	// 容器模板：保存常用的创建容器请求，实例化时替换 ${NAME} 参数
+	templateStore, err := services.NewTemplateStore(config.Conf.Templates.File)
+	if err != nil {
+		log.Fatalf("❌ 容器模板加载失败: %v", err)
+	}
+	controllers.InitTemplates(templateStore)
+
 	r := gin.Default()
 	// Redoc 页面
 	r.Static("/docs", "./static/redoc")
@@ -56,6 +112,7 @@ func main() {
 
 	// Routes
 	v1.RegisterRoutes(r)
+	v2.RegisterRoutes(r)
 
 	log.Println("✅ Server starting on :8081...")
 	if err := r.Run(":8081"); err != nil {
@@ -3,6 +3,7 @@ package config
 import (
 	"github.com/spf13/viper"
 	"log"
+	"os"
 )
 
 // ✅ 确保 `ConfigStruct` 结构体只定义一次
@@ -12,6 +13,63 @@ type ConfigStruct struct {
 		InventoryDir      string   `mapstructure:"inventory_dir"`
 		AllowedExtensions []string `mapstructure:"allowed_extensions"`
 	}
+	Docker     DockerConfig     `mapstructure:"docker"`
+	Registry   RegistryConfig   `mapstructure:"registry"`
+	Backup     BackupConfig     `mapstructure:"backup"`
+	AutoUpdate AutoUpdateConfig `mapstructure:"auto_update"`
+	BlueGreen  BlueGreenConfig  `mapstructure:"bluegreen"`
+	Templates  TemplatesConfig  `mapstructure:"templates"`
+}
+
+// TemplatesConfig 容器模板
+type TemplatesConfig struct {
+	File string `mapstructure:"file"` // 全部模板及其历史版本
+}
+
+// BlueGreenConfig 蓝绿部署
+type BlueGreenConfig struct {
+	RecordsFile string `mapstructure:"records_file"` // 各应用的蓝绿部署记录：当前在线的颜色、两个颜色的容器和镜像
+}
+
+// AutoUpdateConfig 镜像自动更新：定期比较带标签容器的本地镜像摘要与仓库中的摘要，不一致时拉取并重建容器
+type AutoUpdateConfig struct {
+	Enabled     bool   `mapstructure:"enabled"`
+	Interval    string `mapstructure:"interval"`     // 检查间隔，如 30m、6h
+	Window      string `mapstructure:"window"`       // 维护窗口 HH:MM-HH:MM（服务器本地时间，可跨零点），只在窗口内检查和重建，留空不限
+	Label       string `mapstructure:"label"`        // 参与自动更新的容器需带有 <label>=true
+	ReportsFile string `mapstructure:"reports_file"` // 每次检查的报告
+	KeepReports int    `mapstructure:"keep_reports"` // 保留的报告数量
+}
+
+// BackupConfig 数据卷备份
+type BackupConfig struct {
+	Dir           string `mapstructure:"dir"`            // 备份归档 (tar.gz) 存放目录
+	HelperImage   string `mapstructure:"helper_image"`   // 读写数据卷使用的辅助容器镜像，需包含 sh / find
+	SnapshotsFile string `mapstructure:"snapshots_file"` // 容器快照记录
+}
+
+// RegistryConfig 私有镜像仓库凭据存储
+type RegistryConfig struct {
+	CredentialsFile string `mapstructure:"credentials_file"` // AES-GCM 加密的凭据文件，密钥由口令经 scrypt 派生
+	SecretKey       string `mapstructure:"secret_key"`       // 加密口令，留空读取 ADP_REGISTRY_SECRET_KEY 环境变量，仍为空时不能保存凭据
+}
+
+// DockerConfig 多主机 Docker 配置
+type DockerConfig struct {
+	DefaultHost        string             `mapstructure:"default_host"` // 请求未携带 host 参数时使用
+	Hosts              []DockerHostConfig `mapstructure:"hosts"`
+	SecretEnvPatterns  []string           `mapstructure:"secret_env_patterns"`  // 环境变量名包含这些关键字时脱敏显示
+	ExecAllowedOrigins []string           `mapstructure:"exec_allowed_origins"` // 除同源页面外允许打开容器终端的页面来源，如 https://ops.example.com
+}
+
+// DockerHostConfig 单个 Docker 主机
+type DockerHostConfig struct {
+	ID       string `mapstructure:"id"`
+	Name     string `mapstructure:"name"`
+	Endpoint string `mapstructure:"endpoint"` // unix:///var/run/docker.sock 或 tcp://host:2376，留空读取 DOCKER_HOST 环境变量
+	TLSCA    string `mapstructure:"tls_ca"`
+	TLSCert  string `mapstructure:"tls_cert"`
+	TLSKey   string `mapstructure:"tls_key"`
 }
 
 var Conf ConfigStruct
@@ -34,6 +92,40 @@ func InitConfig() {
 		log.Fatalf("❌ 配置解析失败: %v", err)
 	}
 
+	if Conf.Registry.CredentialsFile == "" {
+		Conf.Registry.CredentialsFile = "data/registry_credentials.enc"
+	}
+	if Conf.Registry.SecretKey == "" {
+		Conf.Registry.SecretKey = os.Getenv("ADP_REGISTRY_SECRET_KEY")
+	}
+	if Conf.Backup.Dir == "" {
+		Conf.Backup.Dir = "data/backups"
+	}
+	if Conf.Backup.HelperImage == "" {
+		Conf.Backup.HelperImage = "busybox:latest"
+	}
+	if Conf.Backup.SnapshotsFile == "" {
+		Conf.Backup.SnapshotsFile = "data/snapshots.json"
+	}
+	if Conf.AutoUpdate.Interval == "" {
+		Conf.AutoUpdate.Interval = "1h"
+	}
+	if Conf.AutoUpdate.Label == "" {
+		Conf.AutoUpdate.Label = "adp.autoupdate"
+	}
+	if Conf.AutoUpdate.ReportsFile == "" {
+		Conf.AutoUpdate.ReportsFile = "data/autoupdate_reports.json"
+	}
+	if Conf.AutoUpdate.KeepReports <= 0 {
+		Conf.AutoUpdate.KeepReports = 50
+	}
+	if Conf.BlueGreen.RecordsFile == "" {
+		Conf.BlueGreen.RecordsFile = "data/bluegreen.json"
+	}
+	if Conf.Templates.File == "" {
+		Conf.Templates.File = "data/container_templates.json"
+	}
+
 	log.Println("✅ 配置加载成功: PlaybookDir =", Conf.Ansible.PlaybookDir)
 }
 
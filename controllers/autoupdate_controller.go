@@ -0,0 +1,284 @@
+package controllers
+
+import (
+	"auto-deploy-platform/models"
+	"auto-deploy-platform/services"
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/errdefs"
+	"github.com/gin-gonic/gin"
+)
+
+// autoUpdate 镜像自动更新的状态，由 main 通过 InitAutoUpdate 注入
+var autoUpdate struct {
+	sync.Mutex
+	enabled  bool
+	schedule services.UpdateSchedule
+	label    string
+	reports  *services.UpdateReportStore
+	running  bool
+	nextRun  time.Time
+}
+
+// InitAutoUpdate 注入自动更新的检查计划和报告存储，带 label=true 标签的运行中容器参与自动更新
+func InitAutoUpdate(store *services.UpdateReportStore, schedule services.UpdateSchedule, label string, enabled bool) {
+	autoUpdate.Lock()
+	defer autoUpdate.Unlock()
+	autoUpdate.reports = store
+	autoUpdate.schedule = schedule
+	autoUpdate.label = label
+	autoUpdate.enabled = enabled
+}
+
+// StartAutoUpdate 在后台按计划检查，ctx 取消时停止；未启用时不做任何事
+func StartAutoUpdate(ctx context.Context) {
+	autoUpdate.Lock()
+	enabled, schedule := autoUpdate.enabled, autoUpdate.schedule
+	autoUpdate.Unlock()
+	if !enabled {
+		return
+	}
+	go func() {
+		for {
+			next := schedule.Next(time.Now())
+			autoUpdate.Lock()
+			autoUpdate.nextRun = next
+			autoUpdate.Unlock()
+
+			timer := time.NewTimer(time.Until(next))
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+			if _, err := runAutoUpdate(ctx, "schedule", false); err != nil {
+				log.Printf("⚠️ 自动更新跳过: %v", err)
+			}
+		}
+	}()
+}
+
+// GetAutoUpdateReports 自动更新状态和报告列表
+// @Summary 自动更新状态和报告
+// @Description 返回自动更新配置、下次检查时间和最近的检查报告概要，最新的在前
+// @Tags 自动更新
+// @Produce json
+// @Success 200 {object} models.AutoUpdateStatusResponse "成功返回状态和报告列表"
+// @Router /autoupdate/reports [get]
+func GetAutoUpdateReports(c *gin.Context) {
+	autoUpdate.Lock()
+	resp := models.AutoUpdateStatusResponse{
+		Enabled:  autoUpdate.enabled,
+		Interval: autoUpdate.schedule.Interval.String(),
+		Window:   autoUpdate.schedule.Window,
+		Label:    autoUpdate.label + "=true",
+		Running:  autoUpdate.running,
+		Reports:  []models.AutoUpdateReportSummary{},
+	}
+	if autoUpdate.enabled && !autoUpdate.nextRun.IsZero() {
+		resp.NextRun = autoUpdate.nextRun.Format(time.RFC3339)
+	}
+	store := autoUpdate.reports
+	autoUpdate.Unlock()
+
+	for _, r := range store.List() {
+		resp.Reports = append(resp.Reports, toAutoUpdateSummary(r))
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetAutoUpdateReport 单次检查的报告
+// @Summary 自动更新报告详情
+// @Description 返回一次检查中每个容器的结果：本地与仓库的镜像摘要、是否重建以及失败原因
+// @Tags 自动更新
+// @Produce json
+// @Param id path string true "报告ID"
+// @Success 200 {object} models.AutoUpdateReport "成功返回报告"
+// @Failure 404 {object} models.ErrorResponse "报告不存在"
+// @Router /autoupdate/report/{id} [get]
+func GetAutoUpdateReport(c *gin.Context) {
+	report, err := autoUpdate.reports.Get(c.Param("id"))
+	if err != nil {
+		dockerError(c, "Get update report failed", err)
+		return
+	}
+	c.JSON(http.StatusOK, toAutoUpdateReport(report))
+}
+
+// RunAutoUpdate 立即执行一次自动更新检查
+// @Summary 立即检查镜像更新
+// @Description 立即检查所有 Docker 主机上带自动更新标签的运行中容器，不受维护窗口和启用开关限制；dry_run=true 时只比较摘要不重建。请求等待检查完成后返回报告，客户端断开不会中断检查
+// @Tags 自动更新
+// @Accept json
+// @Produce json
+// @Param run body models.AutoUpdateRunRequest false "检查选项"
+// @Success 200 {object} models.AutoUpdateRunResponse "检查完成"
+// @Failure 409 {object} models.ErrorResponse "已有检查正在进行"
+// @Router /autoupdate/run [post]
+func RunAutoUpdate(c *gin.Context) {
+	var req models.AutoUpdateRunRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request", "detail": err.Error()})
+			return
+		}
+	}
+	// 重建中途取消会触发回滚，检查一旦开始就执行完
+	report, err := runAutoUpdate(context.WithoutCancel(c.Request.Context()), "manual", req.DryRun)
+	if err != nil {
+		dockerError(c, "Auto update failed", err)
+		return
+	}
+	c.JSON(http.StatusOK, models.AutoUpdateRunResponse{Code: 200, Message: "Auto update finished", Report: toAutoUpdateReport(report)})
+}
+
+// runAutoUpdate 检查所有主机上带标签的运行中容器，镜像有新版本时重建，并保存报告。同一时间只允许一次检查
+func runAutoUpdate(ctx context.Context, trigger string, dryRun bool) (services.UpdateReport, error) {
+	autoUpdate.Lock()
+	if autoUpdate.running {
+		autoUpdate.Unlock()
+		return services.UpdateReport{}, errdefs.Conflict(errors.New("an auto update run is already in progress"))
+	}
+	autoUpdate.running = true
+	schedule, label, store := autoUpdate.schedule, autoUpdate.label, autoUpdate.reports
+	autoUpdate.Unlock()
+	defer func() {
+		autoUpdate.Lock()
+		autoUpdate.running = false
+		autoUpdate.Unlock()
+	}()
+
+	started := time.Now()
+	report := services.UpdateReport{ID: started.Format("20060102-150405.000"), Trigger: trigger, DryRun: dryRun, StartedAt: started, Results: []services.UpdateResult{}}
+	for _, host := range dockerHosts.Hosts() {
+		cli, err := dockerHosts.Get(host.ID)
+		if err != nil {
+			report.Results = append(report.Results, services.UpdateResult{Host: host.ID, Status: services.UpdateStatusFailed, Error: err.Error()})
+			continue
+		}
+		containers, err := cli.ContainerList(ctx, types.ContainerListOptions{Filters: filters.NewArgs(filters.Arg("label", label+"=true"))})
+		if err != nil {
+			report.Results = append(report.Results, services.UpdateResult{Host: host.ID, Status: services.UpdateStatusFailed, Error: err.Error()})
+			continue
+		}
+		sort.Slice(containers, func(i, j int) bool { return containerName(containers[i].Names) < containerName(containers[j].Names) })
+		for _, ctr := range containers {
+			// 定时检查在维护窗口结束后不再重建，留到下一个窗口
+			inWindow := trigger != "schedule" || schedule.InWindow(time.Now())
+			report.Results = append(report.Results, updateContainerImage(ctx, cli, host.ID, ctr, dryRun, !inWindow))
+		}
+	}
+	report.FinishedAt = time.Now()
+
+	log.Printf("自动更新完成: %d 个容器，更新 %d，失败 %d", len(report.Results), report.Count(services.UpdateStatusUpdated), report.Count(services.UpdateStatusFailed))
+	if err := store.Add(&report); err != nil {
+		log.Printf("❌ 保存自动更新报告失败: %v", err)
+	}
+	return report, nil
+}
+
+// updateContainerImage 比较容器所用镜像在本地记录的摘要与仓库中的当前摘要，不一致时拉取并重建容器。
+// checkOnly 时只比较；windowClosed 时有新版本也记为 skipped
+func updateContainerImage(ctx context.Context, cli services.DockerService, host string, ctr types.Container, checkOnly, windowClosed bool) services.UpdateResult {
+	res := services.UpdateResult{Host: host, Container: strings.TrimPrefix(containerName(ctr.Names), "/"), ContainerID: shortID(ctr.ID), Image: ctr.Image}
+	failed := func(err error) services.UpdateResult {
+		res.Status, res.Error = services.UpdateStatusFailed, err.Error()
+		return res
+	}
+
+	info, err := cli.ContainerInspect(ctx, ctr.ID)
+	if err != nil {
+		return failed(err)
+	}
+	ref := info.Config.Image
+	res.Image = ref
+	if strings.Contains(ref, "@") || strings.HasPrefix(ref, "sha256:") {
+		res.Status, res.Error = services.UpdateStatusSkipped, "image is pinned by digest"
+		return res
+	}
+	img, _, err := cli.ImageInspectWithRaw(ctx, info.Image)
+	if err != nil {
+		return failed(err)
+	}
+	if len(img.RepoDigests) == 0 {
+		res.Status, res.Error = services.UpdateStatusSkipped, "image has no registry digest (built or committed locally)"
+		return res
+	}
+	dist, err := cli.DistributionInspect(ctx, ref, registryAuthFor(ref))
+	if err != nil {
+		return failed(err)
+	}
+	res.RemoteDigest = dist.Descriptor.Digest.String()
+	_, res.LocalDigest, _ = strings.Cut(img.RepoDigests[0], "@")
+	for _, d := range img.RepoDigests {
+		if strings.HasSuffix(d, "@"+res.RemoteDigest) {
+			res.LocalDigest, res.Status = res.RemoteDigest, services.UpdateStatusUpToDate
+			return res
+		}
+	}
+
+	switch {
+	case windowClosed:
+		res.Status, res.Error = services.UpdateStatusSkipped, "maintenance window closed"
+		return res
+	case checkOnly:
+		res.Status = services.UpdateStatusAvailable
+		return res
+	}
+	resp, err := redeployContainer(ctx, cli, info, redeployOptions{
+		image:     ref,
+		pull:      true,
+		readiness: readiness{timeout: defaultHealthTimeout, minUptime: defaultMinUptime},
+	})
+	if err != nil {
+		return failed(err)
+	}
+	res.Status = services.UpdateStatusUpToDate
+	if resp.Updated {
+		res.Status, res.NewContainerID = services.UpdateStatusUpdated, resp.ID
+	}
+	return res
+}
+
+func toAutoUpdateSummary(r services.UpdateReport) models.AutoUpdateReportSummary {
+	return models.AutoUpdateReportSummary{
+		ID:         r.ID,
+		Trigger:    r.Trigger,
+		DryRun:     r.DryRun,
+		StartedAt:  r.StartedAt.Format(time.RFC3339),
+		FinishedAt: r.FinishedAt.Format(time.RFC3339),
+		Checked:    len(r.Results),
+		Available:  r.Count(services.UpdateStatusAvailable),
+		Updated:    r.Count(services.UpdateStatusUpdated),
+		Failed:     r.Count(services.UpdateStatusFailed),
+		Skipped:    r.Count(services.UpdateStatusSkipped),
+	}
+}
+
+func toAutoUpdateReport(r services.UpdateReport) models.AutoUpdateReport {
+	report := models.AutoUpdateReport{AutoUpdateReportSummary: toAutoUpdateSummary(r), Results: []models.AutoUpdateResult{}}
+	for _, res := range r.Results {
+		report.Results = append(report.Results, models.AutoUpdateResult{
+			Host:           res.Host,
+			Container:      res.Container,
+			ContainerID:    res.ContainerID,
+			Image:          res.Image,
+			LocalDigest:    res.LocalDigest,
+			RemoteDigest:   res.RemoteDigest,
+			Status:         res.Status,
+			Error:          res.Error,
+			NewContainerID: res.NewContainerID,
+		})
+	}
+	return report
+}
@@ -0,0 +1,236 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"auto-deploy-platform/models"
+	"auto-deploy-platform/services"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/gin-gonic/gin"
+)
+
+const testUpdateLabel = "adp.autoupdate"
+
+// useAutoUpdate 以临时报告文件和给定的维护窗口初始化自动更新，测试结束后恢复为未启用
+func useAutoUpdate(t *testing.T, window string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "updates.json")
+	store, err := services.NewUpdateReportStore(path, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	schedule, err := services.NewUpdateSchedule("1h", window)
+	if err != nil {
+		t.Fatal(err)
+	}
+	InitAutoUpdate(store, schedule, testUpdateLabel, true)
+	t.Cleanup(func() { InitAutoUpdate(nil, services.UpdateSchedule{}, "", false) })
+	return path
+}
+
+// markHealthy 在后台把处于 starting 的容器推进为 healthy，模拟健康检查通过
+func markHealthy(t *testing.T, fake *services.FakeDockerService) {
+	old := healthPollInterval
+	healthPollInterval = 10 * time.Millisecond
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	t.Cleanup(func() {
+		cancel()
+		<-done
+		healthPollInterval = old
+	})
+	go func() {
+		defer close(done)
+		for ctx.Err() == nil {
+			list, _ := fake.ContainerList(ctx, types.ContainerListOptions{Filters: filters.NewArgs(filters.Arg("health", "starting"))})
+			for _, ctr := range list {
+				fake.SetHealth(ctr.ID, types.Healthy)
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+	}()
+}
+
+func runContainer(t *testing.T, fake *services.FakeDockerService, name, image string, labels map[string]string) string {
+	t.Helper()
+	ctx := context.Background()
+	fake.AddImage(image)
+	created, err := fake.ContainerCreate(ctx, &container.Config{Image: image, Labels: labels,
+		Healthcheck: &container.HealthConfig{Test: []string{"CMD", "true"}}}, nil, nil, nil, name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := fake.ContainerStart(ctx, created.ID, types.ContainerStartOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	fake.SetHealth(created.ID, types.Healthy)
+	return created.ID
+}
+
+func resultsByContainer(results []services.UpdateResult) map[string]services.UpdateResult {
+	m := make(map[string]services.UpdateResult)
+	for _, r := range results {
+		m[r.Container] = r
+	}
+	return m
+}
+
+func TestAutoUpdateRun(t *testing.T) {
+	fake := useFakeDocker(t)
+	markHealthy(t, fake)
+	reportsFile := useAutoUpdate(t, "")
+	watched := map[string]string{testUpdateLabel: "true"}
+
+	webID := runContainer(t, fake, "web", "nginx:1.25", watched)
+	runContainer(t, fake, "db", "mysql:8", watched)
+	cacheID := runContainer(t, fake, "cache", "redis:7", nil)
+	webImage, _ := fake.Container(webID)
+	oldImageID := webImage.Summary.ImageID
+	newDigest := fake.PushImage("nginx:1.25")
+	fake.PushImage("redis:7")
+
+	r := gin.New()
+	r.POST("/autoupdate/run", RunAutoUpdate)
+	r.GET("/autoupdate/reports", GetAutoUpdateReports)
+	r.GET("/autoupdate/report/:id", GetAutoUpdateReport)
+
+	// dry_run 只比较摘要
+	var dry models.AutoUpdateRunResponse
+	decode(t, serve(r, http.MethodPost, "/autoupdate/run", `{"dry_run":true}`), http.StatusOK, &dry)
+	if len(dry.Report.Results) != 2 {
+		t.Fatalf("dry run results = %+v, want web and db only", dry.Report.Results)
+	}
+	for _, res := range dry.Report.Results {
+		switch res.Container {
+		case "web":
+			if res.Status != services.UpdateStatusAvailable || res.RemoteDigest != newDigest || res.LocalDigest == newDigest {
+				t.Errorf("web dry run = %+v", res)
+			}
+		case "db":
+			if res.Status != services.UpdateStatusUpToDate || res.LocalDigest != res.RemoteDigest {
+				t.Errorf("db dry run = %+v", res)
+			}
+		default:
+			t.Errorf("unlabeled container %s was checked", res.Container)
+		}
+	}
+	if _, ok := fake.Container(webID); !ok {
+		t.Fatal("dry run replaced web")
+	}
+
+	var run models.AutoUpdateRunResponse
+	decode(t, serve(r, http.MethodPost, "/autoupdate/run", `{}`), http.StatusOK, &run)
+	var web models.AutoUpdateResult
+	for _, res := range run.Report.Results {
+		if res.Container == "web" {
+			web = res
+		}
+	}
+	if web.Status != services.UpdateStatusUpdated || web.NewContainerID == "" {
+		t.Fatalf("web result = %+v", web)
+	}
+	updated, ok := fake.Container("web")
+	if !ok || updated.Summary.ID == webID || updated.Summary.ImageID == oldImageID || updated.Summary.State != "running" {
+		t.Errorf("web after update = %+v", updated)
+	}
+	if updated.Config.Labels[testUpdateLabel] != "true" {
+		t.Errorf("labels after update = %v", updated.Config.Labels)
+	}
+	// 没有标签的容器即使仓库有新版本也不处理
+	if cache, ok := fake.Container("cache"); !ok || cache.Summary.ID != cacheID {
+		t.Error("unlabeled container was replaced")
+	}
+
+	// 报告写入文件，重启后仍可查询
+	store, err := services.NewUpdateReportStore(reportsFile, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if list := store.List(); len(list) != 2 || !list[1].DryRun || list[0].DryRun {
+		t.Errorf("persisted reports = %+v", list)
+	}
+	var status models.AutoUpdateStatusResponse
+	decode(t, serve(r, http.MethodGet, "/autoupdate/reports", ""), http.StatusOK, &status)
+	if len(status.Reports) != 2 || status.Reports[0].ID != run.Report.ID {
+		t.Errorf("reports = %+v", status.Reports)
+	}
+	var detail models.AutoUpdateReport
+	decode(t, serve(r, http.MethodGet, "/autoupdate/report/"+run.Report.ID, ""), http.StatusOK, &detail)
+	if len(detail.Results) != 2 {
+		t.Errorf("report detail = %+v", detail)
+	}
+	decode(t, serve(r, http.MethodGet, "/autoupdate/report/nope", ""), http.StatusNotFound, nil)
+}
+
+func TestAutoUpdateMaintenanceWindow(t *testing.T) {
+	now := time.Now()
+	clock := func(d time.Duration) string { return now.Add(d).Format("15:04") }
+	tests := []struct {
+		name   string
+		window string
+		want   string
+	}{
+		// 窗口可跨零点，按当前时间构造始终包含或不包含现在的窗口
+		{"open", clock(-time.Hour) + "-" + clock(time.Hour), services.UpdateStatusUpdated},
+		{"closed", clock(2*time.Hour) + "-" + clock(3*time.Hour), services.UpdateStatusSkipped},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := useFakeDocker(t)
+			markHealthy(t, fake)
+			useAutoUpdate(t, tt.window)
+			webID := runContainer(t, fake, "web", "nginx:1.25", map[string]string{testUpdateLabel: "true"})
+			fake.PushImage("nginx:1.25")
+
+			report, err := runAutoUpdate(context.Background(), "schedule", false)
+			if err != nil {
+				t.Fatal(err)
+			}
+			res := resultsByContainer(report.Results)["web"]
+			if res.Status != tt.want {
+				t.Fatalf("window %s: result = %+v, want %s", tt.window, res, tt.want)
+			}
+			if ctr, _ := fake.Container("web"); (ctr.Summary.ID == webID) != (tt.want == services.UpdateStatusSkipped) {
+				t.Errorf("window %s: container replaced = %v", tt.window, ctr.Summary.ID != webID)
+			}
+
+			// 手动触发不受维护窗口限制
+			if tt.want == services.UpdateStatusSkipped {
+				report, err := runAutoUpdate(context.Background(), "manual", false)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if res := resultsByContainer(report.Results)["web"]; res.Status != services.UpdateStatusUpdated {
+					t.Errorf("manual run in closed window = %+v", res)
+				}
+			}
+		})
+	}
+}
+
+func TestAutoUpdateRegistryFailure(t *testing.T) {
+	fake := useFakeDocker(t)
+	useAutoUpdate(t, "")
+	webID := runContainer(t, fake, "web", "nginx:1.25", map[string]string{testUpdateLabel: "true"})
+	fake.Errors["DistributionInspect"] = errors.New("registry unreachable")
+
+	report, err := runAutoUpdate(context.Background(), "manual", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res := resultsByContainer(report.Results)["web"]
+	if res.Status != services.UpdateStatusFailed || res.Error != "registry unreachable" {
+		t.Errorf("result = %+v", res)
+	}
+	if ctr, _ := fake.Container("web"); ctr.Summary.ID != webID {
+		t.Error("container replaced although the registry check failed")
+	}
+}
@@ -0,0 +1,505 @@
+package controllers
+
+import (
+	"archive/tar"
+	"auto-deploy-platform/models"
+	"auto-deploy-platform/services"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/api/types/volume"
+	"github.com/docker/docker/errdefs"
+	"github.com/gin-gonic/gin"
+)
+
+// helperVolumePath 辅助容器内数据卷的挂载点
+const helperVolumePath = "/volume"
+
+var (
+	// backups 由 main 注入的备份目录
+	backups *services.BackupStore
+	// backupHelperImage 读写数据卷的辅助容器镜像
+	backupHelperImage = "busybox:latest"
+)
+
+// InitBackups 注入备份目录和辅助容器镜像
+func InitBackups(store *services.BackupStore, helperImage string) {
+	backups = store
+	if helperImage != "" {
+		backupHelperImage = helperImage
+	}
+}
+
+// ListBackups 获取备份列表
+// @Summary 获取备份列表
+// @Description 列出备份目录中的全部备份（大小、sha256、来源），最新的在前
+// @Tags 备份恢复
+// @Produce json
+// @Param kind query string false "按类型过滤：volume / container"
+// @Param source query string false "按来源数据卷或容器名过滤"
+// @Success 200 {object} models.BackupListResponse "成功返回备份列表"
+// @Failure 500 {object} models.ErrorResponse "读取备份目录失败"
+// @Router /backups [get]
+func ListBackups(c *gin.Context) {
+	list, err := backups.List()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "List backups failed", "detail": err.Error()})
+		return
+	}
+	kind, source := c.Query("kind"), strings.TrimPrefix(c.Query("source"), "/")
+	resp := models.BackupListResponse{Backups: []models.BackupInfo{}}
+	for _, b := range list {
+		if (kind != "" && b.Kind != kind) || (source != "" && b.Source != source) {
+			continue
+		}
+		resp.Backups = append(resp.Backups, toBackupInfo(b))
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// CreateBackup 备份数据卷或容器的挂载
+// @Summary 创建备份
+// @Description 把命名数据卷（volume）或容器的全部 volume / bind 挂载（container）打包为带时间戳的 tar.gz。数据卷通过只读挂载它的辅助容器读取，不需要访问 Docker 主机的文件系统。stop_container=true 时备份期间停止使用这些数据的运行中容器，完成后（包括失败时）重新启动
+// @Tags 备份恢复
+// @Accept json
+// @Produce json
+// @Param backup body models.BackupCreateRequest true "备份参数"
+// @Param host query string false "Docker 主机ID，默认使用 default_host"
+// @Success 200 {object} models.BackupCreateResponse "备份成功"
+// @Failure 400 {object} models.ErrorResponse "参数错误"
+// @Failure 404 {object} models.ErrorResponse "数据卷、容器或 Docker 主机不存在"
+// @Failure 500 {object} models.ErrorResponse "服务器内部错误"
+// @Router /backup/create [post]
+func CreateBackup(c *gin.Context) {
+	var req models.BackupCreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil || (req.Volume == "") == (req.Container == "") {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request", "detail": "exactly one of volume or container is required"})
+		return
+	}
+	cli, ok := dockerFor(c)
+	if !ok {
+		return
+	}
+	host, _ := dockerHosts.Host(c.Query("host"))
+
+	ctx := c.Request.Context()
+	meta := services.Backup{Host: host.ID, Note: req.Note}
+	var write func(tw *tar.Writer) error
+	var users []string
+
+	if req.Volume != "" {
+		v, err := cli.VolumeInspect(ctx, req.Volume)
+		if err != nil {
+			dockerError(c, "Inspect volume failed", err)
+			return
+		}
+		meta.Kind, meta.Source = services.BackupKindVolume, v.Name
+		if req.StopContainer {
+			if users, err = volumeRunningUsers(ctx, cli, v.Name); err != nil {
+				dockerError(c, "List containers failed", err)
+				return
+			}
+		}
+		write = func(tw *tar.Writer) error {
+			helper, err := createHelper(ctx, cli, &container.HostConfig{
+				Mounts: []mount.Mount{{Type: mount.TypeVolume, Source: v.Name, Target: helperVolumePath, ReadOnly: true}},
+			}, nil)
+			if err != nil {
+				return err
+			}
+			defer removeHelper(cli, helper)
+			return copyArchive(ctx, cli, helper, helperVolumePath, tw, "")
+		}
+	} else {
+		info, err := cli.ContainerInspect(ctx, req.Container)
+		if err != nil {
+			dockerError(c, "Inspect container failed", err)
+			return
+		}
+		meta.Kind, meta.Source = services.BackupKindContainer, strings.TrimPrefix(info.Name, "/")
+		for _, m := range info.Mounts {
+			source := m.Source
+			if m.Type == mount.TypeVolume {
+				source = m.Name
+			}
+			if m.Type == mount.TypeVolume || m.Type == mount.TypeBind {
+				meta.Mounts = append(meta.Mounts, services.BackupMount{Type: string(m.Type), Source: source, Destination: m.Destination})
+			}
+		}
+		if len(meta.Mounts) == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request", "detail": "container has no volume or bind mounts"})
+			return
+		}
+		if req.StopContainer && info.State != nil && info.State.Running {
+			users = []string{info.ID}
+		}
+		write = func(tw *tar.Writer) error {
+			for _, m := range meta.Mounts {
+				if err := copyArchive(ctx, cli, info.ID, m.Destination, tw, strings.TrimPrefix(m.Destination, "/")); err != nil {
+					return fmt.Errorf("%s: %w", m.Destination, err)
+				}
+			}
+			return nil
+		}
+	}
+
+	var backup services.Backup
+	stopped, err := withContainersStopped(ctx, cli, users, func() error {
+		var err error
+		backup, err = backups.Create(meta, write)
+		return err
+	})
+	if err != nil {
+		log.Printf("❌ Backup %s %s failed: %v", meta.Kind, meta.Source, err)
+		dockerError(c, "Backup failed", err)
+		return
+	}
+	log.Printf("备份完成: %s (%d bytes)", backup.File, backup.Size)
+	c.JSON(http.StatusOK, models.BackupCreateResponse{Code: 200, Message: "Backup created", Backup: toBackupInfo(backup), Stopped: stopped})
+}
+
+// RestoreBackup 恢复备份
+// @Summary 恢复备份
+// @Description 校验 sha256 后把备份写回数据卷或容器。数据卷备份可恢复到原数据卷或新数据卷（不存在时自动创建）；容器备份恢复到原容器，或挂载点相同的其他容器。clean=true 时先清空目标，否则只覆盖同名文件。stop_container=true 时恢复期间停止使用目标数据的运行中容器，完成后重新启动
+// @Tags 备份恢复
+// @Accept json
+// @Produce json
+// @Param backup body models.BackupRestoreRequest true "恢复参数"
+// @Param host query string false "Docker 主机ID，默认使用 default_host"
+// @Success 200 {object} models.BackupRestoreResponse "恢复成功"
+// @Failure 400 {object} models.ErrorResponse "参数错误或目标容器缺少挂载点"
+// @Failure 404 {object} models.ErrorResponse "备份、容器或 Docker 主机不存在"
+// @Failure 500 {object} models.ErrorResponse "备份文件损坏或服务器内部错误"
+// @Router /backup/restore [post]
+func RestoreBackup(c *gin.Context) {
+	var req models.BackupRestoreRequest
+	if err := c.ShouldBindJSON(&req); err != nil || strings.TrimSpace(req.ID) == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+	b, err := backups.Get(req.ID)
+	if err != nil {
+		dockerError(c, "Get backup failed", err)
+		return
+	}
+	if (b.Kind == services.BackupKindVolume && req.Container != "") || (b.Kind == services.BackupKindContainer && req.Volume != "") {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request", "detail": fmt.Sprintf("a %s backup can only be restored into a %s", b.Kind, b.Kind)})
+		return
+	}
+	if req.Volume != "" && !volumeNamePattern.MatchString(req.Volume) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request", "detail": fmt.Sprintf("invalid volume name %q", req.Volume)})
+		return
+	}
+	cli, ok := dockerFor(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	var target string
+	var users []string
+	var restore func(archive io.Reader) error
+
+	if b.Kind == services.BackupKindVolume {
+		target = b.Source
+		if req.Volume != "" {
+			target = req.Volume
+		}
+		if _, err := cli.VolumeInspect(ctx, target); errdefs.IsNotFound(err) {
+			_, err = cli.VolumeCreate(ctx, volume.VolumeCreateBody{Name: target, Driver: "local"})
+			if err != nil {
+				dockerError(c, "Create volume failed", err)
+				return
+			}
+		} else if err != nil {
+			dockerError(c, "Inspect volume failed", err)
+			return
+		}
+		if req.StopContainer {
+			if users, err = volumeRunningUsers(ctx, cli, target); err != nil {
+				dockerError(c, "List containers failed", err)
+				return
+			}
+		}
+		hostConfig := func() *container.HostConfig {
+			return &container.HostConfig{Mounts: []mount.Mount{{Type: mount.TypeVolume, Source: target, Target: helperVolumePath}}}
+		}
+		restore = func(archive io.Reader) error {
+			if req.Clean {
+				if err := runHelper(ctx, cli, hostConfig(), []string{"find", helperVolumePath, "-mindepth", "1", "-delete"}); err != nil {
+					return fmt.Errorf("clean volume %s: %w", target, err)
+				}
+			}
+			helper, err := createHelper(ctx, cli, hostConfig(), nil)
+			if err != nil {
+				return err
+			}
+			defer removeHelper(cli, helper)
+			return cli.CopyToContainer(ctx, helper, helperVolumePath, archive, types.CopyToContainerOptions{})
+		}
+	} else {
+		target = b.Source
+		if req.Container != "" {
+			target = req.Container
+		}
+		info, err := cli.ContainerInspect(ctx, target)
+		if err != nil {
+			dockerError(c, "Inspect container failed", err)
+			return
+		}
+		// 备份中的每个挂载点在目标容器中都必须存在，否则数据会写进容器自身的文件系统
+		var dests []string
+		for _, bm := range b.Mounts {
+			found := false
+			for _, m := range info.Mounts {
+				found = found || m.Destination == bm.Destination
+			}
+			if !found {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request", "detail": fmt.Sprintf("container %s has no mount at %s", target, bm.Destination)})
+				return
+			}
+			dests = append(dests, bm.Destination)
+		}
+		if req.StopContainer && info.State != nil && info.State.Running {
+			users = []string{info.ID}
+		}
+		restore = func(archive io.Reader) error {
+			if req.Clean {
+				cmd := append([]string{"find"}, dests...)
+				cmd = append(cmd, "-mindepth", "1", "-delete")
+				if err := runHelper(ctx, cli, &container.HostConfig{VolumesFrom: []string{info.ID}}, cmd); err != nil {
+					return fmt.Errorf("clean mounts of %s: %w", target, err)
+				}
+			}
+			return cli.CopyToContainer(ctx, info.ID, "/", archive, types.CopyToContainerOptions{})
+		}
+	}
+
+	archive, _, err := backups.Open(b.ID)
+	if err != nil {
+		dockerError(c, "Open backup failed", err)
+		return
+	}
+	defer archive.Close()
+
+	stopped, err := withContainersStopped(ctx, cli, users, func() error { return restore(archive) })
+	if err != nil {
+		log.Printf("❌ Restore %s into %s failed: %v", b.ID, target, err)
+		dockerError(c, "Restore failed", err)
+		return
+	}
+	log.Printf("备份已恢复: %s → %s", b.ID, target)
+	c.JSON(http.StatusOK, models.BackupRestoreResponse{Code: 200, Message: "Backup restored", Target: target, Stopped: stopped})
+}
+
+// DeleteBackup 删除备份
+// @Summary 删除备份
+// @Tags 备份恢复
+// @Accept json
+// @Produce json
+// @Param backup body models.BackupDeleteRequest true "备份ID"
+// @Success 200 {object} models.SuccessResponse "删除成功"
+// @Failure 400 {object} models.ErrorResponse "参数错误"
+// @Failure 404 {object} models.ErrorResponse "备份不存在"
+// @Router /backup/delete [post]
+func DeleteBackup(c *gin.Context) {
+	var req models.BackupDeleteRequest
+	if err := c.ShouldBindJSON(&req); err != nil || strings.TrimSpace(req.ID) == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+	if err := backups.Delete(req.ID); err != nil {
+		dockerError(c, "Delete backup failed", err)
+		return
+	}
+	c.JSON(http.StatusOK, models.SuccessResponse{Code: 200, Message: "Backup deleted"})
+}
+
+// DownloadBackup 下载备份归档
+// @Summary 下载备份
+// @Tags 备份恢复
+// @Produce application/gzip
+// @Param id path string true "备份ID"
+// @Success 200 {file} file "tar.gz 归档"
+// @Failure 404 {object} models.ErrorResponse "备份不存在"
+// @Router /backup/download/{id} [get]
+func DownloadBackup(c *gin.Context) {
+	b, err := backups.Get(c.Param("id"))
+	if err != nil {
+		dockerError(c, "Get backup failed", err)
+		return
+	}
+	c.Header("X-Checksum-Sha256", b.SHA256)
+	c.FileAttachment(backups.Path(b), b.File)
+}
+
+// copyArchive 从容器中读取 srcPath 的 tar 归档写入 tw：去掉 Docker 加上的最后一级目录名，改为 prefix
+func copyArchive(ctx context.Context, cli services.DockerService, containerID, srcPath string, tw *tar.Writer, prefix string) error {
+	rc, _, err := cli.CopyFromContainer(ctx, containerID, srcPath)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	tr := tar.NewReader(rc)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		name := rebaseArchivePath(hdr.Name, prefix)
+		if name == "" || name == "." {
+			continue
+		}
+		if name == ".." || strings.HasPrefix(name, "../") {
+			return fmt.Errorf("unsafe path %q in archive", hdr.Name)
+		}
+		if hdr.Typeflag == tar.TypeDir {
+			name += "/"
+		}
+		hdr.Name = name
+		// 硬链接的目标同样是归档内路径
+		if hdr.Typeflag == tar.TypeLink {
+			hdr.Linkname = rebaseArchivePath(hdr.Linkname, prefix)
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if _, err := io.Copy(tw, tr); err != nil {
+			return err
+		}
+	}
+}
+
+// rebaseArchivePath 把归档内路径的第一级目录替换为 prefix
+func rebaseArchivePath(name, prefix string) string {
+	_, rest, _ := strings.Cut(strings.TrimPrefix(name, "./"), "/")
+	return path.Join(prefix, rest)
+}
+
+// createHelper 创建（不启动）挂载目标数据的辅助容器，镜像不存在时先拉取
+func createHelper(ctx context.Context, cli services.DockerService, hostConfig *container.HostConfig, cmd []string) (string, error) {
+	if _, _, err := cli.ImageInspectWithRaw(ctx, backupHelperImage); err != nil {
+		if err := pullImage(ctx, cli, backupHelperImage, nil); err != nil {
+			return "", fmt.Errorf("pull helper image %s: %w", backupHelperImage, err)
+		}
+	}
+	hostConfig.NetworkMode = "none"
+	resp, err := cli.ContainerCreate(ctx, &container.Config{
+		Image:  backupHelperImage,
+		Cmd:    cmd,
+		Labels: map[string]string{"adp.helper": "backup"},
+	}, hostConfig, nil, nil, "")
+	if err != nil {
+		return "", err
+	}
+	return resp.ID, nil
+}
+
+// runHelper 运行辅助容器执行 cmd 并等待结束，退出码非 0 时返回错误
+func runHelper(ctx context.Context, cli services.DockerService, hostConfig *container.HostConfig, cmd []string) error {
+	id, err := createHelper(ctx, cli, hostConfig, cmd)
+	if err != nil {
+		return err
+	}
+	defer removeHelper(cli, id)
+
+	if err := cli.ContainerStart(ctx, id, types.ContainerStartOptions{}); err != nil {
+		return err
+	}
+	resultC, errC := cli.ContainerWait(ctx, id, container.WaitConditionNotRunning)
+	select {
+	case result := <-resultC:
+		if result.StatusCode != 0 {
+			return fmt.Errorf("helper %s exited with code %d", strings.Join(cmd, " "), result.StatusCode)
+		}
+		return nil
+	case err := <-errC:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// removeHelper 删除辅助容器，请求已取消时仍要清理
+func removeHelper(cli services.DockerService, id string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if err := cli.ContainerRemove(ctx, id, types.ContainerRemoveOptions{Force: true}); err != nil {
+		log.Printf("⚠️ 删除辅助容器 %s 失败: %v", shortID(id), err)
+	}
+}
+
+// volumeRunningUsers 返回挂载该数据卷的运行中容器 ID
+func volumeRunningUsers(ctx context.Context, cli services.DockerService, name string) ([]string, error) {
+	usage, err := volumeUsages(ctx, cli)
+	if err != nil {
+		return nil, err
+	}
+	var ids []string
+	for _, ctr := range usage.containers[name] {
+		if ctr.State == "running" {
+			ids = append(ids, ctr.ID)
+		}
+	}
+	return ids, nil
+}
+
+// withContainersStopped 停止容器后执行 fn，结束后（包括 fn 失败时）重新启动这些容器，返回被停止的容器名
+func withContainersStopped(ctx context.Context, cli services.DockerService, ids []string, fn func() error) ([]string, error) {
+	stopped := []string{}
+	var stoppedIDs []string
+	defer func() {
+		// 请求取消时也要把容器启动回来
+		startCtx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		for _, id := range stoppedIDs {
+			if err := cli.ContainerStart(startCtx, id, types.ContainerStartOptions{}); err != nil {
+				log.Printf("❌ 重新启动容器 %s 失败: %v", shortID(id), err)
+			}
+		}
+	}()
+	for _, id := range ids {
+		info, err := cli.ContainerInspect(ctx, id)
+		if err != nil {
+			return stopped, err
+		}
+		if err := cli.ContainerStop(ctx, id, nil); err != nil {
+			return stopped, fmt.Errorf("stop container %s: %w", info.Name, err)
+		}
+		stoppedIDs = append(stoppedIDs, id)
+		stopped = append(stopped, info.Name)
+	}
+	return stopped, fn()
+}
+
+func toBackupInfo(b services.Backup) models.BackupInfo {
+	info := models.BackupInfo{
+		ID:      b.ID,
+		Kind:    b.Kind,
+		Source:  b.Source,
+		Host:    b.Host,
+		Mounts:  []models.BackupMount{},
+		File:    b.File,
+		Size:    b.Size,
+		SHA256:  b.SHA256,
+		Note:    b.Note,
+		Created: b.CreatedAt.Format(time.RFC3339),
+	}
+	for _, m := range b.Mounts {
+		info.Mounts = append(info.Mounts, models.BackupMount{Type: m.Type, Source: m.Source, Destination: m.Destination})
+	}
+	return info
+}
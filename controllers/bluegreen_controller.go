@@ -0,0 +1,508 @@
+package controllers
+
+import (
+	"auto-deploy-platform/models"
+	"auto-deploy-platform/services"
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/errdefs"
+	"github.com/docker/go-connections/nat"
+	"github.com/gin-gonic/gin"
+)
+
+// 蓝绿部署的容器标签，便于在容器列表中按应用过滤
+const (
+	blueGreenAppLabel   = "adp.bluegreen.app"
+	blueGreenColorLabel = "adp.bluegreen.color"
+)
+
+// blueGreen 由 main 注入的蓝绿部署记录
+var blueGreen *services.BlueGreenStore
+
+// blueGreenMu 同一时间只进行一次部署或回滚，避免两次切换交错占用端口
+var blueGreenMu sync.Mutex
+
+// InitBlueGreen 注入蓝绿部署记录
+func InitBlueGreen(store *services.BlueGreenStore) {
+	blueGreen = store
+}
+
+// ListBlueGreenApps 蓝绿部署应用列表
+// @Summary 蓝绿部署应用列表
+// @Description 列出当前 Docker 主机上做过蓝绿部署的应用：在线的颜色、两个颜色的容器、镜像和状态，以及最近的部署 / 回滚记录
+// @Tags 蓝绿部署
+// @Produce json
+// @Param host query string false "Docker 主机ID，默认使用 default_host"
+// @Success 200 {object} models.BlueGreenListResponse "成功返回应用列表"
+// @Failure 404 {object} models.ErrorResponse "未知 Docker 主机"
+// @Router /bluegreen/apps [get]
+func ListBlueGreenApps(c *gin.Context) {
+	cli, ok := dockerFor(c)
+	if !ok {
+		return
+	}
+	host, _ := dockerHosts.Host(c.Query("host"))
+
+	resp := models.BlueGreenListResponse{Apps: []models.BlueGreenApp{}}
+	for _, rec := range blueGreen.List(host.ID) {
+		resp.Apps = append(resp.Apps, toBlueGreenApp(c.Request.Context(), cli, rec))
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// BlueGreenDeploy 蓝绿部署新版本
+// @Summary 蓝绿部署新版本
+// @Description 按在线容器的配置用新镜像在另一个颜色上部署：先以临时随机端口启动候选容器并等待就绪（有健康检查时为 healthy），通过后停止在线容器，按公开端口重建 <app>-<颜色> 容器并再次确认就绪。原在线容器保持停止，可通过回滚接口立即切回；上一次的备用容器在新版本就绪后才删除。首次部署时 app 为已有容器的名称，该容器成为 blue 并改名为 <app>-blue
+// @Tags 蓝绿部署
+// @Accept json
+// @Produce json
+// @Param deploy body models.BlueGreenDeployRequest true "应用名和新镜像"
+// @Param host query string false "Docker 主机ID，默认使用 default_host"
+// @Success 200 {object} models.BlueGreenResponse "已切换到新版本"
+// @Failure 400 {object} models.ErrorResponse "请求参数错误"
+// @Failure 404 {object} models.ErrorResponse "应用、容器或 Docker 主机不存在"
+// @Failure 409 {object} models.ErrorResponse "已有部署或回滚正在进行，或 <app>-<颜色> 已被其他容器占用"
+// @Failure 500 {object} models.ContainerNotReadyResponse "新版本未就绪，原版本仍在线"
+// @Router /bluegreen/deploy [post]
+func BlueGreenDeploy(c *gin.Context) {
+	var req models.BlueGreenDeployRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request", "detail": err.Error()})
+		return
+	}
+	app := strings.TrimPrefix(strings.TrimSpace(req.App), "/")
+	if app == "" || strings.TrimSpace(req.Image) == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request", "detail": "app and image are required"})
+		return
+	}
+	ready, err := newReadiness(req.HealthTimeout, req.MinUptime)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request", "detail": err.Error()})
+		return
+	}
+	cli, ok := dockerFor(c)
+	if !ok {
+		return
+	}
+	host, _ := dockerHosts.Host(c.Query("host"))
+	if !blueGreenMu.TryLock() {
+		c.JSON(http.StatusConflict, gin.H{"error": "Deploy failed", "detail": "another blue/green deploy or rollback is in progress"})
+		return
+	}
+	defer blueGreenMu.Unlock()
+
+	// 切换中途取消会让应用停在半路，开始后就执行完
+	ctx := context.WithoutCancel(c.Request.Context())
+	rec, live, err := blueGreenLive(ctx, cli, host.ID, app)
+	if err != nil {
+		dockerError(c, "Deploy failed", err)
+		return
+	}
+	target := services.OtherColor(rec.Live)
+	targetName := app + "-" + target
+	image := services.NormalizeImageRef(req.Image)
+
+	// 上一次留下的备用容器，名称被不属于该应用的容器占用时不做任何改动
+	standby, err := cli.ContainerInspect(ctx, targetName)
+	switch {
+	case err == nil:
+		if standby.ID == live.ID {
+			dockerError(c, "Deploy failed", errdefs.Conflict(fmt.Errorf("live container is already named %s", targetName)))
+			return
+		}
+		if !isBlueGreenStandby(rec, target, standby) {
+			dockerError(c, "Deploy failed", errdefs.Conflict(fmt.Errorf("container %s was not created by blue/green deploy of %s", targetName, app)))
+			return
+		}
+	case errdefs.IsNotFound(err):
+		standby = types.ContainerJSON{}
+	default:
+		dockerError(c, "Inspect standby container failed", err)
+		return
+	}
+
+	if req.Pull == nil || *req.Pull {
+		if err := pullImage(ctx, cli, image, nil); err != nil {
+			log.Printf("❌ Pull image %s for %s failed: %v", image, app, err)
+			dockerError(c, "Pull image failed", err)
+			return
+		}
+	}
+	img, _, err := cli.ImageInspectWithRaw(ctx, image)
+	if err != nil {
+		dockerError(c, "Inspect image failed", err)
+		return
+	}
+
+	config, networks := reusableConfig(live)
+	if old, _, err := cli.ImageInspectWithRaw(ctx, live.Image); err == nil {
+		stripImageDefaults(config, old.Config)
+	}
+	config.Image = image
+	labels := make(map[string]string, len(config.Labels)+2)
+	for k, v := range config.Labels {
+		labels[k] = v
+	}
+	labels[blueGreenAppLabel], labels[blueGreenColorLabel] = app, target
+	config.Labels = labels
+	public := *live.HostConfig
+	pinAnonymousVolumes(live, &public)
+
+	// 第一步：候选容器使用随机端口，不带网络别名和静态 IP，验证通过前不接收任何流量
+	temporary := public
+	temporary.PortBindings = temporaryBindings(public.PortBindings)
+	var notReady *models.ContainerNotReadyResponse
+	var temporaryPorts []string
+	candidateID, _, err := replaceContainer(ctx, cli, nil, replacement{
+		name:       targetName + "-candidate",
+		config:     config,
+		hostConfig: &temporary,
+		networks:   candidateEndpoints(networks),
+		verify: func(ctx context.Context, id string) error {
+			if err := waitReady(ctx, cli, id, ready); err != nil {
+				resp := notReadyResponse(cli, id, "New version is not ready", err, false)
+				notReady = &resp
+				return err
+			}
+			if info, err := cli.ContainerInspect(ctx, id); err == nil {
+				temporaryPorts = formatPublishedPorts(info)
+			}
+			return nil
+		},
+	})
+	if err != nil {
+		log.Printf("❌ 蓝绿部署 %s: 新版本 %s 未通过检查，%s 保持在线: %v", app, image, rec.Live, err)
+		blueGreenFailed(c, "Deploy failed", err, notReady)
+		return
+	}
+	if err := cli.ContainerRemove(ctx, candidateID, types.ContainerRemoveOptions{Force: true}); err != nil {
+		dockerError(c, "Remove candidate container failed", err)
+		return
+	}
+
+	// 第二步：上一次留下的备用容器先改名让出名称，停止在线容器，按公开端口重建；
+	// 新容器就绪后才删除备用容器，失败时改回原名，回滚目标始终保留
+	standbyRunning := false
+	if standby.ContainerJSONBase != nil {
+		standbyRunning = standby.State != nil && standby.State.Running
+		if standbyRunning {
+			if err := cli.ContainerStop(ctx, standby.ID, nil); err != nil {
+				dockerError(c, "Stop standby container failed", err)
+				return
+			}
+		}
+		if err := cli.ContainerRename(ctx, standby.ID, fmt.Sprintf("%s-replaced-%s", targetName, shortID(standby.ID))); err != nil {
+			restoreContainer(cli, standby.ID, "", standbyRunning)
+			dockerError(c, "Rename standby container failed", err)
+			return
+		}
+	}
+	restoreStandby := func() {
+		if standby.ContainerJSONBase != nil {
+			restoreContainer(cli, standby.ID, targetName, standbyRunning)
+		}
+	}
+	wasRunning := live.State != nil && live.State.Running
+	if wasRunning {
+		if err := cli.ContainerStop(ctx, live.ID, nil); err != nil {
+			restoreStandby()
+			dockerError(c, "Stop live container failed", err)
+			return
+		}
+	}
+	notReady = nil
+	newID, _, err := replaceContainer(ctx, cli, nil, replacement{
+		name:       targetName,
+		config:     config,
+		hostConfig: &public,
+		networks:   networks,
+		verify: func(ctx context.Context, id string) error {
+			if err := waitReady(ctx, cli, id, ready); err != nil {
+				resp := notReadyResponse(cli, id, "New version is not ready", err, false)
+				notReady = &resp
+				return err
+			}
+			return nil
+		},
+	})
+	if err != nil {
+		log.Printf("❌ 蓝绿部署 %s: %s 未能在公开端口就绪，恢复 %s: %v", app, targetName, rec.Live, err)
+		if wasRunning {
+			restoreContainer(cli, live.ID, "", true)
+		}
+		restoreStandby()
+		blueGreenFailed(c, "Deploy failed", err, notReady)
+		return
+	}
+	if standby.ContainerJSONBase != nil {
+		if err := cli.ContainerRemove(ctx, standby.ID, types.ContainerRemoveOptions{Force: true}); err != nil {
+			log.Printf("⚠️ 删除旧的备用容器 %s 失败: %v", shortID(standby.ID), err)
+		}
+	}
+
+	now := time.Now()
+	if slot := *rec.Slot(rec.Live); slot.Container != app+"-"+rec.Live {
+		// 首次部署接管的容器改名为 <app>-blue，失败时保留原名
+		if err := cli.ContainerRename(ctx, live.ID, app+"-"+rec.Live); err != nil {
+			log.Printf("⚠️ 容器 %s 改名为 %s 失败: %v", slot.Container, app+"-"+rec.Live, err)
+		} else {
+			slot.Container = app + "-" + rec.Live
+			rec.SetSlot(rec.Live, &slot)
+		}
+	}
+	rec.SetSlot(target, &services.BlueGreenSlot{Container: targetName, ContainerID: newID, Image: image, ImageID: img.ID, DeployedAt: now})
+	rec.Record("deploy", target, image, now)
+	if err := blueGreen.Put(rec); err != nil {
+		log.Printf("❌ 保存蓝绿部署记录失败: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Switched to " + target + " but failed to save deploy record", "detail": err.Error()})
+		return
+	}
+	log.Printf("蓝绿部署 %s: %s 已上线 (%s)，%s 已停止备用", app, target, image, services.OtherColor(target))
+	c.JSON(http.StatusOK, models.BlueGreenResponse{
+		Code:           200,
+		Message:        "Switched to " + target,
+		App:            toBlueGreenApp(ctx, cli, rec),
+		TemporaryPorts: temporaryPorts,
+	})
+}
+
+// BlueGreenRollback 切回另一个颜色
+// @Summary 蓝绿回滚
+// @Description 停止在线容器，启动保持停止的另一个颜色的容器并等待就绪，无需重新创建容器。备用容器未就绪时恢复原在线容器
+// @Tags 蓝绿部署
+// @Accept json
+// @Produce json
+// @Param rollback body models.BlueGreenRollbackRequest true "应用名"
+// @Param host query string false "Docker 主机ID，默认使用 default_host"
+// @Success 200 {object} models.BlueGreenResponse "已切回"
+// @Failure 400 {object} models.ErrorResponse "请求参数错误"
+// @Failure 404 {object} models.ErrorResponse "应用、备用容器或 Docker 主机不存在"
+// @Failure 409 {object} models.ErrorResponse "没有可回滚的版本或已有部署正在进行"
+// @Failure 500 {object} models.ContainerNotReadyResponse "备用容器未就绪，原版本仍在线"
+// @Router /bluegreen/rollback [post]
+func BlueGreenRollback(c *gin.Context) {
+	var req models.BlueGreenRollbackRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request", "detail": err.Error()})
+		return
+	}
+	app := strings.TrimPrefix(strings.TrimSpace(req.App), "/")
+	if app == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request", "detail": "app is required"})
+		return
+	}
+	ready, err := newReadiness(req.HealthTimeout, req.MinUptime)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request", "detail": err.Error()})
+		return
+	}
+	cli, ok := dockerFor(c)
+	if !ok {
+		return
+	}
+	host, _ := dockerHosts.Host(c.Query("host"))
+	if !blueGreenMu.TryLock() {
+		c.JSON(http.StatusConflict, gin.H{"error": "Rollback failed", "detail": "another blue/green deploy or rollback is in progress"})
+		return
+	}
+	defer blueGreenMu.Unlock()
+
+	ctx := context.WithoutCancel(c.Request.Context())
+	rec, err := blueGreen.Get(host.ID, app)
+	if err != nil {
+		dockerError(c, "Rollback failed", err)
+		return
+	}
+	to := services.OtherColor(rec.Live)
+	standby := rec.Slot(to)
+	if standby == nil {
+		dockerError(c, "Rollback failed", errdefs.Conflict(fmt.Errorf("app %s has no %s version to roll back to", app, to)))
+		return
+	}
+	if _, err := cli.ContainerInspect(ctx, standby.ContainerID); err != nil {
+		dockerError(c, "Standby container "+standby.Container+" is gone", err)
+		return
+	}
+
+	live := rec.Slot(rec.Live)
+	liveRunning := false
+	if info, err := cli.ContainerInspect(ctx, live.ContainerID); err == nil && info.State != nil && info.State.Running {
+		liveRunning = true
+		if err := cli.ContainerStop(ctx, live.ContainerID, nil); err != nil {
+			dockerError(c, "Stop live container failed", err)
+			return
+		}
+	}
+	restoreLive := func() {
+		stopCtx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		if err := cli.ContainerStop(stopCtx, standby.ContainerID, nil); err != nil {
+			log.Printf("⚠️ 停止备用容器 %s 失败: %v", standby.Container, err)
+		}
+		if liveRunning {
+			restoreContainer(cli, live.ContainerID, "", true)
+		}
+	}
+	if err := cli.ContainerStart(ctx, standby.ContainerID, types.ContainerStartOptions{}); err != nil {
+		restoreLive()
+		dockerError(c, "Start standby container failed", err)
+		return
+	}
+	if err := waitReady(ctx, cli, standby.ContainerID, ready); err != nil {
+		resp := notReadyResponse(cli, standby.ContainerID, "Standby version is not ready", err, false)
+		restoreLive()
+		log.Printf("❌ 蓝绿回滚 %s: %s 未就绪，恢复 %s: %v", app, to, rec.Live, err)
+		c.JSON(http.StatusInternalServerError, resp)
+		return
+	}
+
+	rec.Record("rollback", to, standby.Image, time.Now())
+	if err := blueGreen.Put(rec); err != nil {
+		log.Printf("❌ 保存蓝绿部署记录失败: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Switched to " + to + " but failed to save deploy record", "detail": err.Error()})
+		return
+	}
+	log.Printf("蓝绿回滚 %s: %s 已上线 (%s)", app, to, standby.Image)
+	c.JSON(http.StatusOK, models.BlueGreenResponse{Code: 200, Message: "Switched to " + to, App: toBlueGreenApp(ctx, cli, rec)})
+}
+
+// blueGreenLive 返回应用的部署记录和在线容器；首次部署时接管名为 app 的已有容器作为 blue，记录在部署成功后才保存
+func blueGreenLive(ctx context.Context, cli services.DockerService, host, app string) (services.BlueGreenApp, types.ContainerJSON, error) {
+	rec, err := blueGreen.Get(host, app)
+	if err == nil {
+		info, err := cli.ContainerInspect(ctx, rec.Slot(rec.Live).ContainerID)
+		return rec, info, err
+	}
+	if !errdefs.IsNotFound(err) {
+		return rec, types.ContainerJSON{}, err
+	}
+
+	info, err := cli.ContainerInspect(ctx, app)
+	if err != nil {
+		return rec, info, err
+	}
+	mode := string(info.HostConfig.NetworkMode)
+	if mode == "host" || strings.HasPrefix(mode, "container:") {
+		return rec, info, errdefs.InvalidParameter(fmt.Errorf("container %s uses network mode %s, blue/green needs published ports", app, mode))
+	}
+	img, _, _ := cli.ImageInspectWithRaw(ctx, info.Image)
+	created, _ := time.Parse(time.RFC3339Nano, info.Created)
+	rec = services.BlueGreenApp{Host: host, App: app, Live: services.ColorBlue, History: []services.BlueGreenEvent{}}
+	rec.SetSlot(services.ColorBlue, &services.BlueGreenSlot{
+		Container:   strings.TrimPrefix(info.Name, "/"),
+		ContainerID: info.ID,
+		Image:       info.Config.Image,
+		ImageID:     img.ID,
+		DeployedAt:  created,
+	})
+	return rec, info, nil
+}
+
+// isBlueGreenStandby 判断占用 <app>-<颜色> 名称的容器是否为该应用的备用容器：与记录一致或带有该应用的标签
+func isBlueGreenStandby(rec services.BlueGreenApp, color string, info types.ContainerJSON) bool {
+	if slot := rec.Slot(color); slot != nil && slot.ContainerID == info.ID {
+		return true
+	}
+	return info.Config != nil && info.Config.Labels[blueGreenAppLabel] == rec.App
+}
+
+// blueGreenFailed 输出部署失败的响应：容器未就绪时附带状态和日志
+func blueGreenFailed(c *gin.Context, message string, err error, notReady *models.ContainerNotReadyResponse) {
+	if notReady == nil {
+		dockerError(c, message, err)
+		return
+	}
+	notReady.Removed = true // 未就绪的容器已由 replaceContainer 删除
+	c.JSON(http.StatusInternalServerError, notReady)
+}
+
+// temporaryBindings 去掉宿主机端口，由 Docker 分配随机端口，保留监听地址
+func temporaryBindings(bindings nat.PortMap) nat.PortMap {
+	if len(bindings) == 0 {
+		return nil
+	}
+	temporary := make(nat.PortMap, len(bindings))
+	for port, list := range bindings {
+		for _, b := range list {
+			temporary[port] = append(temporary[port], nat.PortBinding{HostIP: b.HostIP})
+		}
+	}
+	return temporary
+}
+
+// candidateEndpoints 候选容器接入相同的网络，但不使用别名和静态 IP，它们仍属于在线容器
+func candidateEndpoints(networks map[string]*network.EndpointSettings) map[string]*network.EndpointSettings {
+	candidate := make(map[string]*network.EndpointSettings, len(networks))
+	for name, ep := range networks {
+		candidate[name] = &network.EndpointSettings{Links: ep.Links, DriverOpts: ep.DriverOpts}
+	}
+	return candidate
+}
+
+// formatPublishedPorts 以 docker ps 的格式列出容器实际发布的端口
+func formatPublishedPorts(info types.ContainerJSON) []string {
+	var ports []string
+	if info.NetworkSettings == nil {
+		return ports
+	}
+	for port, bindings := range info.NetworkSettings.Ports {
+		for _, b := range bindings {
+			hostIP := b.HostIP
+			if hostIP == "" {
+				hostIP = "0.0.0.0"
+			}
+			ports = append(ports, fmt.Sprintf("%s:%s->%s", hostIP, b.HostPort, port))
+		}
+	}
+	sort.Strings(ports)
+	return ports
+}
+
+func toBlueGreenApp(ctx context.Context, cli services.DockerService, rec services.BlueGreenApp) models.BlueGreenApp {
+	app := models.BlueGreenApp{
+		App:       rec.App,
+		Host:      rec.Host,
+		Live:      rec.Live,
+		Slots:     []models.BlueGreenSlot{},
+		History:   []models.BlueGreenEvent{},
+		UpdatedAt: rec.UpdatedAt.Format(time.RFC3339),
+	}
+	for _, color := range []string{services.ColorBlue, services.ColorGreen} {
+		slot := rec.Slot(color)
+		if slot == nil {
+			continue
+		}
+		state := "missing"
+		info, err := cli.ContainerInspect(ctx, slot.ContainerID)
+		switch {
+		case err == nil && info.State != nil:
+			state = info.State.Status
+		case err != nil && !errdefs.IsNotFound(err):
+			state = "unknown"
+		}
+		app.Slots = append(app.Slots, models.BlueGreenSlot{
+			Color:       color,
+			Live:        color == rec.Live,
+			Container:   slot.Container,
+			ContainerID: shortID(slot.ContainerID),
+			Image:       slot.Image,
+			ImageID:     slot.ImageID,
+			State:       state,
+			DeployedAt:  slot.DeployedAt.Format(time.RFC3339),
+		})
+	}
+	for i := len(rec.History) - 1; i >= 0; i-- {
+		e := rec.History[i]
+		app.History = append(app.History, models.BlueGreenEvent{Action: e.Action, From: e.From, To: e.To, Image: e.Image, At: e.At.Format(time.RFC3339)})
+	}
+	return app
+}
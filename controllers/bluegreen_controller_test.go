@@ -0,0 +1,93 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"auto-deploy-platform/models"
+	"auto-deploy-platform/services"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/gin-gonic/gin"
+)
+
+// useBlueGreen 以临时记录文件初始化蓝绿部署，返回注册了部署和回滚接口的路由
+func useBlueGreen(t *testing.T) *gin.Engine {
+	t.Helper()
+	store, err := services.NewBlueGreenStore(filepath.Join(t.TempDir(), "bluegreen.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	old := blueGreen
+	InitBlueGreen(store)
+	t.Cleanup(func() { blueGreen = old })
+
+	r := gin.New()
+	r.POST("/bluegreen/deploy", BlueGreenDeploy)
+	r.POST("/bluegreen/rollback", BlueGreenRollback)
+	return r
+}
+
+func TestBlueGreenDeployKeepsUnrelatedContainer(t *testing.T) {
+	fake := useFakeDocker(t)
+	r := useBlueGreen(t)
+	markHealthy(t, fake)
+
+	liveID := runContainer(t, fake, "api", "nginx:1.26", nil)
+	fake.AddImage("nginx:1.27")
+	created, err := fake.ContainerCreate(context.Background(), &container.Config{Image: "nginx:1.27"}, nil, nil, nil, "api-green")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decode(t, serve(r, http.MethodPost, "/bluegreen/deploy", `{"app":"api","image":"nginx:1.27","pull":false}`), http.StatusConflict, nil)
+
+	if _, ok := fake.Container(created.ID); !ok {
+		t.Error("unrelated container api-green was removed")
+	}
+	info, err := fake.ContainerInspect(context.Background(), "api")
+	if err != nil || info.ID != liveID || !info.State.Running {
+		t.Errorf("live container after conflict = %+v, %v, want %s still running", info.ContainerJSONBase, err, liveID)
+	}
+}
+
+func TestBlueGreenFailedDeployKeepsStandby(t *testing.T) {
+	fake := useFakeDocker(t)
+	r := useBlueGreen(t)
+	markHealthy(t, fake)
+
+	blueID := runContainer(t, fake, "web", "nginx:1.26", nil)
+	fake.AddImage("nginx:1.27")
+	fake.AddImage("nginx:1.28")
+	var resp models.BlueGreenResponse
+	decode(t, serve(r, http.MethodPost, "/bluegreen/deploy", `{"app":"web","image":"nginx:1.27","pull":false}`), http.StatusOK, &resp)
+	if resp.App.Live != services.ColorGreen {
+		t.Fatalf("live = %s, want green", resp.App.Live)
+	}
+
+	// 停止在线容器失败时，已改名让出的备用容器 web-blue 必须改回原名
+	fake.SetError("ContainerStop", errors.New("stop timed out"))
+	decode(t, serve(r, http.MethodPost, "/bluegreen/deploy", `{"app":"web","image":"nginx:1.28","pull":false}`), http.StatusInternalServerError, nil)
+	fake.SetError("ContainerStop", nil)
+
+	info, err := fake.ContainerInspect(context.Background(), "web-blue")
+	if err != nil || info.ID != blueID {
+		t.Fatalf("standby after failed deploy = %v, want web-blue (%s)", err, blueID)
+	}
+	list, err := fake.ContainerList(context.Background(), types.ContainerListOptions{All: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 2 {
+		t.Errorf("containers after failed deploy = %d, want web-blue and web-green", len(list))
+	}
+
+	decode(t, serve(r, http.MethodPost, "/bluegreen/rollback", `{"app":"web"}`), http.StatusOK, &resp)
+	if resp.App.Live != services.ColorBlue {
+		t.Errorf("live after rollback = %s, want blue", resp.App.Live)
+	}
+}
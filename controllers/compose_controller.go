@@ -9,13 +9,44 @@ import (
 	"path/filepath"
 
 	"github.com/docker/docker/api/types"
-	"github.com/docker/docker/client"
 	"github.com/gin-gonic/gin"
 	"github.com/gorilla/websocket"
 )
 
 var composeBasePath = "./compose-files" // 📁 Compose 文件存储目录
 
+// composeProjectLabel docker-compose 为容器、网络和数据卷打上的项目名标签
+const composeProjectLabel = "com.docker.compose.project"
+
+// composeCommand 构造指定主机上的 docker-compose 命令，远程主机通过 -H / --tls* 全局参数传入
+func composeCommand(c *gin.Context, dir string, args ...string) (*exec.Cmd, bool) {
+	host, err := dockerHosts.Host(c.Query("host"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Unknown docker host", "detail": err.Error()})
+		return nil, false
+	}
+
+	var global []string
+	if host.Endpoint != "" {
+		global = append(global, "-H", host.Endpoint)
+	}
+	if host.TLSCA != "" {
+		global = append(global, "--tlsverify", "--tlscacert", host.TLSCA)
+	} else if host.TLSCert != "" || host.TLSKey != "" {
+		global = append(global, "--tls")
+	}
+	if host.TLSCert != "" {
+		global = append(global, "--tlscert", host.TLSCert)
+	}
+	if host.TLSKey != "" {
+		global = append(global, "--tlskey", host.TLSKey)
+	}
+
+	cmd := exec.Command("docker-compose", append(global, args...)...)
+	cmd.Dir = dir
+	return cmd, true
+}
+
 // UploadCompose 上传 Docker Compose 文件
 // @Summary 上传 Compose 文件
 // @Description 上传并保存 Docker Compose 文件
@@ -71,13 +102,14 @@ func ListCompose(c *gin.Context) {
 // @Description 查看各 Compose 应用包含的容器及运行状态
 // @Tags Compose管理
 // @Produce json
+// @Param host query string false "Docker 主机ID，默认使用 default_host"
 // @Success 200 {object} models.ComposeStatusResponse "成功返回 Compose 容器状态"
+// @Failure 404 {object} models.ErrorResponse "未知 Docker 主机"
 // @Failure 500 {object} models.ErrorResponse "Docker client 初始化或容器列表失败"
 // @Router /compose/status [get]
 func ComposeStatus(c *gin.Context) {
-	cli, err := client.NewClientWithOpts(client.FromEnv)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Docker client failed"})
+	cli, ok := dockerFor(c)
+	if !ok {
 		return
 	}
 
@@ -89,7 +121,7 @@ func ComposeStatus(c *gin.Context) {
 
 	composeApps := make(map[string][]gin.H)
 	for _, container := range containers {
-		project := container.Labels["com.docker.compose.project"]
+		project := container.Labels[composeProjectLabel]
 		if project == "" {
 			continue
 		}
@@ -99,7 +131,7 @@ func ComposeStatus(c *gin.Context) {
 		}
 		composeApps[project] = append(composeApps[project], gin.H{
 			"id":     container.ID[:12],
-			"name":   container.Names[0],
+			"name":   containerName(container.Names),
 			"image":  container.Image,
 			"status": container.Status,
 			"ports":  portStr,
@@ -136,6 +168,7 @@ func ComposeStatus(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param compose body models.ComposeActionRequest true "Compose 应用名称"
+// @Param host query string false "Docker 主机ID，默认使用 default_host"
 // @Success 200 {object} models.SuccessResponse "启动成功"
 // @Failure 400 {object} models.ErrorResponse "参数错误"
 // @Failure 500 {object} models.ErrorResponse "启动失败"
@@ -144,8 +177,11 @@ func StartCompose(c *gin.Context) {
 	var req struct{ Name string }
 	c.BindJSON(&req)
 	dir := filepath.Join(composeBasePath, req.Name)
-	cmd := exec.Command("docker-compose", "up", "-d")
-	cmd.Dir = dir
+	cmd, ok := composeCommand(c, dir, "up", "-d")
+	if !ok {
+		return
+	}
+	defer withRegistryConfig(cmd)()
 	cmd.Run()
 	c.JSON(http.StatusOK, gin.H{"message": "Started"})
 }
@@ -157,6 +193,7 @@ func StartCompose(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param compose body models.ComposeActionRequest true "Compose 应用名称"
+// @Param host query string false "Docker 主机ID，默认使用 default_host"
 // @Success 200 {object} models.SuccessResponse "停止成功"
 // @Failure 400 {object} models.ErrorResponse "参数错误"
 // @Failure 500 {object} models.ErrorResponse "停止失败"
@@ -165,8 +202,10 @@ func StopCompose(c *gin.Context) {
 	var req struct{ Name string }
 	c.BindJSON(&req)
 	dir := filepath.Join(composeBasePath, req.Name)
-	cmd := exec.Command("docker-compose", "down")
-	cmd.Dir = dir
+	cmd, ok := composeCommand(c, dir, "down")
+	if !ok {
+		return
+	}
 	cmd.Run()
 	c.JSON(http.StatusOK, gin.H{"message": "Stopped"})
 }
@@ -190,21 +229,26 @@ func DeleteCompose(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "Deleted"})
 }
 
+var upgrader = websocket.Upgrader{CheckOrigin: func(r *http.Request) bool { return true }}
+
 // ComposeLogsWS 获取 Compose 应用日志 WebSocket
 // @Summary 获取 Compose 应用日志
 // @Description 通过 WebSocket 连接实时获取指定 Compose 应用的日志流
 // @Tags Compose管理
 // @Produce plain
 // @Param name query string true "Compose 应用名称"
+// @Param host query string false "Docker 主机ID，默认使用 default_host"
 // @Success 101 {string} string "WebSocket 连接已建立，开始推送日志"
 // @Failure 400 {object} models.ErrorResponse "参数错误"
 // @Failure 500 {object} models.ErrorResponse "WebSocket 升级失败 或 日志启动失败"
 // @Router /compose/logs/ws [get]
-var upgrader = websocket.Upgrader{CheckOrigin: func(r *http.Request) bool { return true }}
-
 func ComposeLogsWS(c *gin.Context) {
 	name := c.Query("name")
 	dir := filepath.Join(composeBasePath, name)
+	cmd, ok := composeCommand(c, dir, "logs", "-f")
+	if !ok {
+		return
+	}
 
 	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
 	if err != nil {
@@ -212,8 +256,6 @@ func ComposeLogsWS(c *gin.Context) {
 	}
 	defer conn.Close()
 
-	cmd := exec.Command("docker-compose", "logs", "-f")
-	cmd.Dir = dir
 	stdout, _ := cmd.StdoutPipe()
 	cmd.Start()
 
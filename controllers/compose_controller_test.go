@@ -0,0 +1,101 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/go-connections/nat"
+	"github.com/gin-gonic/gin"
+)
+
+type composeStatusApp struct {
+	Name       string `json:"name"`
+	Status     string `json:"status"`
+	Containers []struct {
+		ID    string `json:"id"`
+		Name  string `json:"name"`
+		Image string `json:"image"`
+		Ports string `json:"ports"`
+	} `json:"containers"`
+}
+
+// useComposeDir 把 Compose 文件目录指向临时目录并为每个应用建立子目录
+func useComposeDir(t *testing.T, projects ...string) {
+	t.Helper()
+	dir := t.TempDir()
+	for _, p := range projects {
+		if err := os.Mkdir(filepath.Join(dir, p), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	old := composeBasePath
+	composeBasePath = dir
+	t.Cleanup(func() { composeBasePath = old })
+}
+
+func TestComposeStatus(t *testing.T) {
+	fake := useFakeDocker(t)
+	useComposeDir(t, "blog", "shop")
+	fake.AddImage("nginx:1.25")
+	fake.AddImage("mysql:8")
+
+	ctx := context.Background()
+	web, err := fake.ContainerCreate(ctx, &container.Config{Image: "nginx:1.25", Labels: map[string]string{composeProjectLabel: "blog"}},
+		&container.HostConfig{PortBindings: nat.PortMap{"80/tcp": {{HostPort: "8080"}}}}, nil, nil, "blog-web-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	db, err := fake.ContainerCreate(ctx, &container.Config{Image: "mysql:8", Labels: map[string]string{composeProjectLabel: "blog"}}, nil, nil, nil, "blog-db-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fake.ContainerStart(ctx, web.ID, types.ContainerStartOptions{})
+	fake.ContainerStart(ctx, db.ID, types.ContainerStartOptions{})
+	// 不属于任何 Compose 应用或应用目录已删除的容器不出现在结果中
+	fake.AddContainer(types.Container{Names: []string{"/standalone"}, Image: "busybox", State: "running"})
+	fake.AddContainer(types.Container{Names: []string{"/old-app-1"}, Image: "busybox", State: "running", Labels: map[string]string{composeProjectLabel: "old"}})
+
+	r := gin.New()
+	r.GET("/compose/status", ComposeStatus)
+
+	var resp struct {
+		Apps []composeStatusApp `json:"apps"`
+	}
+	decode(t, serve(r, http.MethodGet, "/compose/status", ""), http.StatusOK, &resp)
+	if len(resp.Apps) != 2 {
+		t.Fatalf("apps = %+v", resp.Apps)
+	}
+	blog, shop := resp.Apps[0], resp.Apps[1]
+	if blog.Name != "blog" || blog.Status != "Running (2/2)" || len(blog.Containers) != 2 {
+		t.Errorf("blog = %+v", blog)
+	}
+	for _, ctr := range blog.Containers {
+		if len(ctr.ID) != 12 {
+			t.Errorf("id = %q", ctr.ID)
+		}
+		if ctr.Name == "blog-web-1" && ctr.Ports != "8080:80 " {
+			t.Errorf("web ports = %q", ctr.Ports)
+		}
+	}
+	if shop.Name != "shop" || shop.Status != "Not Running" || len(shop.Containers) != 0 {
+		t.Errorf("shop = %+v", shop)
+	}
+}
+
+func TestComposeStatusErrors(t *testing.T) {
+	fake := useFakeDocker(t)
+	useComposeDir(t, "blog")
+	r := gin.New()
+	r.GET("/compose/status", ComposeStatus)
+
+	decode(t, serve(r, http.MethodGet, "/compose/status?host=nowhere", ""), http.StatusNotFound, nil)
+
+	fake.Errors["ContainerList"] = errors.New("daemon unavailable")
+	decode(t, serve(r, http.MethodGet, "/compose/status", ""), http.StatusInternalServerError, nil)
+}
@@ -0,0 +1,626 @@
+package controllers
+
+import (
+	"auto-deploy-platform/models"
+	"auto-deploy-platform/services"
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/go-connections/nat"
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	// 与 docker 的容器名规则一致
+	containerNamePattern = regexp.MustCompile(`^/?[a-zA-Z0-9][a-zA-Z0-9_.-]+$`)
+	capabilityPattern    = regexp.MustCompile(`^(CAP_)?[A-Z_]+$`)
+)
+
+// Docker 预置网络，不支持别名和静态 IP，也不能删除
+var predefinedNetworks = map[string]bool{"bridge": true, "host": true, "none": true}
+
+var restartPolicies = map[string]bool{"": true, "no": true, "always": true, "unless-stopped": true, "on-failure": true}
+
+// CreateContainerV2 创建容器（结构化请求）
+// @Summary 创建容器 (v2)
+// @Description 使用结构化参数创建并启动容器：端口支持协议、宿主机 IP 和范围，挂载支持 bind / volume / tmpfs 及只读，另可设置命令、入口、标签、用户、工作目录、capabilities 和健康检查。所有字段先做校验，校验失败时返回全部错误字段且不会调用 Docker。wait=true 时等待容器运行并在配置了健康检查时等到 healthy 再返回，容器退出、unhealthy 或超时时返回退出码和最后几行日志，remove_on_failure=true 时同时删除该容器
+// @Tags 容器管理
+// @Accept json
+// @Produce json
+// @Param container body models.CreateContainerV2Request true "创建容器请求参数"
+// @Param host query string false "Docker 主机ID，默认使用 default_host"
+// @Success 200 {object} models.CreateContainerResponse "创建成功返回容器ID"
+// @Failure 400 {object} models.ValidationErrorResponse "请求参数错误"
+// @Failure 401 {object} models.ErrorResponse "私有仓库认证失败"
+// @Failure 404 {object} models.ErrorResponse "未知 Docker 主机"
+// @Failure 409 {object} models.ErrorResponse "容器名已被占用"
+// @Failure 500 {object} models.ContainerNotReadyResponse "容器启动失败或未就绪"
+// @Router /api/v2/container/create [post]
+func CreateContainerV2(c *gin.Context) {
+	var req models.CreateContainerV2Request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request", "detail": err.Error()})
+		return
+	}
+	id, ok := createContainer(c, req)
+	if !ok {
+		return
+	}
+	c.JSON(http.StatusOK, models.CreateContainerResponse{Code: 200, Message: "Container created", ID: id})
+}
+
+// containerSpec 创建容器所需的 Docker 配置。Docker 创建容器时只能接入一个网络，
+// 其余网络 (extraNetworks) 在启动前逐个接入
+type containerSpec struct {
+	config           *container.Config
+	hostConfig       *container.HostConfig
+	networkingConfig *network.NetworkingConfig
+	extraNetworks    []networkEndpoint
+	wait             *readiness // 非空时启动后等待容器就绪
+	removeOnFailure  bool
+}
+
+type networkEndpoint struct {
+	name     string
+	endpoint *network.EndpointSettings
+}
+
+// createContainer 校验请求、按需拉取镜像，然后创建并启动容器，返回短 ID；失败时已写入响应
+func createContainer(c *gin.Context, req models.CreateContainerV2Request) (string, bool) {
+	spec, errs := buildContainerConfig(req)
+	if len(errs) > 0 {
+		c.JSON(http.StatusBadRequest, models.ValidationErrorResponse{Error: "Invalid request", Fields: errs})
+		return "", false
+	}
+
+	cli, ok := dockerFor(c)
+	if !ok {
+		return "", false
+	}
+
+	// 请求断开时取消镜像拉取
+	ctx := c.Request.Context()
+
+	if _, _, err := cli.ImageInspectWithRaw(ctx, spec.config.Image); err != nil {
+		log.Printf("镜像不存在，本地拉取: %s", spec.config.Image)
+
+		if pullErr := pullImage(ctx, cli, spec.config.Image, nil); pullErr != nil {
+			log.Printf("❌ Image pull failed: %v", pullErr)
+			dockerError(c, "Pull image failed", pullErr)
+			return "", false
+		}
+		log.Println("镜像拉取完成！")
+	}
+
+	resp, err := cli.ContainerCreate(ctx, spec.config, spec.hostConfig, spec.networkingConfig, nil, strings.TrimPrefix(req.Name, "/"))
+	if err != nil {
+		log.Printf("❌ Container create failed: %v", err)
+		dockerError(c, "Create failed", err)
+		return "", false
+	}
+
+	for _, n := range spec.extraNetworks {
+		if err := cli.NetworkConnect(ctx, n.name, resp.ID, n.endpoint); err != nil {
+			log.Printf("❌ Connect network %s failed: %v", n.name, err)
+			cli.ContainerRemove(context.Background(), resp.ID, types.ContainerRemoveOptions{Force: true})
+			dockerError(c, "Connect network "+n.name+" failed", err)
+			return "", false
+		}
+	}
+
+	if err := cli.ContainerStart(ctx, resp.ID, types.ContainerStartOptions{}); err != nil {
+		log.Printf("❌ Container start failed: %v", err)
+		c.JSON(http.StatusInternalServerError, notReadyResponse(cli, resp.ID, "Container created but failed to start", err, spec.removeOnFailure))
+		return "", false
+	}
+	if spec.wait != nil {
+		if err := waitReady(ctx, cli, resp.ID, *spec.wait); err != nil {
+			log.Printf("❌ Container %s not ready: %v", shortID(resp.ID), err)
+			c.JSON(http.StatusInternalServerError, notReadyResponse(cli, resp.ID, "Container is not ready", err, spec.removeOnFailure))
+			return "", false
+		}
+	}
+	return resp.ID[:12], true
+}
+
+// notReadyLogLines 启动失败时附带的日志行数
+const notReadyLogLines = 20
+
+// notReadyResponse 收集启动失败或未就绪容器的状态、退出码和最后几行日志，removeContainer 时随后删除容器。
+// 请求可能已断开，使用独立的 context
+func notReadyResponse(cli services.DockerService, id, message string, cause error, removeContainer bool) models.ContainerNotReadyResponse {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	resp := models.ContainerNotReadyResponse{Error: message, Detail: cause.Error(), ID: shortID(id), Logs: []string{}}
+
+	info, err := cli.ContainerInspect(ctx, id)
+	if err == nil && info.State != nil {
+		resp.Status, resp.ExitCode = info.State.Status, info.State.ExitCode
+		if info.State.Health != nil {
+			resp.Health = info.State.Health.Status
+		}
+		options := types.ContainerLogsOptions{ShowStdout: true, ShowStderr: true, Tail: strconv.Itoa(notReadyLogLines)}
+		err = streamContainerLogs(ctx, cli, info, options, func(f LogFrame) {
+			if f.Stream == "stderr" {
+				f.Text = "stderr: " + f.Text
+			}
+			resp.Logs = append(resp.Logs, f.Text)
+		})
+	}
+	if err != nil {
+		log.Printf("⚠️ 读取容器 %s 的状态和日志失败: %v", shortID(id), err)
+	}
+
+	if removeContainer {
+		if err := cli.ContainerRemove(ctx, id, types.ContainerRemoveOptions{Force: true, RemoveVolumes: true}); err != nil {
+			log.Printf("❌ Remove failed container %s failed: %v", shortID(id), err)
+		} else {
+			resp.Removed = true
+		}
+	}
+	return resp
+}
+
+// buildContainerConfig 校验请求并转换为 Docker API 配置，收集全部字段错误一并返回
+func buildContainerConfig(req models.CreateContainerV2Request) (*containerSpec, []models.FieldError) {
+	var errs []models.FieldError
+	fail := func(field, format string, args ...interface{}) {
+		errs = append(errs, models.FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+
+	config := &container.Config{
+		Image:        services.NormalizeImageRef(req.Image),
+		Cmd:          req.Command,
+		Entrypoint:   req.Entrypoint,
+		WorkingDir:   req.WorkingDir,
+		User:         req.User,
+		Hostname:     req.Hostname,
+		Labels:       req.Labels,
+		ExposedPorts: nat.PortSet{},
+	}
+	hostConfig := &container.HostConfig{
+		PortBindings: nat.PortMap{},
+		NetworkMode:  container.NetworkMode(req.Network),
+		CapAdd:       req.CapAdd,
+		CapDrop:      req.CapDrop,
+		Privileged:   req.Privileged,
+	}
+
+	if strings.TrimSpace(req.Image) == "" {
+		fail("image", "image is required")
+	}
+	if req.Name != "" && !containerNamePattern.MatchString(req.Name) {
+		fail("name", "invalid container name %q, only [a-zA-Z0-9][a-zA-Z0-9_.-] are allowed", req.Name)
+	}
+	if req.WorkingDir != "" && !path.IsAbs(req.WorkingDir) {
+		fail("working_dir", "working_dir must be an absolute path")
+	}
+
+	// 环境变量
+	for i, e := range req.Env {
+		if e.Name == "" || strings.ContainsAny(e.Name, "= ") {
+			fail(fmt.Sprintf("env[%d].name", i), "invalid env name %q", e.Name)
+			continue
+		}
+		config.Env = append(config.Env, e.Name+"="+e.Value)
+	}
+
+	// 标签
+	for k := range req.Labels {
+		if strings.TrimSpace(k) == "" {
+			fail("labels", "label key must not be empty")
+		}
+	}
+
+	// 网络：第一个网络作为 NetworkMode 在创建时接入，其余在启动前接入
+	networkingConfig := &network.NetworkingConfig{}
+	var extraNetworks []networkEndpoint
+	if len(req.Networks) > 0 {
+		switch {
+		case req.Network == "host" || req.Network == "none" || strings.HasPrefix(req.Network, "container:"):
+			fail("networks", "networks cannot be combined with network mode %s", req.Network)
+		case req.Network != "" && req.Network != req.Networks[0].Name:
+			fail("network", "network must be empty or equal to networks[0].name")
+		}
+		seen := map[string]bool{}
+		for i, n := range req.Networks {
+			field := fmt.Sprintf("networks[%d]", i)
+			ep, ok := buildEndpoint(field, n, fail)
+			if !ok {
+				continue
+			}
+			if seen[n.Name] {
+				fail(field+".name", "duplicate network %s", n.Name)
+				continue
+			}
+			seen[n.Name] = true
+			if i == 0 {
+				hostConfig.NetworkMode = container.NetworkMode(n.Name)
+				networkingConfig.EndpointsConfig = map[string]*network.EndpointSettings{n.Name: ep}
+			} else {
+				extraNetworks = append(extraNetworks, networkEndpoint{name: n.Name, endpoint: ep})
+			}
+		}
+	}
+
+	// 端口映射
+	if len(req.Ports) > 0 && (req.Network == "host" || len(req.Networks) > 0 && req.Networks[0].Name == "host") {
+		fail("ports", "port mappings are not supported with host network")
+	}
+	for i, p := range req.Ports {
+		field := fmt.Sprintf("ports[%d]", i)
+		proto := strings.ToLower(p.Protocol)
+		if proto == "" {
+			proto = "tcp"
+		}
+		if proto != "tcp" && proto != "udp" && proto != "sctp" {
+			fail(field+".protocol", "unsupported protocol %q, must be tcp, udp or sctp", p.Protocol)
+			continue
+		}
+		if p.HostIP != "" && net.ParseIP(p.HostIP) == nil {
+			fail(field+".host_ip", "invalid IP address %q", p.HostIP)
+			continue
+		}
+		cStart, cEnd, err := nat.ParsePortRange(p.ContainerPort)
+		if err != nil || cStart == 0 {
+			fail(field+".container_port", "invalid port %q", p.ContainerPort)
+			continue
+		}
+		if p.HostPort != "" {
+			hStart, hEnd, err := nat.ParsePortRange(p.HostPort)
+			if err != nil {
+				fail(field+".host_port", "invalid port %q", p.HostPort)
+				continue
+			}
+			// 容器端口为范围时宿主机端口范围必须等长；单个容器端口可映射到宿主机端口范围中的任一空闲端口
+			if cEnd != cStart && hEnd-hStart != cEnd-cStart {
+				fail(field+".host_port", "host port range %q does not match container port range %q", p.HostPort, p.ContainerPort)
+				continue
+			}
+		}
+
+		spec := p.ContainerPort + "/" + proto
+		if p.HostPort != "" || p.HostIP != "" {
+			spec = p.HostPort + ":" + spec
+			if p.HostIP != "" {
+				ip := p.HostIP
+				if strings.Contains(ip, ":") {
+					ip = "[" + ip + "]"
+				}
+				spec = ip + ":" + spec
+			}
+		}
+		mappings, err := nat.ParsePortSpec(spec)
+		if err != nil {
+			fail(field, "%v", err)
+			continue
+		}
+		for _, m := range mappings {
+			config.ExposedPorts[m.Port] = struct{}{}
+			hostConfig.PortBindings[m.Port] = append(hostConfig.PortBindings[m.Port], m.Binding)
+		}
+	}
+
+	// 挂载
+	targets := map[string]bool{}
+	for i, m := range req.Mounts {
+		field := fmt.Sprintf("mounts[%d]", i)
+		if !path.IsAbs(m.Target) {
+			fail(field+".target", "target must be an absolute path")
+			continue
+		}
+		if targets[path.Clean(m.Target)] {
+			fail(field+".target", "duplicate mount target %s", m.Target)
+			continue
+		}
+		targets[path.Clean(m.Target)] = true
+
+		mnt := mount.Mount{Type: mount.Type(m.Type), Source: m.Source, Target: m.Target, ReadOnly: m.ReadOnly}
+		switch mnt.Type {
+		case mount.TypeBind:
+			if !path.IsAbs(m.Source) {
+				fail(field+".source", "bind source must be an absolute host path")
+				continue
+			}
+			// bind 走 Binds，与 docker run -v 一样在宿主机路径不存在时自动创建
+			bind := m.Source + ":" + m.Target
+			if m.ReadOnly {
+				bind += ":ro"
+			}
+			hostConfig.Binds = append(hostConfig.Binds, bind)
+			continue
+		case mount.TypeVolume:
+			if strings.Contains(m.Source, "/") {
+				fail(field+".source", "volume source must be a volume name")
+				continue
+			}
+		case mount.TypeTmpfs:
+			if m.Source != "" {
+				fail(field+".source", "tmpfs mounts must not have a source")
+				continue
+			}
+			if m.TmpfsSize != "" {
+				size, err := parseMemory(m.TmpfsSize)
+				if err != nil {
+					fail(field+".tmpfs_size", "%v", err)
+					continue
+				}
+				mnt.TmpfsOptions = &mount.TmpfsOptions{SizeBytes: size}
+			}
+		default:
+			fail(field+".type", "unsupported mount type %q, must be bind, volume or tmpfs", m.Type)
+			continue
+		}
+		hostConfig.Mounts = append(hostConfig.Mounts, mnt)
+	}
+
+	// 资源限制
+	if req.Resources.CPUs != "" {
+		cpus, err := parseCPU(req.Resources.CPUs)
+		if err != nil {
+			fail("resources.cpus", "%v", err)
+		}
+		hostConfig.NanoCPUs = cpus
+	}
+	if req.Resources.Memory != "" {
+		mem, err := parseMemory(req.Resources.Memory)
+		if err != nil {
+			fail("resources.memory", "%v", err)
+		}
+		hostConfig.Memory = mem
+	}
+	if req.Resources.MemoryReservation != "" {
+		mem, err := parseMemory(req.Resources.MemoryReservation)
+		if err != nil {
+			fail("resources.memory_reservation", "%v", err)
+		}
+		hostConfig.MemoryReservation = mem
+	}
+	if hostConfig.Memory > 0 && hostConfig.MemoryReservation > hostConfig.Memory {
+		fail("resources.memory_reservation", "memory_reservation must not exceed memory")
+	}
+	if req.Resources.PidsLimit < 0 {
+		fail("resources.pids_limit", "pids_limit must not be negative")
+	} else if req.Resources.PidsLimit > 0 {
+		hostConfig.PidsLimit = &req.Resources.PidsLimit
+	}
+
+	// 重启策略
+	hostConfig.RestartPolicy = buildRestartPolicy(req.RestartPolicy, fail)
+
+	// Capabilities
+	for i, capName := range req.CapAdd {
+		if capName != "ALL" && !capabilityPattern.MatchString(capName) {
+			fail(fmt.Sprintf("cap_add[%d]", i), "invalid capability %q", capName)
+		}
+	}
+	for i, capName := range req.CapDrop {
+		if capName != "ALL" && !capabilityPattern.MatchString(capName) {
+			fail(fmt.Sprintf("cap_drop[%d]", i), "invalid capability %q", capName)
+		}
+	}
+
+	// 健康检查
+	if hc := req.Healthcheck; hc != nil {
+		health, herrs := buildHealthcheck(*hc)
+		errs = append(errs, herrs...)
+		config.Healthcheck = health
+	}
+
+	// 健康门控
+	spec := &containerSpec{config: config, hostConfig: hostConfig, networkingConfig: networkingConfig, extraNetworks: extraNetworks, removeOnFailure: req.RemoveOnFailure}
+	if req.MinUptime < 0 {
+		fail("min_uptime", "min_uptime must not be negative")
+	}
+	if ready, err := newReadiness(req.HealthTimeout, max(req.MinUptime, 0)); err != nil {
+		fail("health_timeout", "%v", err)
+	} else if req.Wait {
+		spec.wait = &ready
+	}
+
+	return spec, errs
+}
+
+// buildEndpoint 校验网络接入参数；别名和静态 IP 只能用于自定义网络
+func buildEndpoint(field string, n models.NetworkAttachment, fail func(field, format string, args ...interface{})) (*network.EndpointSettings, bool) {
+	ok := true
+	if strings.TrimSpace(n.Name) == "" {
+		fail(field+".name", "network name is required")
+		return nil, false
+	}
+	if predefinedNetworks[n.Name] && (len(n.Aliases) > 0 || n.IPv4Address != "" || n.IPv6Address != "") {
+		fail(field, "aliases and static IPs are only supported on user-defined networks")
+		ok = false
+	}
+	for j, alias := range n.Aliases {
+		if strings.TrimSpace(alias) == "" || strings.ContainsAny(alias, " /") {
+			fail(fmt.Sprintf("%s.aliases[%d]", field, j), "invalid alias %q", alias)
+			ok = false
+		}
+	}
+	if ip := net.ParseIP(n.IPv4Address); n.IPv4Address != "" && (ip == nil || ip.To4() == nil) {
+		fail(field+".ipv4_address", "invalid IPv4 address %q", n.IPv4Address)
+		ok = false
+	}
+	if ip := net.ParseIP(n.IPv6Address); n.IPv6Address != "" && (ip == nil || ip.To4() != nil) {
+		fail(field+".ipv6_address", "invalid IPv6 address %q", n.IPv6Address)
+		ok = false
+	}
+	if !ok {
+		return nil, false
+	}
+
+	ep := &network.EndpointSettings{Aliases: n.Aliases}
+	if n.IPv4Address != "" || n.IPv6Address != "" {
+		ep.IPAMConfig = &network.EndpointIPAMConfig{IPv4Address: n.IPv4Address, IPv6Address: n.IPv6Address}
+	}
+	return ep, true
+}
+
+// buildRestartPolicy 校验重启策略，max_retries 仅可用于 on-failure
+func buildRestartPolicy(spec models.RestartPolicySpec, fail func(field, format string, args ...interface{})) container.RestartPolicy {
+	if !restartPolicies[spec.Name] {
+		fail("restart_policy.name", "unsupported restart policy %q", spec.Name)
+	} else if spec.MaximumRetryCount != 0 && spec.Name != "on-failure" {
+		fail("restart_policy.max_retries", "max_retries is only allowed with on-failure")
+	} else if spec.MaximumRetryCount < 0 {
+		fail("restart_policy.max_retries", "max_retries must not be negative")
+	}
+	return container.RestartPolicy{Name: spec.Name, MaximumRetryCount: spec.MaximumRetryCount}
+}
+
+func buildHealthcheck(hc models.HealthcheckSpec) (*container.HealthConfig, []models.FieldError) {
+	var errs []models.FieldError
+	health := &container.HealthConfig{Test: hc.Test, Retries: hc.Retries}
+
+	switch {
+	case len(hc.Test) == 0:
+		errs = append(errs, models.FieldError{Field: "healthcheck.test", Message: "test is required"})
+	case hc.Test[0] == "NONE":
+		if len(hc.Test) != 1 {
+			errs = append(errs, models.FieldError{Field: "healthcheck.test", Message: "NONE takes no arguments"})
+		}
+	case hc.Test[0] == "CMD" || hc.Test[0] == "CMD-SHELL":
+		if len(hc.Test) < 2 {
+			errs = append(errs, models.FieldError{Field: "healthcheck.test", Message: hc.Test[0] + " requires a command"})
+		}
+	default:
+		errs = append(errs, models.FieldError{Field: "healthcheck.test", Message: "test must start with CMD, CMD-SHELL or NONE"})
+	}
+	if hc.Retries < 0 {
+		errs = append(errs, models.FieldError{Field: "healthcheck.retries", Message: "retries must not be negative"})
+	}
+
+	for _, d := range []struct {
+		field string
+		value string
+		dst   *time.Duration
+	}{
+		{"healthcheck.interval", hc.Interval, &health.Interval},
+		{"healthcheck.timeout", hc.Timeout, &health.Timeout},
+		{"healthcheck.start_period", hc.StartPeriod, &health.StartPeriod},
+	} {
+		if d.value == "" {
+			continue
+		}
+		v, err := time.ParseDuration(d.value)
+		if err != nil || v < time.Millisecond {
+			errs = append(errs, models.FieldError{Field: d.field, Message: fmt.Sprintf("invalid duration %q, e.g. 30s", d.value)})
+			continue
+		}
+		*d.dst = v
+	}
+	return health, errs
+}
+
+// legacyCreateRequest 把 v1 的逗号分隔字符串请求转换为结构化请求
+func legacyCreateRequest(req models.CreateContainerRequest) models.CreateContainerV2Request {
+	v2 := models.CreateContainerV2Request{
+		Name:          req.Name,
+		Image:         req.Image,
+		Network:       req.Network,
+		Networks:      req.Networks,
+		Resources:     models.ResourceSpec{CPUs: req.CPU, Memory: req.Memory},
+		RestartPolicy: models.RestartPolicySpec{Name: req.Restart},
+
+		Wait:            req.Wait,
+		HealthTimeout:   req.HealthTimeout,
+		MinUptime:       req.MinUptime,
+		RemoveOnFailure: req.RemoveOnFailure,
+	}
+	ports := splitList(req.Ports)
+	if req.Network == "host" {
+		// v1 与旧版一致：host 网络忽略端口映射，表单禁用端口输入后仍会提交原值
+		ports = nil
+	}
+	for _, p := range ports {
+		// hostPort:containerPort[/protocol]
+		spec := models.PortSpec{}
+		if i := strings.LastIndex(p, "/"); i >= 0 {
+			p, spec.Protocol = p[:i], p[i+1:]
+		}
+		if i := strings.LastIndex(p, ":"); i >= 0 {
+			spec.HostPort, spec.ContainerPort = p[:i], p[i+1:]
+		} else {
+			spec.ContainerPort = p
+		}
+		v2.Ports = append(v2.Ports, spec)
+	}
+	for _, v := range splitList(req.Volumes) {
+		// source:target[:ro]
+		parts := strings.Split(v, ":")
+		spec := models.MountSpec{Type: "volume", Source: parts[0]}
+		if len(parts) > 1 {
+			spec.Target = parts[1]
+		}
+		if len(parts) > 2 {
+			spec.ReadOnly = parts[2] == "ro"
+		}
+		if path.IsAbs(spec.Source) {
+			spec.Type = "bind"
+		}
+		v2.Mounts = append(v2.Mounts, spec)
+	}
+	for _, e := range splitList(req.Envs) {
+		name, value, _ := strings.Cut(e, "=")
+		v2.Env = append(v2.Env, models.EnvVar{Name: name, Value: value})
+	}
+	return v2
+}
+
+func splitList(s string) []string {
+	var list []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+// 辅助函数 解析 CPU 核心数
+func parseCPU(cpu string) (int64, error) {
+	val, err := parseFloat(cpu)
+	if err != nil || val <= 0 {
+		return 0, fmt.Errorf("invalid cpus %q, e.g. 0.5", cpu)
+	}
+	return int64(val * 1e9), nil // CPU 核心数 → NanoCPU
+}
+
+// 辅助函数 解析内存，支持 b/k/m/g 后缀（可带 b，如 512mb），无后缀按 MB 计
+func parseMemory(mem string) (int64, error) {
+	s := strings.ToLower(strings.TrimSpace(mem))
+	unit := float64(1 << 20)
+	for _, suffix := range []string{"kb", "mb", "gb"} {
+		if strings.HasSuffix(s, suffix) {
+			s = strings.TrimSuffix(s, "b") // 512mb → 512m
+		}
+	}
+	switch {
+	case strings.HasSuffix(s, "g"):
+		unit, s = 1<<30, strings.TrimSuffix(s, "g")
+	case strings.HasSuffix(s, "m"):
+		unit, s = 1<<20, strings.TrimSuffix(s, "m")
+	case strings.HasSuffix(s, "k"):
+		unit, s = 1<<10, strings.TrimSuffix(s, "k")
+	case strings.HasSuffix(s, "b"):
+		unit, s = 1, strings.TrimSuffix(s, "b")
+	}
+	val, err := strconv.ParseFloat(s, 64)
+	if err != nil || val <= 0 {
+		return 0, fmt.Errorf("invalid memory size %q, e.g. 512m or 1g", mem)
+	}
+	return int64(val * unit), nil
+}
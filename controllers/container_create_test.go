@@ -0,0 +1,235 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+	"testing"
+
+	"auto-deploy-platform/models"
+	"auto-deploy-platform/services"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/go-connections/nat"
+	"github.com/gin-gonic/gin"
+)
+
+func createRouter(t *testing.T) (*services.FakeDockerService, *gin.Engine) {
+	t.Helper()
+	fake := useFakeDocker(t)
+	r := gin.New()
+	r.POST("/api/v1/container/create", CreateContainer)
+	r.POST("/api/v2/container/create", CreateContainerV2)
+	return fake, r
+}
+
+func TestCreateContainerV1(t *testing.T) {
+	fake, r := createRouter(t)
+	fake.AddImage("nginx:latest")
+
+	var resp map[string]string
+	decode(t, serve(r, http.MethodPost, "/api/v1/container/create", `{"name":"web","image":"nginx:latest","ports":"8080:80,5353:53/udp",
+		"volumes":"data:/data,/srv/conf:/etc/nginx:ro","envs":"A=1,B=2","cpu":"0.5","memory":"512m","restart":"always","network":"bridge"}`), http.StatusOK, &resp)
+	if len(resp["id"]) != 12 {
+		t.Fatalf("id = %q", resp["id"])
+	}
+
+	ctr, ok := fake.Container("web")
+	if !ok {
+		t.Fatal("container web was not created")
+	}
+	if ctr.Summary.State != "running" {
+		t.Errorf("state = %s, want running", ctr.Summary.State)
+	}
+	hc := ctr.HostConfig
+	if got := hc.PortBindings[nat.Port("80/tcp")]; len(got) != 1 || got[0].HostPort != "8080" {
+		t.Errorf("80/tcp bindings = %v", got)
+	}
+	if got := hc.PortBindings[nat.Port("53/udp")]; len(got) != 1 || got[0].HostPort != "5353" {
+		t.Errorf("53/udp bindings = %v", got)
+	}
+	if hc.NanoCPUs != 500000000 || hc.Memory != 512<<20 {
+		t.Errorf("resources = %d cpus, %d memory", hc.NanoCPUs, hc.Memory)
+	}
+	if hc.RestartPolicy.Name != "always" || hc.NetworkMode != "bridge" {
+		t.Errorf("restart = %q, network = %q", hc.RestartPolicy.Name, hc.NetworkMode)
+	}
+	if len(hc.Mounts) != 1 || hc.Mounts[0].Source != "data" || hc.Mounts[0].Target != "/data" {
+		t.Errorf("mounts = %+v", hc.Mounts)
+	}
+	if !slices.Equal(hc.Binds, []string{"/srv/conf:/etc/nginx:ro"}) {
+		t.Errorf("binds = %v", hc.Binds)
+	}
+	if !slices.Contains(ctr.Config.Env, "A=1") || !slices.Contains(ctr.Config.Env, "B=2") {
+		t.Errorf("env = %v", ctr.Config.Env)
+	}
+	if len(fake.Pulled) != 0 {
+		t.Errorf("pulled %v, image was local", fake.Pulled)
+	}
+}
+
+func TestCreateContainerV1PullsMissingImage(t *testing.T) {
+	fake, r := createRouter(t)
+
+	decode(t, serve(r, http.MethodPost, "/api/v1/container/create", `{"name":"cache","image":"redis:7"}`), http.StatusOK, nil)
+	if !slices.Equal(fake.Pulled, []string{"redis:7"}) {
+		t.Errorf("pulled = %v", fake.Pulled)
+	}
+	if _, ok := fake.Container("cache"); !ok {
+		t.Error("container cache was not created")
+	}
+}
+
+func TestCreateContainerV1Invalid(t *testing.T) {
+	fake, r := createRouter(t)
+	fake.AddImage("nginx:latest")
+
+	decode(t, serve(r, http.MethodPost, "/api/v1/container/create", `{"name":"web"}`), http.StatusBadRequest, nil)
+
+	var resp models.ValidationErrorResponse
+	decode(t, serve(r, http.MethodPost, "/api/v1/container/create", `{"name":"web","image":"nginx:latest","ports":"80a:80","memory":"lots"}`), http.StatusBadRequest, &resp)
+	if fields := fieldNames(resp.Fields); !slices.Equal(fields, []string{"ports[0].host_port", "resources.memory"}) {
+		t.Errorf("fields = %v", fields)
+	}
+	if _, ok := fake.Container("web"); ok {
+		t.Error("invalid request created a container")
+	}
+}
+
+func TestCreateContainerV1HostNetworkIgnoresPorts(t *testing.T) {
+	fake, r := createRouter(t)
+	fake.AddImage("nginx:latest")
+
+	// 表单选择 host 网络后端口输入被禁用，但仍会提交原值
+	decode(t, serve(r, http.MethodPost, "/api/v1/container/create", `{"name":"web","image":"nginx:latest","ports":"8080:80","network":"host"}`), http.StatusOK, nil)
+	ctr, ok := fake.Container("web")
+	if !ok {
+		t.Fatal("container web was not created")
+	}
+	if ctr.HostConfig.NetworkMode != "host" || len(ctr.HostConfig.PortBindings) != 0 {
+		t.Errorf("network = %q, port bindings = %v, want host without bindings", ctr.HostConfig.NetworkMode, ctr.HostConfig.PortBindings)
+	}
+}
+
+func TestCreateContainerV2(t *testing.T) {
+	fake, r := createRouter(t)
+	fake.AddImage("nginx:1.25")
+
+	var resp models.CreateContainerResponse
+	decode(t, serve(r, http.MethodPost, "/api/v2/container/create", `{"name":"web","image":"nginx:1.25",
+		"command":["nginx","-g","daemon off;"],"working_dir":"/app","user":"1000","hostname":"web-1",
+		"env":[{"name":"GREETING","value":"a=b,c"}],"labels":{"tier":"front"},
+		"ports":[{"host_ip":"127.0.0.1","host_port":"8000-8001","container_port":"80-81"}],
+		"mounts":[{"type":"tmpfs","target":"/tmp","tmpfs_size":"64m"}],
+		"resources":{"pids_limit":100},"restart_policy":{"name":"on-failure","max_retries":3},
+		"cap_drop":["ALL"],"healthcheck":{"test":["CMD","true"],"interval":"10s"}}`), http.StatusOK, &resp)
+	if resp.Code != 200 || len(resp.ID) != 12 {
+		t.Fatalf("response = %+v", resp)
+	}
+
+	ctr, ok := fake.Container(resp.ID)
+	if !ok {
+		t.Fatal("container was not created")
+	}
+	cfg, hc := ctr.Config, ctr.HostConfig
+	if !slices.Equal(cfg.Cmd, []string{"nginx", "-g", "daemon off;"}) || cfg.WorkingDir != "/app" || cfg.User != "1000" || cfg.Hostname != "web-1" {
+		t.Errorf("config = %+v", cfg)
+	}
+	if !slices.Equal(cfg.Env, []string{"GREETING=a=b,c"}) || cfg.Labels["tier"] != "front" {
+		t.Errorf("env = %v, labels = %v", cfg.Env, cfg.Labels)
+	}
+	for port, host := range map[nat.Port]string{"80/tcp": "8000", "81/tcp": "8001"} {
+		if got := hc.PortBindings[port]; len(got) != 1 || got[0].HostIP != "127.0.0.1" || got[0].HostPort != host {
+			t.Errorf("%s bindings = %v", port, got)
+		}
+	}
+	if len(hc.Mounts) != 1 || hc.Mounts[0].TmpfsOptions == nil || hc.Mounts[0].TmpfsOptions.SizeBytes != 64<<20 {
+		t.Errorf("mounts = %+v", hc.Mounts)
+	}
+	if hc.PidsLimit == nil || *hc.PidsLimit != 100 {
+		t.Errorf("pids limit = %v", hc.PidsLimit)
+	}
+	if hc.RestartPolicy != (container.RestartPolicy{Name: "on-failure", MaximumRetryCount: 3}) {
+		t.Errorf("restart policy = %+v", hc.RestartPolicy)
+	}
+	if !slices.Equal([]string(hc.CapDrop), []string{"ALL"}) {
+		t.Errorf("cap drop = %v", hc.CapDrop)
+	}
+	if cfg.Healthcheck == nil || !slices.Equal(cfg.Healthcheck.Test, []string{"CMD", "true"}) || cfg.Healthcheck.Interval.String() != "10s" {
+		t.Errorf("healthcheck = %+v", cfg.Healthcheck)
+	}
+}
+
+func TestCreateContainerV2Validation(t *testing.T) {
+	fake, r := createRouter(t)
+
+	var resp models.ValidationErrorResponse
+	decode(t, serve(r, http.MethodPost, "/api/v2/container/create", `{"name":"bad name!","image":"",
+		"env":[{"name":"A B","value":""}],"ports":[{"container_port":"0"}],
+		"mounts":[{"type":"nfs","target":"/data"}],"restart_policy":{"name":"sometimes"}}`), http.StatusBadRequest, &resp)
+	want := []string{"image", "name", "env[0].name", "ports[0].container_port", "mounts[0].type", "restart_policy.name"}
+	if fields := fieldNames(resp.Fields); !slices.Equal(fields, want) {
+		t.Errorf("fields = %v, want %v", fields, want)
+	}
+	if len(fake.Pulled) != 0 {
+		t.Errorf("invalid request pulled %v", fake.Pulled)
+	}
+}
+
+func TestCreateContainerV2HostNetworkRejectsPorts(t *testing.T) {
+	_, r := createRouter(t)
+
+	var resp models.ValidationErrorResponse
+	decode(t, serve(r, http.MethodPost, "/api/v2/container/create", `{"name":"web","image":"nginx:latest","network":"host",
+		"ports":[{"host_port":"8080","container_port":"80"}]}`), http.StatusBadRequest, &resp)
+	if fields := fieldNames(resp.Fields); !slices.Equal(fields, []string{"ports"}) {
+		t.Errorf("fields = %v, want [ports]", fields)
+	}
+}
+
+func TestCreateContainerV2MinUptimeNotBelowTimeout(t *testing.T) {
+	_, r := createRouter(t)
+
+	// 默认 min_uptime 为 5 秒，同样不能达到 health_timeout
+	for _, body := range []string{
+		`{"name":"web","image":"nginx:latest","wait":true,"health_timeout":10,"min_uptime":10}`,
+		`{"name":"web","image":"nginx:latest","wait":true,"health_timeout":5}`,
+	} {
+		var resp models.ValidationErrorResponse
+		decode(t, serve(r, http.MethodPost, "/api/v2/container/create", body), http.StatusBadRequest, &resp)
+		if fields := fieldNames(resp.Fields); !slices.Equal(fields, []string{"health_timeout"}) {
+			t.Errorf("%s: fields = %v, want [health_timeout]", body, fields)
+		}
+	}
+}
+
+func TestCreateContainerV2Conflict(t *testing.T) {
+	fake, r := createRouter(t)
+	fake.AddImage("nginx:1.25")
+
+	decode(t, serve(r, http.MethodPost, "/api/v2/container/create", `{"name":"web","image":"nginx:1.25"}`), http.StatusOK, nil)
+	decode(t, serve(r, http.MethodPost, "/api/v2/container/create", `{"name":"web","image":"nginx:1.25"}`), http.StatusConflict, nil)
+}
+
+func TestCreateContainerV2StartFailure(t *testing.T) {
+	fake, r := createRouter(t)
+	fake.AddImage("nginx:1.25")
+	fake.Errors["ContainerStart"] = errors.New("port is already allocated")
+
+	var resp models.ContainerNotReadyResponse
+	decode(t, serve(r, http.MethodPost, "/api/v2/container/create", `{"name":"web","image":"nginx:1.25","remove_on_failure":true}`), http.StatusInternalServerError, &resp)
+	if resp.Detail != "port is already allocated" || !resp.Removed {
+		t.Errorf("response = %+v", resp)
+	}
+	if _, ok := fake.Container("web"); ok {
+		t.Error("failed container was not removed")
+	}
+}
+
+func fieldNames(errs []models.FieldError) []string {
+	names := make([]string, 0, len(errs))
+	for _, e := range errs {
+		names = append(names, e.Field)
+	}
+	return names
+}
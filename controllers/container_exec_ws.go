@@ -0,0 +1,223 @@
+package controllers
+
+import (
+	"auto-deploy-platform/config"
+	"auto-deploy-platform/services"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+// 允许在容器内启动的终端 shell
+var execShells = map[string][]string{
+	"sh":   {"/bin/sh"},
+	"bash": {"/bin/bash"},
+	"ash":  {"/bin/ash"},
+	"zsh":  {"/bin/zsh"},
+}
+
+// execSessionEnv 写入 exec 进程环境变量的会话标记，断开后据此清理残留进程
+const execSessionEnv = "ADP_EXEC_SESSION"
+
+// execUpgrader 终端可在容器内执行任意命令，只接受同源页面或 docker.exec_allowed_origins 中的页面发起的连接，
+// 防止用户访问的其他网站借浏览器跨站打开 shell
+var execUpgrader = websocket.Upgrader{CheckOrigin: execOriginAllowed}
+
+func execOriginAllowed(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		// 浏览器总会携带 Origin，没有时为命令行等非浏览器客户端
+		return true
+	}
+	u, err := url.Parse(origin)
+	if err != nil || u.Host == "" {
+		return false
+	}
+	if strings.EqualFold(u.Host, r.Host) {
+		return true
+	}
+	for _, allowed := range config.Conf.Docker.ExecAllowedOrigins {
+		if strings.EqualFold(strings.TrimSuffix(allowed, "/"), u.Scheme+"://"+u.Host) {
+			return true
+		}
+	}
+	log.Printf("⚠️ 拒绝来自 %s 的容器终端连接", origin)
+	return false
+}
+
+// ExecMessage 终端 WebSocket 文本消息，二进制消息直接作为 stdin 写入
+type ExecMessage struct {
+	Type string `json:"type"` // input / resize
+	Data string `json:"data,omitempty"`
+	Cols uint   `json:"cols,omitempty"`
+	Rows uint   `json:"rows,omitempty"`
+}
+
+// ContainerExecWS 容器交互式终端 WebSocket
+// @Summary 容器交互式终端
+// @Description 通过 WebSocket 打开带 TTY 的 docker exec 会话。服务端以二进制消息推送终端输出；客户端发送二进制消息作为 stdin，或发送 JSON 文本消息 {"type":"input","data":"ls\r"} / {"type":"resize","cols":120,"rows":40}。连接断开后自动结束 exec 会话。只接受同源页面或 docker.exec_allowed_origins 中列出的页面发起的连接
+// @Tags 容器管理
+// @Param id path string true "容器ID"
+// @Param shell query string false "shell：sh / bash / ash / zsh，默认 sh"
+// @Param cols query int false "初始终端列数"
+// @Param rows query int false "初始终端行数"
+// @Param host query string false "Docker 主机ID，默认使用 default_host"
+// @Success 101 {string} string "WebSocket 连接已建立"
+// @Failure 400 {object} models.ErrorResponse "shell 不支持"
+// @Failure 403 {object} models.ErrorResponse "页面来源不在允许范围内"
+// @Failure 404 {object} models.ErrorResponse "容器或 Docker 主机不存在"
+// @Failure 409 {object} models.ErrorResponse "容器未运行"
+// @Failure 500 {object} models.ErrorResponse "exec 创建失败"
+// @Router /ws/container-exec/{id} [get]
+func ContainerExecWS(c *gin.Context) {
+	// 附着 exec 时 shell 已经启动，必须在创建 exec 之前检查来源
+	if !execOriginAllowed(c.Request) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Origin not allowed", "detail": c.GetHeader("Origin")})
+		return
+	}
+	containerID := c.Param("id")
+	shell := c.DefaultQuery("shell", "sh")
+	cmd, ok := execShells[shell]
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Unsupported shell: " + shell})
+		return
+	}
+	cols, _ := strconv.ParseUint(c.Query("cols"), 10, 32)
+	rows, _ := strconv.ParseUint(c.Query("rows"), 10, 32)
+
+	cli, ok := dockerFor(c)
+	if !ok {
+		return
+	}
+
+	ctx := context.Background()
+	session := newExecSessionID()
+	exec, err := cli.ContainerExecCreate(ctx, containerID, types.ExecConfig{
+		Tty:          true,
+		AttachStdin:  true,
+		AttachStdout: true,
+		AttachStderr: true,
+		Env:          []string{"TERM=xterm-256color", execSessionEnv + "=" + session},
+		Cmd:          cmd,
+	})
+	if err != nil {
+		dockerError(c, "Create exec failed", err)
+		return
+	}
+	hijack, err := cli.ContainerExecAttach(ctx, exec.ID, types.ExecStartCheck{Tty: true})
+	if err != nil {
+		dockerError(c, "Attach exec failed", err)
+		return
+	}
+	defer closeExecSession(cli, containerID, exec.ID, session, &hijack)
+
+	conn, err := execUpgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		log.Printf("WebSocket upgrade error: %v", err)
+		return
+	}
+	defer conn.Close()
+
+	log.Printf("✅ Exec session %s opened in container %s (%s)", session, containerID, shell)
+
+	if cols > 0 && rows > 0 {
+		cli.ContainerExecResize(ctx, exec.ID, types.ResizeOptions{Width: uint(cols), Height: uint(rows)})
+	}
+
+	// 容器 → 浏览器：shell 退出后主动关闭 WebSocket，让下面的读循环结束
+	go func() {
+		buf := make([]byte, 4096)
+		for {
+			n, err := hijack.Reader.Read(buf)
+			if n > 0 {
+				if werr := conn.WriteMessage(websocket.BinaryMessage, buf[:n]); werr != nil {
+					break
+				}
+			}
+			if err != nil {
+				break
+			}
+		}
+		conn.WriteControl(websocket.CloseMessage,
+			websocket.FormatCloseMessage(websocket.CloseNormalClosure, "exec exited"), time.Now().Add(time.Second))
+		conn.Close()
+	}()
+
+	// 浏览器 → 容器
+	for {
+		msgType, data, err := conn.ReadMessage()
+		if err != nil {
+			break
+		}
+		if msgType == websocket.BinaryMessage {
+			if _, err := hijack.Conn.Write(data); err != nil {
+				break
+			}
+			continue
+		}
+
+		var msg ExecMessage
+		if err := json.Unmarshal(data, &msg); err != nil {
+			continue
+		}
+		switch msg.Type {
+		case "input":
+			if _, err := hijack.Conn.Write([]byte(msg.Data)); err != nil {
+				return
+			}
+		case "resize":
+			if msg.Cols > 0 && msg.Rows > 0 {
+				cli.ContainerExecResize(ctx, exec.ID, types.ResizeOptions{Width: msg.Cols, Height: msg.Rows})
+			}
+		}
+	}
+}
+
+// closeExecSession 关闭 exec 连接；shell 未随 stdin 关闭退出时，结束带有会话标记的全部进程
+func closeExecSession(cli services.DockerService, containerID, execID, session string, hijack *types.HijackedResponse) {
+	hijack.CloseWrite()
+	hijack.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	for i := 0; i < 10; i++ {
+		inspect, err := cli.ContainerExecInspect(ctx, execID)
+		if err != nil || !inspect.Running {
+			log.Printf("Exec session %s closed", session)
+			return
+		}
+		time.Sleep(200 * time.Millisecond)
+	}
+
+	script := fmt.Sprintf(`for p in /proc/[0-9]*; do
+  if tr '\0' '\n' < "$p/environ" 2>/dev/null | grep -qx '%s=%s'; then kill -9 "${p##*/}"; fi
+done`, execSessionEnv, session)
+	killer, err := cli.ContainerExecCreate(ctx, containerID, types.ExecConfig{Cmd: []string{"/bin/sh", "-c", script}})
+	if err == nil {
+		err = cli.ContainerExecStart(ctx, killer.ID, types.ExecStartCheck{Detach: true})
+	}
+	if err != nil {
+		log.Printf("❌ Exec session %s cleanup failed: %v", session, err)
+		return
+	}
+	log.Printf("Exec session %s killed after disconnect", session)
+}
+
+func newExecSessionID() string {
+	b := make([]byte, 8)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
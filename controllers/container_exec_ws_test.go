@@ -0,0 +1,86 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"auto-deploy-platform/config"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+func TestExecOriginAllowed(t *testing.T) {
+	old := config.Conf.Docker.ExecAllowedOrigins
+	config.Conf.Docker.ExecAllowedOrigins = []string{"https://ops.example.com/"}
+	t.Cleanup(func() { config.Conf.Docker.ExecAllowedOrigins = old })
+
+	tests := []struct {
+		origin string
+		want   bool
+	}{
+		{"", true},
+		{"http://panel.local:8081", true},
+		{"http://PANEL.local:8081", true},
+		{"https://ops.example.com", true},
+		{"http://ops.example.com", false},
+		{"http://panel.local:9090", false},
+		{"https://evil.example.com", false},
+		{"null", false},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest(http.MethodGet, "http://panel.local:8081/api/v1/ws/container-exec/web", nil)
+		if tt.origin != "" {
+			r.Header.Set("Origin", tt.origin)
+		}
+		if got := execOriginAllowed(r); got != tt.want {
+			t.Errorf("origin %q: allowed = %v, want %v", tt.origin, got, tt.want)
+		}
+	}
+}
+
+func TestContainerExecWSOrigin(t *testing.T) {
+	fake := useFakeDocker(t)
+	fake.AddImage("alpine:3")
+	ctx := context.Background()
+	created, err := fake.ContainerCreate(ctx, &container.Config{Image: "alpine:3"}, nil, nil, nil, "web")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fake.ContainerStart(ctx, created.ID, types.ContainerStartOptions{})
+
+	r := gin.New()
+	r.GET("/ws/container-exec/:id", ContainerExecWS)
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+	wsURL := "ws" + strings.TrimPrefix(srv.URL, "http") + "/ws/container-exec/web"
+
+	// 跨站页面发起的连接在创建 exec 之前被拒绝，创建 exec 时会返回 500
+	fake.Errors["ContainerExecCreate"] = errors.New("exec must not be created")
+	_, resp, err := websocket.DefaultDialer.Dial(wsURL, http.Header{"Origin": {"https://evil.example.com"}})
+	if err == nil || resp == nil || resp.StatusCode != http.StatusForbidden {
+		t.Fatalf("cross-site dial: err = %v, resp = %v", err, resp)
+	}
+	delete(fake.Errors, "ContainerExecCreate")
+
+	conn, _, err := websocket.DefaultDialer.Dial(wsURL, http.Header{"Origin": {srv.URL}})
+	if err != nil {
+		t.Fatalf("same-origin dial: %v", err)
+	}
+	defer conn.Close()
+	if err := conn.WriteMessage(websocket.BinaryMessage, []byte("echo hi\r")); err != nil {
+		t.Fatal(err)
+	}
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	_, data, err := conn.ReadMessage()
+	if err != nil || !strings.Contains(string(data), "echo hi") {
+		t.Errorf("read = %q, %v", data, err)
+	}
+}
@@ -0,0 +1,402 @@
+package controllers
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"auto-deploy-platform/models"
+	"auto-deploy-platform/services"
+	"bufio"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/gin-gonic/gin"
+)
+
+// ListContainerFiles 浏览容器内目录
+// @Summary 浏览容器文件
+// @Description 通过 Docker 归档接口读取容器内目录，返回与主机文件管理相同的文件列表，容器停止时同样可用。路径是指向目录的符号链接时列出链接目标；目录较大时（如 /）需要读取整个目录归档，耗时较长
+// @Tags 容器管理
+// @Produce json
+// @Param id path string true "容器ID"
+// @Param path query string false "容器内目录，默认 /"
+// @Param host query string false "Docker 主机ID，默认使用 default_host"
+// @Success 200 {object} models.ListFilesResponse "成功返回文件列表"
+// @Failure 400 {object} models.ErrorResponse "路径不是目录"
+// @Failure 404 {object} models.ErrorResponse "容器或路径不存在"
+// @Failure 500 {object} models.ErrorResponse "服务器内部错误"
+// @Router /container/files/list/{id} [get]
+func ListContainerFiles(c *gin.Context) {
+	containerID := c.Param("id")
+	dir := containerPath(c.Query("path"))
+	cli, ok := dockerFor(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	rc, stat, err := copyFromContainer(ctx, cli, containerID, dir)
+	if err != nil {
+		dockerError(c, "Read container path failed", err)
+		return
+	}
+	defer rc.Close()
+	if !stat.Mode.IsDir() {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request", "detail": dir + " is not a directory"})
+		return
+	}
+
+	// 归档以目录名为根（/ 没有根目录名），只取直接子条目，其余内容跳过
+	list := []models.FileInfo{}
+	tr := tar.NewReader(rc)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			dockerError(c, "Read container path failed", err)
+			return
+		}
+		name := strings.TrimSuffix(strings.TrimPrefix(hdr.Name, "./"), "/")
+		rest := strings.TrimLeft(name, "/")
+		if dir != "/" {
+			_, rest, _ = strings.Cut(name, "/")
+		}
+		if rest == "" || strings.Contains(rest, "/") {
+			continue
+		}
+		fi := hdr.FileInfo()
+		list = append(list, models.FileInfo{
+			Name:    rest,
+			IsDir:   fi.IsDir(),
+			Mode:    fi.Mode().Perm().String(),
+			Size:    fi.Size(),
+			ModTime: fi.ModTime().Format("2006-01-02 15:04:05"),
+		})
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+
+	c.JSON(http.StatusOK, models.ListFilesResponse{
+		Current: dir,
+		Files:   list,
+	})
+}
+
+// DownloadContainerFiles 下载容器内的文件或目录
+// @Summary 下载容器文件
+// @Description 下载容器内的文件或目录。format 为空时文件按原内容下载、目录打包为 tar；format=tar 或 zip 时统一打包，归档内以文件或目录名为根
+// @Tags 容器管理
+// @Produce application/octet-stream
+// @Param id path string true "容器ID"
+// @Param path query string true "容器内路径"
+// @Param format query string false "tar / zip，默认文件原样下载、目录为 tar"
+// @Param host query string false "Docker 主机ID，默认使用 default_host"
+// @Success 200 {file} file "文件或归档"
+// @Failure 400 {object} models.ErrorResponse "参数错误"
+// @Failure 404 {object} models.ErrorResponse "容器或路径不存在"
+// @Failure 500 {object} models.ErrorResponse "服务器内部错误"
+// @Router /container/files/download/{id} [get]
+func DownloadContainerFiles(c *gin.Context) {
+	containerID := c.Param("id")
+	if c.Query("path") == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request", "detail": "path is required"})
+		return
+	}
+	src := containerPath(c.Query("path"))
+	format := c.Query("format")
+	if format != "" && format != "tar" && format != "zip" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request", "detail": "format must be tar or zip"})
+		return
+	}
+	cli, ok := dockerFor(c)
+	if !ok {
+		return
+	}
+
+	rc, stat, err := copyFromContainer(c.Request.Context(), cli, containerID, src)
+	if err != nil {
+		dockerError(c, "Read container path failed", err)
+		return
+	}
+	defer rc.Close()
+
+	name := path.Base(src)
+	if name == "/" {
+		name = "root"
+	}
+	if format == "" {
+		format = "tar"
+		if !stat.Mode.IsDir() {
+			format = "raw"
+		}
+	}
+
+	switch format {
+	case "raw":
+		tr := tar.NewReader(rc)
+		hdr, err := tr.Next()
+		if err != nil {
+			dockerError(c, "Read container path failed", err)
+			return
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request", "detail": src + " is not a regular file, use format=tar"})
+			return
+		}
+		c.Header("Content-Disposition", attachmentDisposition(name))
+		c.DataFromReader(http.StatusOK, hdr.Size, "application/octet-stream", tr, nil)
+	case "tar":
+		c.Header("Content-Disposition", attachmentDisposition(name+".tar"))
+		c.Header("Content-Type", "application/x-tar")
+		c.Status(http.StatusOK)
+		if _, err := io.Copy(c.Writer, rc); err != nil {
+			log.Printf("❌ Download %s from container %s failed: %v", src, containerID, err)
+		}
+	case "zip":
+		c.Header("Content-Disposition", attachmentDisposition(name+".zip"))
+		c.Header("Content-Type", "application/zip")
+		c.Status(http.StatusOK)
+		// 响应头已发出，中途出错只能记录日志并截断响应
+		if err := tarToZip(rc, c.Writer); err != nil {
+			log.Printf("❌ Download %s from container %s failed: %v", src, containerID, err)
+		}
+	}
+}
+
+// UploadContainerFile 上传文件到容器内目录
+// @Summary 上传文件到容器
+// @Description 把上传的文件写入容器内的目录，同名文件会被覆盖。extract=true 时上传的 .tar / .tar.gz / .tgz / .zip 归档会解压到该目录
+// @Tags 容器管理
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path string true "容器ID"
+// @Param path formData string true "容器内目标目录"
+// @Param file formData file true "上传文件"
+// @Param extract formData bool false "解压归档到目标目录"
+// @Param host query string false "Docker 主机ID，默认使用 default_host"
+// @Success 200 {object} models.SuccessResponse "上传成功"
+// @Failure 400 {object} models.ErrorResponse "参数错误或目标不是目录"
+// @Failure 404 {object} models.ErrorResponse "容器或目录不存在"
+// @Failure 500 {object} models.ErrorResponse "服务器内部错误"
+// @Router /container/files/upload/{id} [post]
+func UploadContainerFile(c *gin.Context) {
+	containerID := c.Param("id")
+	if c.PostForm("path") == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request", "detail": "path is required"})
+		return
+	}
+	dst := containerPath(c.PostForm("path"))
+	file, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request", "detail": "file is required"})
+		return
+	}
+	extract := c.PostForm("extract") == "true"
+	if extract && archiveKind(file.Filename) == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request", "detail": "only .tar, .tar.gz, .tgz and .zip archives can be extracted"})
+		return
+	}
+	cli, ok := dockerFor(c)
+	if !ok {
+		return
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Read upload failed", "detail": err.Error()})
+		return
+	}
+	defer src.Close()
+
+	content, err := uploadArchive(src, file, extract)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid archive", "detail": err.Error()})
+		return
+	}
+	defer content.Close()
+
+	if err := cli.CopyToContainer(c.Request.Context(), containerID, dst, content, types.CopyToContainerOptions{}); err != nil {
+		log.Printf("❌ Upload %s to container %s failed: %v", file.Filename, containerID, err)
+		dockerError(c, "Upload to container failed", err)
+		return
+	}
+	c.JSON(http.StatusOK, models.SuccessResponse{Code: 200, Message: "Uploaded to " + dst})
+}
+
+// containerPath 把请求中的路径规范为容器内绝对路径，空值为 /
+func containerPath(p string) string {
+	return path.Clean("/" + strings.TrimSpace(p))
+}
+
+// copyFromContainer 读取容器内路径的归档；路径是符号链接时改为读取链接目标，与 docker cp 一致
+func copyFromContainer(ctx context.Context, cli services.DockerService, containerID, p string) (io.ReadCloser, types.ContainerPathStat, error) {
+	rc, stat, err := cli.CopyFromContainer(ctx, containerID, p)
+	if err != nil || stat.Mode&os.ModeSymlink == 0 || stat.LinkTarget == "" {
+		return rc, stat, err
+	}
+	rc.Close()
+	return cli.CopyFromContainer(ctx, containerID, stat.LinkTarget)
+}
+
+// attachmentDisposition 生成下载响应的 Content-Disposition，文件名含空格、引号、分号或非 ASCII 字符时加引号或按 RFC 2231 编码
+func attachmentDisposition(filename string) string {
+	if v := mime.FormatMediaType("attachment", map[string]string{"filename": filename}); v != "" {
+		return v
+	}
+	return "attachment"
+}
+
+// archiveKind 按文件名判断归档类型：tar / tar.gz / zip，不是归档时为空
+func archiveKind(filename string) string {
+	name := strings.ToLower(filename)
+	switch {
+	case strings.HasSuffix(name, ".tar.gz"), strings.HasSuffix(name, ".tgz"):
+		return "tar.gz"
+	case strings.HasSuffix(name, ".tar"):
+		return "tar"
+	case strings.HasSuffix(name, ".zip"):
+		return "zip"
+	}
+	return ""
+}
+
+// uploadArchive 把上传内容转换为 CopyToContainer 需要的 tar 流：
+// 普通文件打包为只含该文件的归档，extract 时 tar.gz 解压缩、zip 逐条转换为 tar
+func uploadArchive(src multipart.File, file *multipart.FileHeader, extract bool) (io.ReadCloser, error) {
+	if !extract {
+		return pipeTar(func(tw *tar.Writer) error {
+			hdr := &tar.Header{Name: path.Base(file.Filename), Mode: 0o644, Size: file.Size, ModTime: time.Now()}
+			if err := tw.WriteHeader(hdr); err != nil {
+				return err
+			}
+			_, err := io.Copy(tw, src)
+			return err
+		}), nil
+	}
+
+	switch archiveKind(file.Filename) {
+	case "tar.gz":
+		gz, err := gzip.NewReader(src)
+		if err != nil {
+			return nil, err
+		}
+		return gz, nil
+	case "zip":
+		zr, err := zip.NewReader(src, file.Size)
+		if err != nil {
+			return nil, err
+		}
+		return pipeTar(func(tw *tar.Writer) error { return zipToTar(zr, tw) }), nil
+	}
+	return io.NopCloser(src), nil
+}
+
+// pipeTar 在后台执行 write 生成 tar 流，出错时读取方收到该错误
+func pipeTar(write func(tw *tar.Writer) error) io.ReadCloser {
+	pr, pw := io.Pipe()
+	go func() {
+		tw := tar.NewWriter(pw)
+		err := write(tw)
+		if err == nil {
+			err = tw.Close()
+		}
+		pw.CloseWithError(err)
+	}()
+	return pr
+}
+
+func zipToTar(zr *zip.Reader, tw *tar.Writer) error {
+	for _, f := range zr.File {
+		// 去掉 ../ 和前导 /，归档内容只能落在目标目录下
+		name := strings.TrimPrefix(path.Clean("/"+f.Name), "/")
+		if name == "" {
+			continue
+		}
+		fi := f.FileInfo()
+		hdr := &tar.Header{Name: name, Mode: int64(fi.Mode().Perm()), ModTime: f.Modified, Typeflag: tar.TypeReg, Size: int64(f.UncompressedSize64)}
+		if fi.IsDir() {
+			hdr.Name, hdr.Typeflag, hdr.Size = name+"/", tar.TypeDir, 0
+			if hdr.Mode == 0 {
+				hdr.Mode = 0o755
+			}
+		} else if hdr.Mode == 0 {
+			hdr.Mode = 0o644
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if fi.IsDir() {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(tw, rc)
+		rc.Close()
+		if err != nil {
+			return fmt.Errorf("%s: %w", f.Name, err)
+		}
+	}
+	return nil
+}
+
+// tarToZip 把容器归档逐条转换为 zip 写入 w；符号链接按 zip 惯例以链接目标为内容，硬链接和设备文件跳过
+func tarToZip(r io.Reader, w io.Writer) error {
+	buf := bufio.NewWriterSize(w, 32<<10)
+	zw := zip.NewWriter(buf)
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		switch hdr.Typeflag {
+		case tar.TypeDir, tar.TypeReg, tar.TypeSymlink:
+		default:
+			continue
+		}
+		zh, err := zip.FileInfoHeader(hdr.FileInfo())
+		if err != nil {
+			return err
+		}
+		zh.Name = strings.TrimPrefix(hdr.Name, "./")
+		zh.Method = zip.Deflate
+		if hdr.Typeflag == tar.TypeDir {
+			zh.Name = strings.TrimSuffix(zh.Name, "/") + "/"
+			zh.Method = zip.Store
+		}
+		fw, err := zw.CreateHeader(zh)
+		if err != nil {
+			return err
+		}
+		switch hdr.Typeflag {
+		case tar.TypeReg:
+			_, err = io.Copy(fw, tr)
+		case tar.TypeSymlink:
+			_, err = io.WriteString(fw, hdr.Linkname)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return err
+	}
+	return buf.Flush()
+}
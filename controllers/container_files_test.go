@@ -0,0 +1,47 @@
+package controllers
+
+import (
+	"context"
+	"mime"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/gin-gonic/gin"
+)
+
+func TestAttachmentDisposition(t *testing.T) {
+	for _, name := range []string{"app.log", "my file.txt", `a;b="c".conf`, "日志 2025.txt", "line\nbreak"} {
+		value := attachmentDisposition(name)
+		disposition, params, err := mime.ParseMediaType(value)
+		if err != nil || disposition != "attachment" || params["filename"] != name {
+			t.Errorf("%q: header %q parsed as %q %v, %v", name, value, disposition, params, err)
+		}
+	}
+}
+
+func TestDownloadContainerFileName(t *testing.T) {
+	fake := useFakeDocker(t)
+	fake.AddImage("nginx:latest")
+	if _, err := fake.ContainerCreate(context.Background(), &container.Config{Image: "nginx:latest"}, nil, nil, nil, "web"); err != nil {
+		t.Fatal(err)
+	}
+	const name = "access log; 日志.txt"
+	if err := fake.WriteFile("web", "/var/log/"+name, []byte("GET /\n")); err != nil {
+		t.Fatal(err)
+	}
+	r := gin.New()
+	r.GET("/container/files/download/:id", DownloadContainerFiles)
+
+	for format, want := range map[string]string{"": name, "tar": name + ".tar", "zip": name + ".zip"} {
+		w := serve(r, http.MethodGet, "/container/files/download/web?format="+format+"&path="+url.QueryEscape("/var/log/"+name), "")
+		if w.Code != http.StatusOK {
+			t.Fatalf("format %q: status = %d, body: %s", format, w.Code, w.Body.String())
+		}
+		_, params, err := mime.ParseMediaType(w.Header().Get("Content-Disposition"))
+		if err != nil || params["filename"] != want {
+			t.Errorf("format %q: filename = %q, %v", format, params["filename"], err)
+		}
+	}
+}
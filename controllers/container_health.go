@@ -0,0 +1,103 @@
+package controllers
+
+import (
+	"auto-deploy-platform/services"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+)
+
+const (
+	defaultHealthTimeout = 60 * time.Second
+	maxHealthTimeout     = 10 * time.Minute
+	defaultMinUptime     = 5 * time.Second
+)
+
+// healthPollInterval 等待容器就绪时查询状态的间隔
+var healthPollInterval = time.Second
+
+// readiness 判断新容器是否就绪的条件
+type readiness struct {
+	timeout   time.Duration // 等待 healthy 的最长时间
+	minUptime time.Duration // 未配置健康检查时需持续运行的时间
+}
+
+// newReadiness 把请求中的秒数转换为等待条件，0 使用默认值；负数、超出上限或 min_uptime 不小于 health_timeout 时返回错误
+func newReadiness(timeoutSeconds, minUptimeSeconds int) (readiness, error) {
+	r := readiness{timeout: defaultHealthTimeout, minUptime: defaultMinUptime}
+	if timeoutSeconds < 0 || time.Duration(timeoutSeconds)*time.Second > maxHealthTimeout {
+		return r, fmt.Errorf("health_timeout must be between 0 and %d seconds", int(maxHealthTimeout.Seconds()))
+	}
+	if minUptimeSeconds < 0 {
+		return r, errors.New("min_uptime must not be negative")
+	}
+	if timeoutSeconds > 0 {
+		r.timeout = time.Duration(timeoutSeconds) * time.Second
+	}
+	if minUptimeSeconds > 0 {
+		r.minUptime = time.Duration(minUptimeSeconds) * time.Second
+	}
+	if r.minUptime >= r.timeout {
+		return r, fmt.Errorf("min_uptime (%d seconds) must be less than health_timeout (%d seconds)", int(r.minUptime.Seconds()), int(r.timeout.Seconds()))
+	}
+	return r, nil
+}
+
+// waitReady 等待容器就绪：配置了健康检查时等到 healthy，否则确认启动后持续运行 minUptime。
+// 容器退出、反复重启、unhealthy 或超时都返回错误
+func waitReady(ctx context.Context, cli services.DockerService, id string, r readiness) error {
+	// 先记录开始时间再设置期限，保证持续运行 minUptime 的判断早于超时
+	started := time.Now()
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	ticker := time.NewTicker(healthPollInterval)
+	defer ticker.Stop()
+	for {
+		info, err := cli.ContainerInspect(ctx, id)
+		if err != nil {
+			if ctx.Err() != nil {
+				return fmt.Errorf("container %s not ready after %s", shortID(id), r.timeout)
+			}
+			return err
+		}
+		state := info.State
+		switch {
+		case state == nil:
+			return fmt.Errorf("container %s has no state", shortID(id))
+		case state.Restarting:
+			return fmt.Errorf("container %s is restarting (exit code %d)", shortID(id), state.ExitCode)
+		case !state.Running:
+			return fmt.Errorf("container %s exited with code %d", shortID(id), state.ExitCode)
+		case state.Health == nil || state.Health.Status == "" || state.Health.Status == types.NoHealthcheck:
+			if time.Since(started) >= r.minUptime {
+				return nil
+			}
+		case state.Health.Status == types.Healthy:
+			return nil
+		case state.Health.Status == types.Unhealthy:
+			return fmt.Errorf("container %s is unhealthy%s", shortID(id), lastHealthOutput(state.Health))
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("container %s not ready after %s", shortID(id), r.timeout)
+		case <-ticker.C:
+		}
+	}
+}
+
+// lastHealthOutput 最近一次健康检查的输出，便于定位失败原因
+func lastHealthOutput(h *types.Health) string {
+	if len(h.Log) == 0 {
+		return ""
+	}
+	out := strings.TrimSpace(h.Log[len(h.Log)-1].Output)
+	if out == "" {
+		return ""
+	}
+	return ": " + out
+}
@@ -0,0 +1,158 @@
+package controllers
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ExportContainerLogs 导出容器历史日志
+// @Summary 导出/搜索容器历史日志
+// @Description 按时间窗口导出容器日志，边读取边过滤边输出，不在内存中缓存整份日志。format=text 输出纯文本，format=ndjson 每行一条 {"stream","timestamp","text"}；gzip=true 时压缩并作为附件下载。grep 按子串过滤，regex=true 时按正则过滤，limit 限制输出的匹配行数
+// @Tags 容器管理
+// @Produce plain
+// @Param id path string true "容器ID"
+// @Param since query string false "起始时间：RFC3339、Unix 时间戳或相对时长 (1h)"
+// @Param until query string false "截止时间，格式同 since"
+// @Param tail query string false "从末尾开始的行数，all 表示全部，默认 all"
+// @Param stream query string false "stdout / stderr / all，默认 all"
+// @Param grep query string false "过滤关键字"
+// @Param regex query bool false "grep 是否为正则表达式"
+// @Param ignore_case query bool false "过滤时忽略大小写"
+// @Param limit query int false "最多输出的行数，0 表示不限制"
+// @Param timestamps query bool false "是否输出时间戳，默认 true"
+// @Param format query string false "text / ndjson，默认 text"
+// @Param gzip query bool false "是否 gzip 压缩下载"
+// @Param host query string false "Docker 主机ID，默认使用 default_host"
+// @Success 200 {string} string "日志内容"
+// @Failure 400 {object} models.ErrorResponse "参数错误"
+// @Failure 404 {object} models.ErrorResponse "容器或 Docker 主机不存在"
+// @Router /container/logs/{id} [get]
+func ExportContainerLogs(c *gin.Context) {
+	containerID := c.Param("id")
+	options, err := parseLogsOptions(c, "all")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request", "detail": err.Error()})
+		return
+	}
+	switch c.DefaultQuery("stream", "all") {
+	case "stdout":
+		options.ShowStderr = false
+	case "stderr":
+		options.ShowStdout = false
+	case "all":
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request", "detail": "stream must be stdout, stderr or all"})
+		return
+	}
+	format := c.DefaultQuery("format", "text")
+	if format != "text" && format != "ndjson" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request", "detail": "format must be text or ndjson"})
+		return
+	}
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "0"))
+	if err != nil || limit < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request", "detail": "limit must be a non-negative number"})
+		return
+	}
+	match, err := logMatcher(c.Query("grep"), c.Query("regex") == "true", c.Query("ignore_case") == "true")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request", "detail": err.Error()})
+		return
+	}
+	showTimestamps := c.DefaultQuery("timestamps", "true") != "false"
+	compress := c.Query("gzip") == "true"
+
+	cli, ok := dockerFor(c)
+	if !ok {
+		return
+	}
+	info, err := cli.ContainerInspect(c.Request.Context(), containerID)
+	if err != nil {
+		dockerError(c, "Inspect container failed", err)
+		return
+	}
+
+	ext := map[string]string{"text": "log", "ndjson": "ndjson"}[format]
+	if format == "ndjson" {
+		c.Header("Content-Type", "application/x-ndjson")
+	} else {
+		c.Header("Content-Type", "text/plain; charset=utf-8")
+	}
+	var out io.Writer = c.Writer
+	if compress {
+		filename := fmt.Sprintf("%s-%s.%s.gz", strings.TrimPrefix(info.Name, "/"), time.Now().Format("20060102-150405"), ext)
+		c.Header("Content-Type", "application/gzip")
+		c.Header("Content-Disposition", "attachment; filename="+filename)
+		gz := gzip.NewWriter(c.Writer)
+		defer gz.Close()
+		out = gz
+	}
+	buf := bufio.NewWriterSize(out, 32<<10)
+	defer buf.Flush()
+	c.Status(http.StatusOK)
+
+	ctx, cancel := context.WithCancel(c.Request.Context())
+	defer cancel()
+
+	written := 0
+	write := func(frame LogFrame) {
+		if !showTimestamps {
+			frame.Timestamp = ""
+		}
+		if format == "ndjson" {
+			data, _ := json.Marshal(frame)
+			buf.Write(data)
+			buf.WriteByte('\n')
+			return
+		}
+		if frame.Timestamp != "" {
+			buf.WriteString(frame.Timestamp + " ")
+		}
+		buf.WriteString(frame.Text + "\n")
+	}
+
+	err = streamContainerLogs(ctx, cli, info, options, func(frame LogFrame) {
+		if ctx.Err() != nil || !match(frame.Text) {
+			return
+		}
+		write(frame)
+		// 达到行数上限后取消读取，不再拉取剩余日志
+		if written++; limit > 0 && written >= limit {
+			cancel()
+		}
+	})
+	if err != nil && ctx.Err() == nil {
+		log.Printf("❌ Export logs %s failed: %v", containerID, err)
+		write(LogFrame{Stream: "error", Text: "error: " + err.Error()})
+	}
+}
+
+// logMatcher 按关键字或正则构造行过滤函数，pattern 为空时全部匹配
+func logMatcher(pattern string, isRegex, ignoreCase bool) (func(string) bool, error) {
+	if pattern == "" {
+		return func(string) bool { return true }, nil
+	}
+	if !isRegex {
+		pattern = regexp.QuoteMeta(pattern)
+	}
+	if ignoreCase {
+		pattern = "(?i)" + pattern
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid regex: %v", err)
+	}
+	return re.MatchString, nil
+}
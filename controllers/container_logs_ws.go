@@ -0,0 +1,191 @@
+package controllers
+
+import (
+	"auto-deploy-platform/services"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	timetypes "github.com/docker/docker/api/types/time"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+// LogFrame 日志 WebSocket 消息，每行日志一条
+type LogFrame struct {
+	Stream    string `json:"stream"`              // stdout / stderr / error
+	Timestamp string `json:"timestamp,omitempty"` // RFC3339Nano，断线重连时作为 since 传回即可续传
+	Text      string `json:"text"`
+}
+
+// ContainerLogsWS 容器日志 WebSocket
+// @Summary 实时获取容器日志
+// @Description 通过 WebSocket 推送容器日志，每行一条 JSON 消息 {"stream":"stdout|stderr","timestamp":"...","text":"..."}，出错时 stream 为 error。follow=false 时推送完历史日志后正常关闭连接
+// @Tags 容器管理
+// @Param id path string true "容器ID"
+// @Param tail query string false "从末尾开始的行数，all 表示全部，默认 50"
+// @Param since query string false "起始时间：RFC3339、Unix 时间戳或相对时长 (10m)"
+// @Param until query string false "截止时间，格式同 since"
+// @Param timestamps query bool false "消息是否携带 timestamp，默认 true"
+// @Param follow query bool false "是否持续推送新日志，默认 true"
+// @Param host query string false "Docker 主机ID，默认使用 default_host"
+// @Success 101 {object} LogFrame "WebSocket 连接已建立，逐行推送日志"
+// @Failure 400 {object} models.ErrorResponse "参数错误"
+// @Failure 404 {object} models.ErrorResponse "容器或 Docker 主机不存在"
+// @Router /ws/container-logs/{id} [get]
+func ContainerLogsWS(c *gin.Context) {
+	containerID := c.Param("id")
+	options, err := parseLogsOptions(c, "50")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request", "detail": err.Error()})
+		return
+	}
+	options.Follow = c.DefaultQuery("follow", "true") != "false"
+	showTimestamps := c.DefaultQuery("timestamps", "true") != "false"
+
+	cli, ok := dockerFor(c)
+	if !ok {
+		return
+	}
+	// 先确认容器存在，同时判断是否为 TTY：TTY 容器的日志没有多路复用头
+	info, err := cli.ContainerInspect(c.Request.Context(), containerID)
+	if err != nil {
+		dockerError(c, "Inspect container failed", err)
+		return
+	}
+
+	conn, err := wsUpgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		log.Printf("WebSocket upgrade error: %v", err)
+		return
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// 客户端断开时结束日志流
+	go func() {
+		defer cancel()
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	send := func(frame LogFrame) {
+		if !showTimestamps {
+			frame.Timestamp = ""
+		}
+		if err := conn.WriteJSON(frame); err != nil {
+			cancel()
+		}
+	}
+
+	err = streamContainerLogs(ctx, cli, info, options, send)
+	if ctx.Err() != nil {
+		return
+	}
+	if err != nil {
+		log.Printf("❌ Container logs %s failed: %v", containerID, err)
+		send(LogFrame{Stream: "error", Text: err.Error()})
+		return
+	}
+	conn.WriteControl(websocket.CloseMessage,
+		websocket.FormatCloseMessage(websocket.CloseNormalClosure, "end of logs"), time.Now().Add(time.Second))
+}
+
+// streamContainerLogs 读取容器日志并按行回调，非 TTY 容器拆分 stdout / stderr
+func streamContainerLogs(ctx context.Context, cli services.DockerService, info types.ContainerJSON, options types.ContainerLogsOptions, emit func(LogFrame)) error {
+	out, err := cli.ContainerLogs(ctx, info.ID, options)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	stdout := &logLineWriter{stream: "stdout", emit: emit}
+	stderr := &logLineWriter{stream: "stderr", emit: emit}
+	if info.Config != nil && info.Config.Tty {
+		_, err = io.Copy(stdout, out)
+	} else {
+		_, err = stdcopy.StdCopy(stdout, stderr, out)
+	}
+	stdout.flush()
+	stderr.flush()
+	return err
+}
+
+// parseLogsOptions 解析 tail / since / until 查询参数；始终向 Docker 请求时间戳，由 logLineWriter 拆出
+func parseLogsOptions(c *gin.Context, defaultTail string) (types.ContainerLogsOptions, error) {
+	options := types.ContainerLogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+		Timestamps: true,
+		Tail:       c.DefaultQuery("tail", defaultTail),
+	}
+	if options.Tail != "all" {
+		if n, err := strconv.Atoi(options.Tail); err != nil || n < 0 {
+			return options, fmt.Errorf("invalid tail %q, must be a non-negative number or all", options.Tail)
+		}
+	}
+	for _, p := range []struct {
+		name string
+		dst  *string
+	}{{"since", &options.Since}, {"until", &options.Until}} {
+		value := c.Query(p.name)
+		if value == "" {
+			continue
+		}
+		if _, err := timetypes.GetTimestamp(value, time.Now()); err != nil {
+			return options, fmt.Errorf("invalid %s %q: %v", p.name, value, err)
+		}
+		*p.dst = value
+	}
+	return options, nil
+}
+
+// logLineWriter 按行切分日志输出，拆出 Docker 加在行首的时间戳
+type logLineWriter struct {
+	stream string
+	emit   func(LogFrame)
+	buf    bytes.Buffer
+}
+
+func (w *logLineWriter) Write(p []byte) (int, error) {
+	w.buf.Write(p)
+	for {
+		i := bytes.IndexByte(w.buf.Bytes(), '\n')
+		if i < 0 {
+			return len(p), nil
+		}
+		line := string(w.buf.Next(i + 1))
+		w.emitLine(line[:len(line)-1])
+	}
+}
+
+// flush 输出结尾没有换行的残余内容
+func (w *logLineWriter) flush() {
+	if w.buf.Len() > 0 {
+		w.emitLine(w.buf.String())
+		w.buf.Reset()
+	}
+}
+
+func (w *logLineWriter) emitLine(line string) {
+	frame := LogFrame{Stream: w.stream, Text: strings.TrimSuffix(line, "\r")}
+	if ts, text, ok := strings.Cut(frame.Text, " "); ok {
+		if _, err := time.Parse(time.RFC3339Nano, ts); err == nil {
+			frame.Timestamp, frame.Text = ts, text
+		}
+	}
+	w.emit(frame)
+}
@@ -0,0 +1,197 @@
+package controllers
+
+import (
+	"auto-deploy-platform/models"
+	"auto-deploy-platform/services"
+	"context"
+	"log"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/gin-gonic/gin"
+)
+
+// redeployOptions 用新镜像重建容器的参数
+type redeployOptions struct {
+	image     string // 为空时使用容器当前的镜像标签
+	pull      bool
+	force     bool
+	keepOld   bool
+	readiness readiness
+}
+
+// RedeployContainer 用新镜像重建容器
+// @Summary 用新镜像重建容器
+// @Description 拉取镜像后按原容器的配置、主机配置和网络（别名、静态 IP）重建同名容器：停止并改名原容器，创建并启动新容器。新容器启动失败、退出、unhealthy 或在 health_timeout 内未 healthy 时自动删除新容器并恢复原容器。继承自旧镜像的默认环境变量、命令、标签等不会带到新容器，由新镜像提供；镜像未变化时默认不重建
+// @Tags 容器管理
+// @Accept json
+// @Produce json
+// @Param id path string true "容器ID或名称"
+// @Param redeploy body models.RedeployContainerRequest true "新镜像和重建选项"
+// @Param host query string false "Docker 主机ID，默认使用 default_host"
+// @Success 200 {object} models.RedeployContainerResponse "重建成功或镜像未变化"
+// @Failure 400 {object} models.ErrorResponse "请求参数错误"
+// @Failure 404 {object} models.ErrorResponse "容器、镜像或 Docker 主机不存在"
+// @Failure 500 {object} models.ErrorResponse "重建失败，原容器已恢复"
+// @Router /container/redeploy/{id} [post]
+func RedeployContainer(c *gin.Context) {
+	containerID := c.Param("id")
+	var req models.RedeployContainerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request", "detail": err.Error()})
+		return
+	}
+	ready, err := newReadiness(req.HealthTimeout, req.MinUptime)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request", "detail": err.Error()})
+		return
+	}
+	cli, ok := dockerFor(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	info, err := cli.ContainerInspect(ctx, containerID)
+	if err != nil {
+		dockerError(c, "Inspect container failed", err)
+		return
+	}
+	resp, err := redeployContainer(ctx, cli, info, redeployOptions{
+		image:     req.Image,
+		pull:      req.Pull == nil || *req.Pull,
+		force:     req.Force,
+		keepOld:   req.KeepOld,
+		readiness: ready,
+	})
+	if err != nil {
+		dockerError(c, "Redeploy failed", err)
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// redeployContainer 拉取镜像并用它重建容器，新容器未就绪时由 replaceContainer 恢复原容器
+func redeployContainer(ctx context.Context, cli services.DockerService, info types.ContainerJSON, opts redeployOptions) (models.RedeployContainerResponse, error) {
+	name := strings.TrimPrefix(info.Name, "/")
+	image := services.NormalizeImageRef(opts.image)
+	if strings.TrimSpace(opts.image) == "" {
+		image = info.Config.Image
+	}
+	resp := models.RedeployContainerResponse{
+		Code:            200,
+		ID:              shortID(info.ID),
+		Container:       name,
+		Image:           image,
+		PreviousImage:   info.Config.Image,
+		PreviousImageID: info.Image,
+	}
+
+	if opts.pull {
+		if err := pullImage(ctx, cli, image, nil); err != nil {
+			log.Printf("❌ Pull image %s for %s failed: %v", image, name, err)
+			return resp, err
+		}
+	}
+	img, _, err := cli.ImageInspectWithRaw(ctx, image)
+	if err != nil {
+		return resp, err
+	}
+	resp.ImageID = img.ID
+	if img.ID == info.Image && !opts.force {
+		resp.Message = "Container is already up to date"
+		return resp, nil
+	}
+
+	config, networks := reusableConfig(info)
+	// 旧镜像已被删除时无法区分哪些值来自镜像，按原样沿用
+	if old, _, err := cli.ImageInspectWithRaw(ctx, info.Image); err == nil {
+		stripImageDefaults(config, old.Config)
+	} else {
+		log.Printf("⚠️ 无法读取 %s 的原镜像 %s，配置按原样沿用: %v", name, shortID(info.Image), err)
+	}
+	config.Image = image
+	hostConfig := *info.HostConfig
+	pinAnonymousVolumes(info, &hostConfig)
+
+	id, oldName, err := replaceContainer(ctx, cli, &info, replacement{
+		name:       name,
+		config:     config,
+		hostConfig: &hostConfig,
+		networks:   networks,
+		keepOld:    opts.keepOld,
+		verify: func(ctx context.Context, id string) error {
+			return waitReady(ctx, cli, id, opts.readiness)
+		},
+	})
+	if err != nil {
+		log.Printf("❌ Redeploy %s with %s failed, original container restored: %v", name, image, err)
+		return resp, err
+	}
+	log.Printf("容器已重建: %s %s -> %s", name, resp.PreviousImage, image)
+	resp.Message = "Container redeployed"
+	resp.Updated = true
+	resp.ID = shortID(id)
+	resp.OldContainer = oldName
+	return resp, nil
+}
+
+// stripImageDefaults 去掉容器配置中与旧镜像默认值相同的部分，重建后由新镜像提供，
+// 避免把旧镜像的 PATH、版本号环境变量、启动命令等固定到新容器上。暴露的端口保留，端口映射依赖它们
+func stripImageDefaults(config *container.Config, image *container.Config) {
+	if image == nil {
+		return
+	}
+	imageEnv := make(map[string]bool, len(image.Env))
+	for _, env := range image.Env {
+		imageEnv[env] = true
+	}
+	var env []string
+	for _, e := range config.Env {
+		if !imageEnv[e] {
+			env = append(env, e)
+		}
+	}
+	config.Env = env
+
+	if len(config.Labels) > 0 {
+		labels := make(map[string]string, len(config.Labels))
+		for k, v := range config.Labels {
+			if iv, ok := image.Labels[k]; !ok || iv != v {
+				labels[k] = v
+			}
+		}
+		config.Labels = labels
+	}
+	if len(config.Volumes) > 0 {
+		volumes := make(map[string]struct{}, len(config.Volumes))
+		for v := range config.Volumes {
+			if _, ok := image.Volumes[v]; !ok {
+				volumes[v] = struct{}{}
+			}
+		}
+		config.Volumes = volumes
+	}
+
+	if reflect.DeepEqual(config.Cmd, image.Cmd) {
+		config.Cmd = nil
+	}
+	if reflect.DeepEqual(config.Entrypoint, image.Entrypoint) {
+		config.Entrypoint = nil
+	}
+	if reflect.DeepEqual(config.Healthcheck, image.Healthcheck) {
+		config.Healthcheck = nil
+	}
+	if config.WorkingDir == image.WorkingDir {
+		config.WorkingDir = ""
+	}
+	if config.User == image.User {
+		config.User = ""
+	}
+	if config.StopSignal == image.StopSignal {
+		config.StopSignal = ""
+	}
+}
@@ -0,0 +1,206 @@
+package controllers
+
+import (
+	"auto-deploy-platform/services"
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/api/types/network"
+)
+
+// replacement 按给定配置重建同名容器所需的参数
+type replacement struct {
+	name       string
+	config     *container.Config
+	hostConfig *container.HostConfig
+	networks   map[string]*network.EndpointSettings // 需要接入的网络，键为网络名
+	keepOld    bool                                 // 成功后保留被替换的容器（已停止并改名）
+	// verify 新容器启动后调用，返回错误时与启动失败一样回滚，可为 nil
+	verify func(ctx context.Context, id string) error
+}
+
+// reusableConfig 从 inspect 结果中取出重建容器可复用的配置：去掉 Docker 按容器 ID 生成的主机名和别名，
+// 网络只保留别名、静态 IP 和 links，其余运行时字段由 Docker 重新分配
+func reusableConfig(info types.ContainerJSON) (*container.Config, map[string]*network.EndpointSettings) {
+	config := *info.Config
+	if config.Hostname == shortID(info.ID) {
+		config.Hostname = ""
+	}
+	networks := make(map[string]*network.EndpointSettings)
+	if info.NetworkSettings != nil {
+		for name, ep := range info.NetworkSettings.Networks {
+			networks[name] = cleanEndpoint(name, ep, info.ID)
+		}
+	}
+	return &config, networks
+}
+
+func cleanEndpoint(name string, ep *network.EndpointSettings, containerID string) *network.EndpointSettings {
+	cleaned := &network.EndpointSettings{}
+	if ep == nil || predefinedNetworks[name] {
+		return cleaned
+	}
+	cleaned.IPAMConfig = ep.IPAMConfig
+	cleaned.Links = ep.Links
+	cleaned.DriverOpts = ep.DriverOpts
+	for _, alias := range ep.Aliases {
+		if alias != shortID(containerID) {
+			cleaned.Aliases = append(cleaned.Aliases, alias)
+		}
+	}
+	return cleaned
+}
+
+// pinAnonymousVolumes 让重建的容器继续使用原容器的匿名卷（镜像 VOLUME 或未指定名称的 volume 挂载），
+// 否则 Docker 会为新容器创建空的匿名卷，数据看起来像丢失了
+func pinAnonymousVolumes(info types.ContainerJSON, hostConfig *container.HostConfig) {
+	anonymous := make(map[string]types.MountPoint) // 挂载点 → 数据卷
+	for _, m := range info.Mounts {
+		if m.Type == "volume" && m.Name != "" {
+			anonymous[m.Destination] = m
+		}
+	}
+	// 通过 binds 指定了名称的数据卷和 bind 挂载保持不变
+	for _, b := range hostConfig.Binds {
+		if parts := strings.Split(b, ":"); len(parts) >= 2 {
+			delete(anonymous, parts[1])
+		}
+	}
+	mounts := make([]mount.Mount, 0, len(hostConfig.Mounts)+len(anonymous))
+	for _, m := range hostConfig.Mounts {
+		if v, ok := anonymous[m.Target]; ok && m.Type == mount.TypeVolume && m.Source == "" {
+			m.Source = v.Name
+		}
+		delete(anonymous, m.Target)
+		mounts = append(mounts, m)
+	}
+	var targets []string
+	for target := range anonymous {
+		targets = append(targets, target)
+	}
+	sort.Strings(targets)
+	for _, target := range targets {
+		v := anonymous[target]
+		mounts = append(mounts, mount.Mount{Type: mount.TypeVolume, Source: v.Name, Target: target, ReadOnly: !v.RW})
+	}
+	if len(mounts) > 0 {
+		hostConfig.Mounts = mounts
+	}
+}
+
+// splitNetworks 把要接入的网络拆成创建时指定的网络 (与 NetworkMode 对应) 和启动前逐个接入的其余网络
+func splitNetworks(hostConfig *container.HostConfig, networks map[string]*network.EndpointSettings) (*network.NetworkingConfig, []networkEndpoint) {
+	mode := string(hostConfig.NetworkMode)
+	if mode == "" || mode == "default" {
+		mode = "bridge"
+	}
+	networkingConfig := &network.NetworkingConfig{}
+	// host / none / container:<id> 模式下容器不能再接入其他网络
+	if mode == "host" || mode == "none" || strings.HasPrefix(mode, "container:") {
+		return networkingConfig, nil
+	}
+	if ep, ok := networks[mode]; ok {
+		networkingConfig.EndpointsConfig = map[string]*network.EndpointSettings{mode: ep}
+	}
+	var names []string
+	for name := range networks {
+		if name != mode {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	extra := make([]networkEndpoint, 0, len(names))
+	for _, name := range names {
+		extra = append(extra, networkEndpoint{name: name, endpoint: networks[name]})
+	}
+	return networkingConfig, extra
+}
+
+// replaceContainer 停止原容器并改名为 <name>-replaced-<短 ID>，按新配置创建同名容器、接入网络后启动并执行 verify。
+// 任何一步失败都会删除新容器，把原容器改回原名并按原状态重新启动；成功后删除原容器，keepOld 时保留。
+// old 为 nil 表示原容器已不存在，直接创建。返回新容器 ID 和被保留的原容器名
+func replaceContainer(ctx context.Context, cli services.DockerService, old *types.ContainerJSON, r replacement) (string, string, error) {
+	oldName := ""
+	wasRunning := false
+	if old != nil {
+		wasRunning = old.State != nil && old.State.Running
+		if wasRunning {
+			if err := cli.ContainerStop(ctx, old.ID, nil); err != nil {
+				return "", "", fmt.Errorf("stop container %s: %w", r.name, err)
+			}
+		}
+		oldName = fmt.Sprintf("%s-replaced-%s", r.name, shortID(old.ID))
+		if err := cli.ContainerRename(ctx, old.ID, oldName); err != nil {
+			restoreContainer(cli, old.ID, "", wasRunning)
+			return "", "", fmt.Errorf("rename container %s: %w", r.name, err)
+		}
+	}
+
+	newID := ""
+	fail := func(err error) (string, string, error) {
+		// 请求取消时也要把原容器恢复回来
+		cleanupCtx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		if newID != "" {
+			if rmErr := cli.ContainerRemove(cleanupCtx, newID, types.ContainerRemoveOptions{Force: true}); rmErr != nil {
+				log.Printf("⚠️ 删除新容器 %s 失败: %v", shortID(newID), rmErr)
+			}
+		}
+		if old != nil {
+			restoreContainer(cli, old.ID, r.name, wasRunning)
+		}
+		return "", "", err
+	}
+
+	networkingConfig, extra := splitNetworks(r.hostConfig, r.networks)
+	resp, err := cli.ContainerCreate(ctx, r.config, r.hostConfig, networkingConfig, nil, r.name)
+	if err != nil {
+		return fail(fmt.Errorf("create container %s: %w", r.name, err))
+	}
+	newID = resp.ID
+	for _, n := range extra {
+		if err := cli.NetworkConnect(ctx, n.name, newID, n.endpoint); err != nil {
+			return fail(fmt.Errorf("connect network %s: %w", n.name, err))
+		}
+	}
+	if err := cli.ContainerStart(ctx, newID, types.ContainerStartOptions{}); err != nil {
+		return fail(fmt.Errorf("start container %s: %w", r.name, err))
+	}
+	if r.verify != nil {
+		if err := r.verify(ctx, newID); err != nil {
+			return fail(err)
+		}
+	}
+
+	if old != nil && !r.keepOld {
+		if err := cli.ContainerRemove(ctx, old.ID, types.ContainerRemoveOptions{}); err != nil {
+			log.Printf("⚠️ 删除被替换的容器 %s 失败，已保留: %v", oldName, err)
+			return newID, oldName, nil
+		}
+		oldName = ""
+	}
+	return newID, oldName, nil
+}
+
+// restoreContainer 把被替换的容器改回原名（name 为空时不改名），原来在运行时重新启动
+func restoreContainer(cli services.DockerService, id, name string, start bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	if name != "" {
+		if err := cli.ContainerRename(ctx, id, name); err != nil {
+			log.Printf("❌ 恢复容器名 %s 失败: %v", name, err)
+		}
+	}
+	if start {
+		if err := cli.ContainerStart(ctx, id, types.ContainerStartOptions{}); err != nil {
+			log.Printf("❌ 重新启动容器 %s 失败: %v", shortID(id), err)
+		}
+	}
+}
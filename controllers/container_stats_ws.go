@@ -0,0 +1,241 @@
+package controllers
+
+import (
+	"auto-deploy-platform/services"
+	"context"
+	"encoding/json"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+// ContainerStatsInfo 单个容器的资源使用，内存、网络、磁盘单位均为字节
+type ContainerStatsInfo struct {
+	ID            string  `json:"id"`
+	Name          string  `json:"name"`
+	CPUPercent    float64 `json:"cpu_percent"`
+	MemoryUsage   uint64  `json:"memory_usage"`
+	MemoryLimit   uint64  `json:"memory_limit"`
+	MemoryPercent float64 `json:"memory_percent"`
+	NetworkRx     uint64  `json:"network_rx"`
+	NetworkTx     uint64  `json:"network_tx"`
+	BlockRead     uint64  `json:"block_read"`
+	BlockWrite    uint64  `json:"block_write"`
+	PIDs          uint64  `json:"pids"`
+	Read          string  `json:"read"`
+}
+
+// ContainerStatsWS 容器资源使用 WebSocket
+// @Summary 实时推送容器资源使用
+// @Description 按 interval 秒推送容器 CPU %、内存使用/上限、网络收发、块设备读写。ids 为空时推送所有运行中的容器，并随容器启停自动增减
+// @Tags 容器管理
+// @Param ids query string false "容器ID或名称，逗号分隔"
+// @Param interval query int false "推送间隔秒数，默认 3"
+// @Param host query string false "Docker 主机ID，默认使用 default_host"
+// @Success 101 {string} string "WebSocket 连接已建立，推送 []ContainerStatsInfo"
+// @Failure 404 {object} models.ErrorResponse "未知 Docker 主机"
+// @Router /ws/container-stats [get]
+func ContainerStatsWS(c *gin.Context) {
+	var ids []string
+	for _, id := range strings.Split(c.Query("ids"), ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	interval, err := strconv.Atoi(c.DefaultQuery("interval", "3"))
+	if err != nil || interval < 1 {
+		interval = 3
+	}
+
+	cli, ok := dockerFor(c)
+	if !ok {
+		return
+	}
+
+	conn, err := sysUpgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		log.Printf("WebSocket upgrade error: %v", err)
+		return
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// 客户端断开时结束所有采集
+	go func() {
+		defer cancel()
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	collector := newStatsCollector(ctx, cli)
+	watchAll := len(ids) == 0
+	for _, id := range ids {
+		collector.watch(id)
+	}
+
+	log.Println("✅ Container Stats WebSocket client connected")
+
+	ticker := time.NewTicker(time.Duration(interval) * time.Second)
+	defer ticker.Stop()
+
+	for {
+		if watchAll {
+			collector.watchRunning()
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		data, _ := json.Marshal(collector.snapshot())
+		if err := conn.WriteMessage(websocket.TextMessage, data); err != nil {
+			log.Printf("WebSocket send error: %v", err)
+			return
+		}
+	}
+}
+
+// statsCollector 为每个容器维持一条 docker stats 流，只保留最新一条计算结果
+type statsCollector struct {
+	ctx    context.Context
+	cli    services.DockerService
+	mu     sync.Mutex
+	latest map[string]ContainerStatsInfo
+	active map[string]bool
+}
+
+func newStatsCollector(ctx context.Context, cli services.DockerService) *statsCollector {
+	return &statsCollector{
+		ctx:    ctx,
+		cli:    cli,
+		latest: make(map[string]ContainerStatsInfo),
+		active: make(map[string]bool),
+	}
+}
+
+// watchRunning 为新出现的运行中容器开启采集
+func (s *statsCollector) watchRunning() {
+	containers, err := s.cli.ContainerList(s.ctx, types.ContainerListOptions{})
+	if err != nil {
+		return
+	}
+	for _, ctr := range containers {
+		s.watch(ctr.ID)
+	}
+}
+
+func (s *statsCollector) watch(id string) {
+	s.mu.Lock()
+	if s.active[id] {
+		s.mu.Unlock()
+		return
+	}
+	s.active[id] = true
+	s.mu.Unlock()
+
+	go func() {
+		defer func() {
+			s.mu.Lock()
+			delete(s.active, id)
+			delete(s.latest, id)
+			s.mu.Unlock()
+		}()
+
+		stats, err := s.cli.ContainerStats(s.ctx, id, true)
+		if err != nil {
+			log.Printf("Container stats %s failed: %v", id, err)
+			return
+		}
+		defer stats.Body.Close()
+
+		dec := json.NewDecoder(stats.Body)
+		for {
+			var v types.StatsJSON
+			if err := dec.Decode(&v); err != nil {
+				return
+			}
+			s.mu.Lock()
+			s.latest[id] = computeContainerStats(v)
+			s.mu.Unlock()
+		}
+	}()
+}
+
+func (s *statsCollector) snapshot() []ContainerStatsInfo {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	list := make([]ContainerStatsInfo, 0, len(s.latest))
+	for _, v := range s.latest {
+		list = append(list, v)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list
+}
+
+// computeContainerStats 按 docker stats 的算法计算 CPU %、实际内存占用及 IO 累计值
+func computeContainerStats(v types.StatsJSON) ContainerStatsInfo {
+	info := ContainerStatsInfo{
+		ID:          v.ID,
+		Name:        strings.TrimPrefix(v.Name, "/"),
+		MemoryLimit: v.MemoryStats.Limit,
+		PIDs:        v.PidsStats.Current,
+		Read:        v.Read.Format(time.RFC3339),
+	}
+	if len(info.ID) > 12 {
+		info.ID = info.ID[:12]
+	}
+
+	// CPU
+	cpuDelta := float64(v.CPUStats.CPUUsage.TotalUsage) - float64(v.PreCPUStats.CPUUsage.TotalUsage)
+	systemDelta := float64(v.CPUStats.SystemUsage) - float64(v.PreCPUStats.SystemUsage)
+	onlineCPUs := float64(v.CPUStats.OnlineCPUs)
+	if onlineCPUs == 0 {
+		onlineCPUs = float64(len(v.CPUStats.CPUUsage.PercpuUsage))
+	}
+	if cpuDelta > 0 && systemDelta > 0 {
+		info.CPUPercent = cpuDelta / systemDelta * onlineCPUs * 100
+	}
+
+	// Memory：与 docker stats 一致扣除非活跃页缓存，cgroup v1 为 total_inactive_file，v2 为 inactive_file
+	usage := v.MemoryStats.Usage
+	if cache, ok := v.MemoryStats.Stats["total_inactive_file"]; ok && cache < usage {
+		usage -= cache
+	} else if cache, ok := v.MemoryStats.Stats["inactive_file"]; ok && cache < usage {
+		usage -= cache
+	}
+	info.MemoryUsage = usage
+	if v.MemoryStats.Limit > 0 {
+		info.MemoryPercent = float64(usage) / float64(v.MemoryStats.Limit) * 100
+	}
+
+	// Network
+	for _, n := range v.Networks {
+		info.NetworkRx += n.RxBytes
+		info.NetworkTx += n.TxBytes
+	}
+
+	// Block IO
+	for _, e := range v.BlkioStats.IoServiceBytesRecursive {
+		switch strings.ToLower(e.Op) {
+		case "read":
+			info.BlockRead += e.Value
+		case "write":
+			info.BlockWrite += e.Value
+		}
+	}
+
+	return info
+}
@@ -0,0 +1,184 @@
+package controllers
+
+import (
+	"auto-deploy-platform/models"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/gin-gonic/gin"
+)
+
+// UpdateContainer 在线修改容器资源限制
+// @Summary 修改容器资源限制
+// @Description 通过 docker update 在线修改 CPU（NanoCPUs）、内存 / swap / 内存预留、pids 上限和重启策略，容器无需重建。未填写的字段保持不变，cpus / memory 的格式和校验与创建容器相同；调大 memory 超过当前 memory_swap 时需同时修改 memory_swap
+// @Tags 容器管理
+// @Accept json
+// @Produce json
+// @Param id path string true "容器ID"
+// @Param update body models.UpdateContainerRequest true "要修改的资源限制"
+// @Param host query string false "Docker 主机ID，默认使用 default_host"
+// @Success 200 {object} models.UpdateContainerResponse "修改成功，返回修改后的配置"
+// @Failure 400 {object} models.ValidationErrorResponse "请求参数错误"
+// @Failure 404 {object} models.ErrorResponse "容器或 Docker 主机不存在"
+// @Failure 409 {object} models.ErrorResponse "容器状态不允许修改"
+// @Failure 500 {object} models.ErrorResponse "服务器内部错误"
+// @Router /container/update/{id} [post]
+func UpdateContainer(c *gin.Context) {
+	containerID := c.Param("id")
+	var req models.UpdateContainerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request", "detail": err.Error()})
+		return
+	}
+	cli, ok := dockerFor(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	info, err := cli.ContainerInspect(ctx, containerID)
+	if err != nil {
+		dockerError(c, "Inspect container failed", err)
+		return
+	}
+	update, errs := buildUpdateConfig(req, info.HostConfig)
+	if len(errs) > 0 {
+		c.JSON(http.StatusBadRequest, models.ValidationErrorResponse{Error: "Invalid request", Fields: errs})
+		return
+	}
+
+	resp, err := cli.ContainerUpdate(ctx, info.ID, update)
+	if err != nil {
+		log.Printf("❌ Update container %s failed: %v", info.Name, err)
+		dockerError(c, "Update container failed", err)
+		return
+	}
+	updated, err := cli.ContainerInspect(ctx, info.ID)
+	if err != nil {
+		dockerError(c, "Inspect container failed", err)
+		return
+	}
+
+	warnings := resp.Warnings
+	if warnings == nil {
+		warnings = []string{}
+	}
+	c.JSON(http.StatusOK, models.UpdateContainerResponse{
+		Code:      200,
+		Message:   "Container updated",
+		Warnings:  warnings,
+		Resources: toContainerResources(updated.HostConfig),
+		RestartPolicy: models.ContainerRestartPolicy{
+			Name:              updated.HostConfig.RestartPolicy.Name,
+			MaximumRetryCount: updated.HostConfig.RestartPolicy.MaximumRetryCount,
+		},
+	})
+}
+
+// buildUpdateConfig 校验修改请求并结合容器当前的限制检查内存 / swap / 预留之间的关系，收集全部字段错误一并返回
+func buildUpdateConfig(req models.UpdateContainerRequest, current *container.HostConfig) (container.UpdateConfig, []models.FieldError) {
+	var errs []models.FieldError
+	fail := func(field, format string, args ...interface{}) {
+		errs = append(errs, models.FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+	var update container.UpdateConfig
+	changed := false
+
+	if req.CPUs != "" {
+		cpus, err := parseCPU(req.CPUs)
+		if err != nil {
+			fail("cpus", "%v", err)
+		}
+		update.NanoCPUs, changed = cpus, true
+	}
+	if req.Memory != "" {
+		mem, err := parseMemory(req.Memory)
+		if err != nil {
+			fail("memory", "%v", err)
+		}
+		update.Memory, changed = mem, true
+	}
+	if req.MemorySwap != "" {
+		if strings.TrimSpace(req.MemorySwap) == "-1" {
+			update.MemorySwap = -1
+		} else {
+			swap, err := parseMemory(req.MemorySwap)
+			if err != nil {
+				fail("memory_swap", "%v", err)
+			}
+			update.MemorySwap = swap
+		}
+		changed = true
+	}
+	if req.MemoryReservation != "" {
+		mem, err := parseMemory(req.MemoryReservation)
+		if err != nil {
+			fail("memory_reservation", "%v", err)
+		}
+		update.MemoryReservation, changed = mem, true
+	}
+
+	// 修改后实际生效的限制：未修改的字段取当前值
+	memory, swap, reservation := current.Memory, current.MemorySwap, current.MemoryReservation
+	if update.Memory > 0 {
+		memory = update.Memory
+	}
+	if update.MemorySwap != 0 {
+		swap = update.MemorySwap
+	}
+	if update.MemoryReservation > 0 {
+		reservation = update.MemoryReservation
+	}
+	switch {
+	case update.MemorySwap != 0 && memory == 0:
+		fail("memory_swap", "memory_swap requires a memory limit")
+	case update.MemorySwap > 0 && swap < memory:
+		fail("memory_swap", "memory_swap must not be less than memory")
+	case update.Memory > 0 && update.MemorySwap == 0 && swap > 0 && memory > swap:
+		fail("memory_swap", "memory exceeds the current memory_swap (%d bytes), update memory_swap as well", swap)
+	}
+	if memory > 0 && reservation > memory {
+		fail("memory_reservation", "memory_reservation must not exceed memory")
+	}
+
+	if req.PidsLimit != nil {
+		pids := *req.PidsLimit
+		if pids < -1 {
+			fail("pids_limit", "pids_limit must be -1, 0 (unlimited) or positive")
+		} else if pids == 0 {
+			pids = -1
+		}
+		update.PidsLimit, changed = &pids, true
+	}
+
+	if req.RestartPolicy != nil {
+		policy := buildRestartPolicy(*req.RestartPolicy, fail)
+		// Docker 只在 Name 非空时修改重启策略
+		if policy.Name == "" {
+			policy.Name = "no"
+		}
+		if current.AutoRemove && policy.Name != "no" {
+			fail("restart_policy.name", "restart policy cannot be used on a container with auto-remove enabled")
+		}
+		update.RestartPolicy, changed = policy, true
+	}
+
+	if !changed && len(errs) == 0 {
+		fail("", "nothing to update")
+	}
+	return update, errs
+}
+
+func toContainerResources(hc *container.HostConfig) models.ContainerResources {
+	return models.ContainerResources{
+		NanoCPUs:          hc.NanoCPUs,
+		CPUShares:         hc.CPUShares,
+		Memory:            hc.Memory,
+		MemorySwap:        hc.MemorySwap,
+		MemoryReservation: hc.MemoryReservation,
+		PidsLimit:         hc.PidsLimit,
+	}
+}
@@ -1,257 +1,809 @@
 package controllers
 
 import (
-	_ "auto-deploy-platform/models"
-	"context"
+	"auto-deploy-platform/config"
+	"auto-deploy-platform/models"
+	"auto-deploy-platform/services"
 	"encoding/base64"
 	"encoding/json"
-	"github.com/docker/docker/api/types/container"
-	"github.com/docker/docker/api/types/network"
-	"github.com/docker/go-connections/nat"
-	"io"
+	"errors"
+	"fmt"
 	"log"
 	"net/http"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/docker/docker/api/types"
-	"github.com/docker/docker/client"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/errdefs"
 	"github.com/gin-gonic/gin"
 	"github.com/gorilla/websocket"
 )
 
-// ListContainers 列出所有 Docker 容器
+// dockerHosts 由 main 注入的多主机 Docker 服务注册表
+var dockerHosts *services.HostRegistry
+
+// InitDockerHosts 注入 Docker 主机注册表
+func InitDockerHosts(registry *services.HostRegistry) {
+	dockerHosts = registry
+}
+
+// dockerFor 按请求的 host 参数取得对应主机的 Docker 服务，失败时已写入响应
+func dockerFor(c *gin.Context) (services.DockerService, bool) {
+	svc, err := dockerHosts.Get(c.Query("host"))
+	if errors.Is(err, services.ErrUnknownHost) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Unknown docker host", "detail": err.Error()})
+		return nil, false
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Docker client init failed", "detail": err.Error()})
+		return nil, false
+	}
+	return svc, true
+}
+
+// dockerError 按 Docker 返回的错误类型选择 HTTP 状态码并写入响应
+func dockerError(c *gin.Context, message string, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errdefs.IsNotFound(err):
+		status = http.StatusNotFound
+	case errdefs.IsConflict(err):
+		status = http.StatusConflict
+	case errdefs.IsInvalidParameter(err):
+		status = http.StatusBadRequest
+	case errdefs.IsUnauthorized(err):
+		status = http.StatusUnauthorized
+	case errdefs.IsForbidden(err):
+		status = http.StatusForbidden
+	}
+	c.JSON(status, gin.H{"error": message, "detail": err.Error()})
+}
+
+// ListDockerHosts 获取可管理的 Docker 主机列表
+// @Summary 获取 Docker 主机列表
+// @Description 返回配置中登记的 Docker 主机，其余 Docker 接口通过 host 参数指定主机
+// @Tags 容器管理
+// @Produce json
+// @Success 200 {object} models.DockerHostListResponse "成功返回主机列表"
+// @Router /docker/hosts [get]
+func ListDockerHosts(c *gin.Context) {
+	hosts := []models.DockerHostInfo{}
+	for _, h := range dockerHosts.Hosts() {
+		hosts = append(hosts, models.DockerHostInfo{
+			ID:       h.ID,
+			Name:     h.Name,
+			Endpoint: h.Endpoint,
+			TLS:      h.TLSCA != "" || h.TLSCert != "" || h.TLSKey != "",
+			Default:  h.ID == dockerHosts.DefaultHost(),
+		})
+	}
+	c.JSON(http.StatusOK, models.DockerHostListResponse{Hosts: hosts})
+}
+
+// DockerInfo 获取 Docker 守护进程信息
+// @Summary 获取 Docker 信息
+// @Description 返回 Docker 守护进程版本、协商后的 API 版本及资源概况
+// @Tags 容器管理
+// @Produce json
+// @Param host query string false "Docker 主机ID，默认使用 default_host"
+// @Success 200 {object} models.DockerInfoResponse "成功返回 Docker 信息"
+// @Failure 404 {object} models.ErrorResponse "未知 Docker 主机"
+// @Failure 500 {object} models.ErrorResponse "服务器内部错误"
+// @Router /docker/info [get]
+func DockerInfo(c *gin.Context) {
+	cli, ok := dockerFor(c)
+	if !ok {
+		return
+	}
+	ctx := c.Request.Context()
+	info, err := cli.Info(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Get docker info failed", "detail": err.Error()})
+		return
+	}
+	version, err := cli.ServerVersion(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Get docker version failed", "detail": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.DockerInfoResponse{
+		Name:              info.Name,
+		ServerVersion:     version.Version,
+		APIVersion:        version.APIVersion,
+		ClientAPIVersion:  cli.ClientVersion(),
+		OS:                info.OperatingSystem,
+		Arch:              info.Architecture,
+		KernelVersion:     info.KernelVersion,
+		NCPU:              info.NCPU,
+		MemTotal:          info.MemTotal,
+		Containers:        info.Containers,
+		ContainersRunning: info.ContainersRunning,
+		Images:            info.Images,
+	})
+}
+
+// ListContainers 列出 Docker 容器
 // @Summary 获取容器列表
-// @Description 获取本机所有 Docker 容器的详细信息
+// @Description 获取容器列表，包含状态、健康、端口、标签、网络和 Compose 项目。state / label / health / compose_project 由 Docker 过滤，name / image 为不区分大小写的子串匹配；结果按 sort 排序后分页，page_size 为 0 时返回全部
 // @Tags 容器管理
 // @Produce json
+// @Param state query string false "容器状态，逗号分隔：created,running,paused,restarting,exited,dead"
+// @Param name query string false "容器名子串"
+// @Param image query string false "镜像名子串"
+// @Param label query string false "标签过滤 key 或 key=value，可重复，需全部匹配"
+// @Param compose_project query string false "Compose 项目名"
+// @Param health query string false "健康状态：healthy / unhealthy / starting / none"
+// @Param sort query string false "排序字段：created（默认）/ name / image / state"
+// @Param order query string false "asc / desc，created 默认 desc，其余默认 asc"
+// @Param page query int false "页码，从 1 开始"
+// @Param page_size query int false "每页条数，默认 0 不分页，最大 500"
+// @Param size query bool false "返回容器可写层及总大小（较慢）"
+// @Param host query string false "Docker 主机ID，默认使用 default_host"
 // @Success 200 {object} models.ContainerListResponse "成功返回容器列表"
+// @Failure 400 {object} models.ErrorResponse "参数错误"
+// @Failure 404 {object} models.ErrorResponse "未知 Docker 主机"
 // @Failure 500 {object} models.ErrorResponse "服务器内部错误"
 // @Router /containers [get]
 func ListContainers(c *gin.Context) {
-	cli, err := client.NewClientWithOpts(client.FromEnv)
+	query, err := parseContainerListQuery(c)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Docker client init failed"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request", "detail": err.Error()})
+		return
+	}
+	cli, ok := dockerFor(c)
+	if !ok {
 		return
 	}
 
-	containers, err := cli.ContainerList(context.Background(), types.ContainerListOptions{All: true})
+	containers, err := cli.ContainerList(c.Request.Context(), query.options)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "List containers failed"})
+		dockerError(c, "List containers failed", err)
 		return
 	}
 
-	var containerInfos []gin.H
-	for _, container := range containers {
-		containerInfos = append(containerInfos, gin.H{
-			"id":      container.ID[:12],
-			"name":    container.Names[0],
-			"status":  container.Status,
-			"image":   container.Image,
-			"created": container.Created,
-		})
+	list := []models.ContainerInfo{}
+	for _, ctr := range containers {
+		info := toContainerInfo(ctr, query.options.Size)
+		if !containsFold(info.Name, query.name) || !containsFold(info.Image, query.image) {
+			continue
+		}
+		list = append(list, info)
 	}
+	sortContainerInfos(list, query.sort, query.desc)
+
+	resp := models.ContainerListResponse{Containers: list, Total: len(list), Page: query.page, PageSize: query.pageSize}
+	if query.pageSize > 0 {
+		from := min((query.page-1)*query.pageSize, len(list))
+		to := min(from+query.pageSize, len(list))
+		resp.Containers = list[from:to]
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// 容器列表可用的状态过滤值
+var containerStates = map[string]bool{"created": true, "running": true, "paused": true, "restarting": true, "removing": true, "exited": true, "dead": true}
 
-	c.JSON(http.StatusOK, gin.H{"containers": containerInfos})
+const maxContainerPageSize = 500
+
+// containerListQuery 容器列表的查询参数
+type containerListQuery struct {
+	options        types.ContainerListOptions
+	name, image    string
+	sort           string
+	desc           bool
+	page, pageSize int
+}
+
+// parseContainerListQuery 校验查询参数；能交给 Docker 的过滤条件放入 ContainerListOptions
+func parseContainerListQuery(c *gin.Context) (containerListQuery, error) {
+	args := filters.NewArgs()
+	q := containerListQuery{
+		name:  strings.TrimSpace(c.Query("name")),
+		image: strings.TrimSpace(c.Query("image")),
+		sort:  c.DefaultQuery("sort", "created"),
+		page:  1,
+	}
+	for _, state := range strings.Split(c.Query("state"), ",") {
+		if state = strings.TrimSpace(state); state == "" {
+			continue
+		}
+		if !containerStates[state] {
+			return q, fmt.Errorf("unknown state %q", state)
+		}
+		args.Add("status", state)
+	}
+	for _, label := range c.QueryArray("label") {
+		if label = strings.TrimSpace(label); label != "" {
+			args.Add("label", label)
+		}
+	}
+	if project := strings.TrimSpace(c.Query("compose_project")); project != "" {
+		args.Add("label", composeProjectLabel+"="+project)
+	}
+	if health := c.Query("health"); health != "" {
+		if health != "healthy" && health != "unhealthy" && health != "starting" && health != "none" {
+			return q, fmt.Errorf("unknown health %q", health)
+		}
+		args.Add("health", health)
+	}
+	q.options = types.ContainerListOptions{All: true, Size: c.Query("size") == "true", Filters: args}
+
+	switch q.sort {
+	case "created":
+		q.desc = true
+	case "name", "image", "state":
+	default:
+		return q, fmt.Errorf("unknown sort field %q", q.sort)
+	}
+	switch c.Query("order") {
+	case "":
+	case "asc":
+		q.desc = false
+	case "desc":
+		q.desc = true
+	default:
+		return q, fmt.Errorf("order must be asc or desc")
+	}
+
+	var err error
+	if v := c.Query("page"); v != "" {
+		if q.page, err = strconv.Atoi(v); err != nil || q.page < 1 {
+			return q, fmt.Errorf("invalid page %q", v)
+		}
+	}
+	if v := c.Query("page_size"); v != "" {
+		if q.pageSize, err = strconv.Atoi(v); err != nil || q.pageSize < 0 || q.pageSize > maxContainerPageSize {
+			return q, fmt.Errorf("page_size must be between 0 and %d", maxContainerPageSize)
+		}
+	}
+	return q, nil
+}
+
+func sortContainerInfos(list []models.ContainerInfo, field string, desc bool) {
+	key := func(info models.ContainerInfo) string {
+		switch field {
+		case "name":
+			return strings.ToLower(info.Name)
+		case "image":
+			return strings.ToLower(info.Image)
+		case "state":
+			return info.State
+		}
+		return ""
+	}
+	sort.SliceStable(list, func(i, j int) bool {
+		a, b := list[i], list[j]
+		if desc {
+			a, b = b, a
+		}
+		if field == "created" {
+			if a.Created != b.Created {
+				return a.Created < b.Created
+			}
+		} else if ka, kb := key(a), key(b); ka != kb {
+			return ka < kb
+		}
+		// 相同时按名称排序，保证分页稳定
+		return a.Name < b.Name
+	})
+}
+
+// toContainerInfo 将 docker ps 的结果整理为 models.ContainerInfo
+func toContainerInfo(ctr types.Container, withSize bool) models.ContainerInfo {
+	info := models.ContainerInfo{
+		ID:             shortID(ctr.ID),
+		Name:           containerName(ctr.Names),
+		Status:         ctr.Status,
+		State:          ctr.State,
+		Health:         healthFromStatus(ctr.Status),
+		Image:          ctr.Image,
+		ImageID:        ctr.ImageID,
+		Command:        ctr.Command,
+		Created:        ctr.Created,
+		Ports:          []models.ContainerPortBinding{},
+		Labels:         map[string]string{},
+		Networks:       []models.ContainerNetwork{},
+		ComposeProject: ctr.Labels[composeProjectLabel],
+	}
+	for k, v := range ctr.Labels {
+		info.Labels[k] = v
+	}
+	for _, p := range ctr.Ports {
+		binding := models.ContainerPortBinding{ContainerPort: strconv.Itoa(int(p.PrivatePort)), Protocol: p.Type, HostIP: p.IP}
+		if p.PublicPort != 0 {
+			binding.HostPort = strconv.Itoa(int(p.PublicPort))
+		}
+		info.Ports = append(info.Ports, binding)
+	}
+	sort.Slice(info.Ports, func(i, j int) bool {
+		if info.Ports[i].ContainerPort != info.Ports[j].ContainerPort {
+			return info.Ports[i].ContainerPort < info.Ports[j].ContainerPort
+		}
+		return info.Ports[i].HostIP < info.Ports[j].HostIP
+	})
+	if ctr.NetworkSettings != nil {
+		for name, ep := range ctr.NetworkSettings.Networks {
+			if ep == nil {
+				continue
+			}
+			info.Networks = append(info.Networks, models.ContainerNetwork{
+				Name:       name,
+				IPAddress:  ep.IPAddress,
+				Gateway:    ep.Gateway,
+				MacAddress: ep.MacAddress,
+				Aliases:    ep.Aliases,
+			})
+		}
+		sort.Slice(info.Networks, func(i, j int) bool { return info.Networks[i].Name < info.Networks[j].Name })
+	}
+	if withSize {
+		sizeRw, sizeRootFs := ctr.SizeRw, ctr.SizeRootFs
+		info.SizeRw, info.SizeRootFs = &sizeRw, &sizeRootFs
+	}
+	return info
+}
+
+// containerName 取容器的主名称；docker ps 会把 link 别名 (/other/alias) 也列在 Names 中
+func containerName(names []string) string {
+	for _, n := range names {
+		if strings.Count(n, "/") == 1 {
+			return n
+		}
+	}
+	if len(names) > 0 {
+		return names[0]
+	}
+	return ""
+}
+
+// healthFromStatus 从 docker ps 的状态文本中解析健康状态，如 "Up 2 hours (healthy)"
+func healthFromStatus(status string) string {
+	switch {
+	case strings.HasSuffix(status, "(healthy)"):
+		return "healthy"
+	case strings.HasSuffix(status, "(unhealthy)"):
+		return "unhealthy"
+	case strings.HasSuffix(status, "(health: starting)"):
+		return "starting"
+	}
+	return ""
+}
+
+func containsFold(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
+
+// InspectContainer 获取容器详情
+// @Summary 获取容器详情
+// @Description 返回容器挂载、网络、端口、重启策略、资源限制、健康状态、标签、启动命令及环境变量。名称匹配敏感关键字的环境变量默认脱敏，reveal=true 时显示原值
+// @Tags 容器管理
+// @Produce json
+// @Param id path string true "容器ID"
+// @Param reveal query bool false "显示敏感环境变量原值"
+// @Param host query string false "Docker 主机ID，默认使用 default_host"
+// @Success 200 {object} models.ContainerDetail "成功返回容器详情"
+// @Failure 404 {object} models.ErrorResponse "容器或 Docker 主机不存在"
+// @Failure 500 {object} models.ErrorResponse "服务器内部错误"
+// @Router /container/{id} [get]
+func InspectContainer(c *gin.Context) {
+	containerID := c.Param("id")
+	cli, ok := dockerFor(c)
+	if !ok {
+		return
+	}
+	info, err := cli.ContainerInspect(c.Request.Context(), containerID)
+	if err != nil {
+		dockerError(c, "Inspect container failed", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toContainerDetail(info, c.Query("reveal") == "true"))
 }
 
 // StartContainer 启动容器
 // @Summary 启动容器
 // @Description 启动指定容器
 // @Tags 容器管理
+// @Produce json
 // @Param id path string true "容器ID"
+// @Param host query string false "Docker 主机ID，默认使用 default_host"
 // @Success 200 {object} models.SuccessResponse "成功启动容器"
-// @Failure 400 {object} models.ErrorResponse "请求参数错误"
+// @Failure 404 {object} models.ErrorResponse "容器或 Docker 主机不存在"
 // @Failure 500 {object} models.ErrorResponse "服务器内部错误"
-// @Router /container/{id}/start [post]
+// @Router /container/start/{id} [post]
 func StartContainer(c *gin.Context) {
 	containerID := c.Param("id")
-	cli, err := client.NewClientWithOpts(client.FromEnv)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Docker client init failed"})
+	cli, ok := dockerFor(c)
+	if !ok {
 		return
 	}
-
-	if err := cli.ContainerStart(context.Background(), containerID, types.ContainerStartOptions{}); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to start container"})
+	if err := cli.ContainerStart(c.Request.Context(), containerID, types.ContainerStartOptions{}); err != nil {
+		dockerError(c, "Failed to start container", err)
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Container started"})
+	c.JSON(http.StatusOK, models.SuccessResponse{Code: 200, Message: "Container started"})
 }
 
 // StopContainer 停止指定容器
 // @Summary 停止容器
-// @Description 通过容器ID停止正在运行的容器
+// @Description 通过容器ID停止正在运行的容器，可指定停止等待秒数
 // @Tags 容器管理
+// @Produce json
 // @Param id path string true "容器ID"
+// @Param t query int false "停止等待秒数，默认 10"
+// @Param host query string false "Docker 主机ID，默认使用 default_host"
 // @Success 200 {object} models.SuccessResponse "成功停止容器"
 // @Failure 400 {object} models.ErrorResponse "请求参数错误"
+// @Failure 404 {object} models.ErrorResponse "容器或 Docker 主机不存在"
 // @Failure 500 {object} models.ErrorResponse "服务器内部错误"
-// @Router /container/{id}/stop [post]
+// @Router /container/stop/{id} [post]
 func StopContainer(c *gin.Context) {
 	containerID := c.Param("id")
-	cli, err := client.NewClientWithOpts(client.FromEnv)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Docker client init failed"})
+	seconds, err := strconv.Atoi(c.DefaultQuery("t", "10"))
+	if err != nil || seconds < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid timeout"})
+		return
+	}
+	timeout := time.Duration(seconds) * time.Second
+	cli, ok := dockerFor(c)
+	if !ok {
+		return
+	}
+	if err := cli.ContainerStop(c.Request.Context(), containerID, &timeout); err != nil {
+		dockerError(c, "Failed to stop container", err)
 		return
 	}
 
-	timeout := 10 * time.Second
-	if err := cli.ContainerStop(context.Background(), containerID, &timeout); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to stop container"})
+	c.JSON(http.StatusOK, models.SuccessResponse{Code: 200, Message: "Container stopped"})
+}
+
+// RestartContainer 重启容器
+// @Summary 重启容器
+// @Description 重启指定容器，可指定停止等待秒数
+// @Tags 容器管理
+// @Produce json
+// @Param id path string true "容器ID"
+// @Param t query int false "停止等待秒数，默认 10"
+// @Param host query string false "Docker 主机ID，默认使用 default_host"
+// @Success 200 {object} models.SuccessResponse "成功重启容器"
+// @Failure 400 {object} models.ErrorResponse "请求参数错误"
+// @Failure 404 {object} models.ErrorResponse "容器或 Docker 主机不存在"
+// @Failure 500 {object} models.ErrorResponse "服务器内部错误"
+// @Router /container/restart/{id} [post]
+func RestartContainer(c *gin.Context) {
+	containerID := c.Param("id")
+	seconds, err := strconv.Atoi(c.DefaultQuery("t", "10"))
+	if err != nil || seconds < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid timeout"})
+		return
+	}
+	timeout := time.Duration(seconds) * time.Second
+	cli, ok := dockerFor(c)
+	if !ok {
+		return
+	}
+	if err := cli.ContainerRestart(c.Request.Context(), containerID, &timeout); err != nil {
+		dockerError(c, "Failed to restart container", err)
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Container stopped"})
+	c.JSON(http.StatusOK, models.SuccessResponse{Code: 200, Message: "Container restarted"})
+}
+
+// PauseContainer 暂停容器
+// @Summary 暂停容器
+// @Description 冻结指定容器内的所有进程
+// @Tags 容器管理
+// @Produce json
+// @Param id path string true "容器ID"
+// @Param host query string false "Docker 主机ID，默认使用 default_host"
+// @Success 200 {object} models.SuccessResponse "成功暂停容器"
+// @Failure 404 {object} models.ErrorResponse "容器或 Docker 主机不存在"
+// @Failure 409 {object} models.ErrorResponse "容器未运行"
+// @Failure 500 {object} models.ErrorResponse "服务器内部错误"
+// @Router /container/pause/{id} [post]
+func PauseContainer(c *gin.Context) {
+	containerID := c.Param("id")
+	cli, ok := dockerFor(c)
+	if !ok {
+		return
+	}
+	if err := cli.ContainerPause(c.Request.Context(), containerID); err != nil {
+		dockerError(c, "Failed to pause container", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse{Code: 200, Message: "Container paused"})
+}
+
+// UnpauseContainer 恢复容器
+// @Summary 恢复暂停的容器
+// @Description 恢复被暂停容器内的所有进程
+// @Tags 容器管理
+// @Produce json
+// @Param id path string true "容器ID"
+// @Param host query string false "Docker 主机ID，默认使用 default_host"
+// @Success 200 {object} models.SuccessResponse "成功恢复容器"
+// @Failure 404 {object} models.ErrorResponse "容器或 Docker 主机不存在"
+// @Failure 409 {object} models.ErrorResponse "容器未暂停"
+// @Failure 500 {object} models.ErrorResponse "服务器内部错误"
+// @Router /container/unpause/{id} [post]
+func UnpauseContainer(c *gin.Context) {
+	containerID := c.Param("id")
+	cli, ok := dockerFor(c)
+	if !ok {
+		return
+	}
+	if err := cli.ContainerUnpause(c.Request.Context(), containerID); err != nil {
+		dockerError(c, "Failed to unpause container", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse{Code: 200, Message: "Container unpaused"})
+}
+
+// KillContainer 向容器发送信号
+// @Summary 强制终止容器
+// @Description 向容器主进程发送指定信号，默认 SIGKILL
+// @Tags 容器管理
+// @Produce json
+// @Param id path string true "容器ID"
+// @Param signal query string false "信号名或编号，如 SIGTERM、SIGHUP、9"
+// @Param host query string false "Docker 主机ID，默认使用 default_host"
+// @Success 200 {object} models.SuccessResponse "信号发送成功"
+// @Failure 400 {object} models.ErrorResponse "信号无效"
+// @Failure 404 {object} models.ErrorResponse "容器或 Docker 主机不存在"
+// @Failure 409 {object} models.ErrorResponse "容器未运行"
+// @Failure 500 {object} models.ErrorResponse "服务器内部错误"
+// @Router /container/kill/{id} [post]
+func KillContainer(c *gin.Context) {
+	containerID := c.Param("id")
+	signal := strings.ToUpper(strings.TrimSpace(c.DefaultQuery("signal", "SIGKILL")))
+	cli, ok := dockerFor(c)
+	if !ok {
+		return
+	}
+	if err := cli.ContainerKill(c.Request.Context(), containerID, signal); err != nil {
+		dockerError(c, "Failed to kill container", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse{Code: 200, Message: "Container killed with " + signal})
+}
+
+// RenameContainer 重命名容器
+// @Summary 重命名容器
+// @Tags 容器管理
+// @Accept json
+// @Produce json
+// @Param id path string true "容器ID"
+// @Param rename body models.RenameContainerRequest true "新容器名"
+// @Param host query string false "Docker 主机ID，默认使用 default_host"
+// @Success 200 {object} models.SuccessResponse "重命名成功"
+// @Failure 400 {object} models.ErrorResponse "请求参数错误"
+// @Failure 404 {object} models.ErrorResponse "容器或 Docker 主机不存在"
+// @Failure 409 {object} models.ErrorResponse "名称已被占用"
+// @Failure 500 {object} models.ErrorResponse "服务器内部错误"
+// @Router /container/rename/{id} [post]
+func RenameContainer(c *gin.Context) {
+	containerID := c.Param("id")
+	var req models.RenameContainerRequest
+	if err := c.ShouldBindJSON(&req); err != nil || strings.TrimSpace(req.Name) == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+	cli, ok := dockerFor(c)
+	if !ok {
+		return
+	}
+	if err := cli.ContainerRename(c.Request.Context(), containerID, strings.TrimSpace(req.Name)); err != nil {
+		dockerError(c, "Failed to rename container", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse{Code: 200, Message: "Container renamed"})
+}
+
+// RemoveContainer 删除容器
+// @Summary 删除容器
+// @Description 删除指定容器，可选同时删除匿名卷、强制删除运行中的容器
+// @Tags 容器管理
+// @Produce json
+// @Param id path string true "容器ID"
+// @Param force query bool false "强制删除运行中的容器"
+// @Param volumes query bool false "同时删除容器的匿名卷"
+// @Param host query string false "Docker 主机ID，默认使用 default_host"
+// @Success 200 {object} models.SuccessResponse "删除成功"
+// @Failure 404 {object} models.ErrorResponse "容器或 Docker 主机不存在"
+// @Failure 409 {object} models.ErrorResponse "容器运行中且未指定 force"
+// @Failure 500 {object} models.ErrorResponse "服务器内部错误"
+// @Router /container/remove/{id} [post]
+func RemoveContainer(c *gin.Context) {
+	containerID := c.Param("id")
+	cli, ok := dockerFor(c)
+	if !ok {
+		return
+	}
+	options := types.ContainerRemoveOptions{
+		Force:         c.Query("force") == "true",
+		RemoveVolumes: c.Query("volumes") == "true",
+	}
+	if err := cli.ContainerRemove(c.Request.Context(), containerID, options); err != nil {
+		dockerError(c, "Failed to remove container", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse{Code: 200, Message: "Container removed"})
 }
 
 // CreateContainer 创建 Docker 容器
 // @Summary 创建容器
-// @Description 创建一个新的 Docker 容器，支持设置端口映射、卷挂载、环境变量、资源限制等
+// @Description 创建一个新的 Docker 容器，支持设置端口映射、卷挂载、环境变量、资源限制等；wait=true 时等待容器就绪（有健康检查时为 healthy）再返回
 // @Tags 容器管理
 // @Accept json
 // @Produce json
 // @Param container body models.CreateContainerRequest true "创建容器请求参数"
+// @Param host query string false "Docker 主机ID，默认使用 default_host"
 // @Success 200 {object} models.CreateContainerResponse "创建成功返回容器ID"
 // @Failure 400 {object} models.ErrorResponse "请求参数错误"
-// @Failure 500 {object} models.ErrorResponse "服务器内部错误"
+// @Failure 401 {object} models.ErrorResponse "私有仓库认证失败"
+// @Failure 404 {object} models.ErrorResponse "未知 Docker 主机"
+// @Failure 500 {object} models.ContainerNotReadyResponse "容器启动失败或未就绪"
 // @Router /container/create [post]
 func CreateContainer(c *gin.Context) {
-	var req struct {
-		Name    string `json:"name"`
-		Image   string `json:"image"`
-		Ports   string `json:"ports"`
-		Volumes string `json:"volumes"`
-		Envs    string `json:"envs"`
-		CPU     string `json:"cpu"`
-		Memory  string `json:"memory"`
-		Restart string `json:"restart"`
-		Network string `json:"network"`
-	}
+	var req models.CreateContainerRequest
 	if err := c.ShouldBindJSON(&req); err != nil || req.Image == "" {
 		log.Printf("❌ Invalid request body: %v", err)
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
 		return
 	}
 
-	cli, err := client.NewClientWithOpts(client.FromEnv)
-	if err != nil {
-		log.Printf("❌ Docker client init failed: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Docker client init failed"})
+	id, ok := createContainer(c, legacyCreateRequest(req))
+	if !ok {
 		return
 	}
+	c.JSON(http.StatusOK, gin.H{"message": "Container created", "id": id})
+}
 
-	ctx := context.Background()
-
-	// 标准化 image 名
-	if !strings.Contains(req.Image, ":") {
-		req.Image = req.Image + ":latest"
+// toContainerDetail 将 docker inspect 结果整理为 models.ContainerDetail
+func toContainerDetail(info types.ContainerJSON, reveal bool) models.ContainerDetail {
+	detail := models.ContainerDetail{
+		ID:           info.ID,
+		Name:         info.Name,
+		ImageID:      info.Image,
+		Created:      info.Created,
+		RestartCount: info.RestartCount,
+		Labels:       map[string]string{},
+		Mounts:       []models.ContainerMount{},
+		Networks:     []models.ContainerNetwork{},
+		Ports:        []models.ContainerPortBinding{},
 	}
 
-	// 先 inspect
-	_, _, err = cli.ImageInspectWithRaw(ctx, req.Image)
-	if err != nil {
-		log.Printf("镜像不存在，本地拉取: %s", req.Image)
-
-		out, pullErr := cli.ImagePull(ctx, req.Image, types.ImagePullOptions{
-			RegistryAuth: encodeAuthToBase64(types.AuthConfig{}),
-		})
-		if pullErr != nil {
-			log.Printf("❌ Image pull failed: %v", pullErr)
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Pull image failed", "detail": pullErr.Error()})
-			return
+	if st := info.State; st != nil {
+		detail.State = models.ContainerState{
+			Status:     st.Status,
+			Running:    st.Running,
+			Paused:     st.Paused,
+			Restarting: st.Restarting,
+			OOMKilled:  st.OOMKilled,
+			ExitCode:   st.ExitCode,
+			Error:      st.Error,
+			StartedAt:  st.StartedAt,
+			FinishedAt: st.FinishedAt,
+		}
+		if st.Health != nil {
+			health := &models.ContainerHealth{Status: st.Health.Status, FailingStreak: st.Health.FailingStreak}
+			if n := len(st.Health.Log); n > 0 && st.Health.Log[n-1] != nil {
+				health.LastOutput = strings.TrimSpace(st.Health.Log[n-1].Output)
+				health.LastExitCode = st.Health.Log[n-1].ExitCode
+			}
+			detail.State.Health = health
 		}
+	}
 
-		defer out.Close()
+	if cfg := info.Config; cfg != nil {
+		detail.Image = cfg.Image
+		detail.Entrypoint = cfg.Entrypoint
+		detail.Command = cfg.Cmd
+		detail.WorkingDir = cfg.WorkingDir
+		detail.User = cfg.User
+		detail.Env, detail.EnvMasked = maskEnv(cfg.Env, reveal)
+		for k, v := range cfg.Labels {
+			detail.Labels[k] = v
+		}
+	}
 
-		// 读取拉取流，确保拉取完成
-		io.Copy(io.Discard, out)
-		log.Println("镜像拉取完成！")
+	for _, m := range info.Mounts {
+		detail.Mounts = append(detail.Mounts, models.ContainerMount{
+			Type:        string(m.Type),
+			Name:        m.Name,
+			Source:      m.Source,
+			Destination: m.Destination,
+			Mode:        m.Mode,
+			RW:          m.RW,
+		})
 	}
 
-	// 端口映射
-	exposedPorts := nat.PortSet{}
-	portBindings := nat.PortMap{}
-	if req.Network != "host" && req.Ports != "" {
-		portPairs := strings.Split(req.Ports, ",")
-		for _, p := range portPairs {
-			parts := strings.Split(p, ":")
-			if len(parts) != 2 {
+	if ns := info.NetworkSettings; ns != nil {
+		for name, ep := range ns.Networks {
+			if ep == nil {
 				continue
 			}
-			portKey := nat.Port(parts[1] + "/tcp")
-			exposedPorts[portKey] = struct{}{}
-			portBindings[portKey] = []nat.PortBinding{{HostPort: parts[0]}}
+			detail.Networks = append(detail.Networks, models.ContainerNetwork{
+				Name:       name,
+				IPAddress:  ep.IPAddress,
+				Gateway:    ep.Gateway,
+				MacAddress: ep.MacAddress,
+				Aliases:    ep.Aliases,
+			})
 		}
+		sort.Slice(detail.Networks, func(i, j int) bool { return detail.Networks[i].Name < detail.Networks[j].Name })
 	}
 
-	// 目录挂载
-	var binds []string
-	if req.Volumes != "" {
-		volList := strings.Split(req.Volumes, ",")
-		for _, v := range volList {
-			binds = append(binds, v)
+	if hc := info.HostConfig; hc != nil {
+		for port, bindings := range hc.PortBindings {
+			for _, b := range bindings {
+				detail.Ports = append(detail.Ports, models.ContainerPortBinding{
+					ContainerPort: port.Port(),
+					Protocol:      port.Proto(),
+					HostIP:        b.HostIP,
+					HostPort:      b.HostPort,
+				})
+			}
 		}
+		sort.Slice(detail.Ports, func(i, j int) bool {
+			if detail.Ports[i].ContainerPort != detail.Ports[j].ContainerPort {
+				return detail.Ports[i].ContainerPort < detail.Ports[j].ContainerPort
+			}
+			return detail.Ports[i].Protocol < detail.Ports[j].Protocol
+		})
+		detail.RestartPolicy = models.ContainerRestartPolicy{
+			Name:              hc.RestartPolicy.Name,
+			MaximumRetryCount: hc.RestartPolicy.MaximumRetryCount,
+		}
+		detail.Resources = toContainerResources(hc)
 	}
 
-	// 环境变量
-	var envList []string
-	if req.Envs != "" {
-		envList = strings.Split(req.Envs, ",")
-	}
+	return detail
+}
 
-	// 资源限制
-	hostConfig := &container.HostConfig{
-		Binds:        binds,
-		PortBindings: portBindings,
-	}
-	if req.CPU != "" {
-		hostConfig.NanoCPUs = parseCPU(req.CPU)
-	}
-	if req.Memory != "" {
-		memLimit := parseMemory(req.Memory)
-		hostConfig.Memory = memLimit
-	}
-	if req.Restart != "" {
-		hostConfig.RestartPolicy = container.RestartPolicy{Name: req.Restart}
-	}
-	if req.Network != "" {
-		hostConfig.NetworkMode = container.NetworkMode(req.Network)
-	}
+// 默认的敏感环境变量关键字，config.yaml 中未配置 docker.secret_env_patterns 时使用
+var defaultSecretEnvPatterns = []string{"PASSWORD", "PASSWD", "SECRET", "TOKEN", "API_KEY", "PRIVATE_KEY"}
 
-	resp, err := cli.ContainerCreate(ctx, &container.Config{
-		Image:        req.Image,
-		Env:          envList,
-		ExposedPorts: exposedPorts,
-	}, hostConfig, &network.NetworkingConfig{}, nil, req.Name)
-	if err != nil {
-		log.Printf("❌ Container create failed: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Create failed", "detail": err.Error()})
-		return
+// maskEnv 对名称命中敏感关键字的环境变量值脱敏，返回是否有变量被脱敏
+func maskEnv(env []string, reveal bool) ([]string, bool) {
+	patterns := config.Conf.Docker.SecretEnvPatterns
+	if len(patterns) == 0 {
+		patterns = defaultSecretEnvPatterns
 	}
 
-	cli.ContainerStart(ctx, resp.ID, types.ContainerStartOptions{})
-	c.JSON(http.StatusOK, gin.H{"message": "Container created", "id": resp.ID[:12]})
-}
-
-// 辅助函数 解析 CPU
-func parseCPU(cpu string) int64 {
-	val, err := parseFloat(cpu)
-	if err != nil {
-		return 0
+	masked := false
+	result := make([]string, 0, len(env))
+	for _, kv := range env {
+		key, _, hasValue := strings.Cut(kv, "=")
+		if !reveal && hasValue && isSecretEnv(key, patterns) {
+			result = append(result, key+"=******")
+			masked = true
+			continue
+		}
+		result = append(result, kv)
 	}
-	return int64(val * 1e9) // CPU 核心数 → NanoCPU
+	return result, masked
 }
 
-// 辅助函数 解析内存
-func parseMemory(mem string) int64 {
-	val, err := parseFloat(mem)
-	if err != nil {
-		return 0
+func isSecretEnv(key string, patterns []string) bool {
+	upper := strings.ToUpper(key)
+	for _, p := range patterns {
+		if p != "" && strings.Contains(upper, strings.ToUpper(p)) {
+			return true
+		}
 	}
-	return int64(val * 1024 * 1024) // MB → Bytes
+	return false
 }
 
 // 辅助函数 → Base64 Encode
@@ -268,52 +820,16 @@ func parseFloat(s string) (float64, error) {
 	return strconv.ParseFloat(strings.TrimSpace(s), 64)
 }
 
+// shortID 截取 Docker 对象 ID 的前 12 位，与 docker ps 显示一致
+func shortID(id string) string {
+	if len(id) > 12 {
+		return id[:12]
+	}
+	return id
+}
+
 var wsUpgrader = websocket.Upgrader{
 	CheckOrigin: func(r *http.Request) bool {
 		return true
 	},
 }
-
-// ContainerLogsWS 容器日志 WebSocket
-// @Summary 实时获取容器日志
-// @Description 通过 WebSocket 连接获取指定容器的实时日志流，连接后服务器持续推送日志内容
-// @Tags 容器管理
-// @Param id path string true "容器ID"
-// @Produce plain
-// @Success 101 {string} string "WebSocket 连接已建立，开始推送日志"
-// @Failure 500 {object} models.ErrorResponse "Docker client 初始化失败 或 WebSocket 升级失败"
-// @Router /container/{id}/logs/ws [get]
-func ContainerLogsWS(c *gin.Context) {
-	containerID := c.Param("id")
-	cli, err := client.NewClientWithOpts(client.FromEnv)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Docker client init failed"})
-		return
-	}
-
-	conn, err := wsUpgrader.Upgrade(c.Writer, c.Request, nil)
-	if err != nil {
-		log.Printf("WebSocket upgrade error: %v", err)
-		return
-	}
-	defer conn.Close()
-
-	options := types.ContainerLogsOptions{ShowStdout: true, ShowStderr: true, Follow: true, Tail: "50"}
-	out, err := cli.ContainerLogs(context.Background(), containerID, options)
-	if err != nil {
-		conn.WriteMessage(websocket.TextMessage, []byte("Error fetching logs"))
-		return
-	}
-	defer out.Close()
-
-	buf := make([]byte, 1024)
-	for {
-		n, err := out.Read(buf)
-		if n > 0 {
-			conn.WriteMessage(websocket.TextMessage, buf[:n])
-		}
-		if err != nil {
-			break
-		}
-	}
-}
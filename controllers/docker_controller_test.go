@@ -0,0 +1,139 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"testing"
+
+	"auto-deploy-platform/models"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/gin-gonic/gin"
+)
+
+func seedContainers(t *testing.T) *gin.Engine {
+	t.Helper()
+	fake := useFakeDocker(t)
+	fake.AddContainer(types.Container{Names: []string{"/web"}, Image: "nginx:1.25", State: "running", Status: "Up 2 hours", Created: 300,
+		Labels: map[string]string{composeProjectLabel: "blog", "tier": "front"}})
+	fake.AddContainer(types.Container{Names: []string{"/db"}, Image: "mysql:8", State: "running", Status: "Up 2 hours", Created: 200,
+		Labels: map[string]string{composeProjectLabel: "blog"}})
+	fake.AddContainer(types.Container{Names: []string{"/job"}, Image: "busybox", State: "exited", Status: "Exited (0) 1 hour ago", Created: 100})
+
+	r := gin.New()
+	r.GET("/containers", ListContainers)
+	return r
+}
+
+func containerNames(list []models.ContainerInfo) []string {
+	names := make([]string, 0, len(list))
+	for _, ctr := range list {
+		names = append(names, ctr.Name)
+	}
+	return names
+}
+
+func TestListContainers(t *testing.T) {
+	r := seedContainers(t)
+
+	tests := []struct {
+		name  string
+		query string
+		want  []string
+		total int
+	}{
+		{"all by created desc", "", []string{"/web", "/db", "/job"}, 3},
+		{"state filter", "?state=exited", []string{"/job"}, 1},
+		{"label filter", "?label=tier=front", []string{"/web"}, 1},
+		{"compose project", "?compose_project=blog&sort=name", []string{"/db", "/web"}, 2},
+		{"name substring", "?name=WE", []string{"/web"}, 1},
+		{"image substring", "?image=mysql", []string{"/db"}, 1},
+		{"sort by name desc", "?sort=name&order=desc", []string{"/web", "/job", "/db"}, 3},
+		{"second page", "?sort=name&page=2&page_size=2", []string{"/web"}, 3},
+		{"page past end", "?page=3&page_size=2", []string{}, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var resp models.ContainerListResponse
+			decode(t, serve(r, http.MethodGet, "/containers"+tt.query, ""), http.StatusOK, &resp)
+			if got := containerNames(resp.Containers); !slices.Equal(got, tt.want) {
+				t.Errorf("containers = %v, want %v", got, tt.want)
+			}
+			if resp.Total != tt.total {
+				t.Errorf("total = %d, want %d", resp.Total, tt.total)
+			}
+		})
+	}
+}
+
+func TestListContainersFields(t *testing.T) {
+	r := seedContainers(t)
+
+	var resp models.ContainerListResponse
+	decode(t, serve(r, http.MethodGet, "/containers?name=web", ""), http.StatusOK, &resp)
+	if len(resp.Containers) != 1 {
+		t.Fatalf("containers = %v", containerNames(resp.Containers))
+	}
+	web := resp.Containers[0]
+	if len(web.ID) != 12 || web.Image != "nginx:1.25" || web.State != "running" || web.ComposeProject != "blog" {
+		t.Errorf("unexpected container info %+v", web)
+	}
+}
+
+func TestListContainersErrors(t *testing.T) {
+	r := seedContainers(t)
+
+	for _, query := range []string{"?state=sleeping", "?health=sick", "?sort=size", "?order=up", "?page=0", "?page_size=501"} {
+		if w := serve(r, http.MethodGet, "/containers"+query, ""); w.Code != http.StatusBadRequest {
+			t.Errorf("%s: status = %d, want 400", query, w.Code)
+		}
+	}
+	if w := serve(r, http.MethodGet, "/containers?host=nowhere", ""); w.Code != http.StatusNotFound {
+		t.Errorf("unknown host: status = %d, want 404", w.Code)
+	}
+}
+
+func TestListContainersDaemonError(t *testing.T) {
+	fake := useFakeDocker(t)
+	fake.Errors["ContainerList"] = errors.New("daemon unavailable")
+	r := gin.New()
+	r.GET("/containers", ListContainers)
+
+	var resp map[string]string
+	decode(t, serve(r, http.MethodGet, "/containers", ""), http.StatusInternalServerError, &resp)
+	if resp["detail"] != "daemon unavailable" {
+		t.Errorf("detail = %q", resp["detail"])
+	}
+}
+
+func TestStartStopContainer(t *testing.T) {
+	fake := useFakeDocker(t)
+	fake.AddImage("nginx:latest")
+	created, err := fake.ContainerCreate(context.Background(), &container.Config{Image: "nginx:latest"}, nil, nil, nil, "web")
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := gin.New()
+	r.POST("/container/start/:id", StartContainer)
+	r.POST("/container/stop/:id", StopContainer)
+
+	var resp models.SuccessResponse
+	decode(t, serve(r, http.MethodPost, "/container/start/web", ""), http.StatusOK, &resp)
+	if resp.Message != "Container started" {
+		t.Errorf("message = %q", resp.Message)
+	}
+	if ctr, _ := fake.Container(created.ID); ctr.Summary.State != "running" {
+		t.Errorf("state after start = %s", ctr.Summary.State)
+	}
+	decode(t, serve(r, http.MethodPost, "/container/stop/web?t=0", ""), http.StatusOK, &resp)
+	if ctr, _ := fake.Container(created.ID); ctr.Summary.State != "exited" {
+		t.Errorf("state after stop = %s", ctr.Summary.State)
+	}
+
+	decode(t, serve(r, http.MethodPost, "/container/start/missing", ""), http.StatusNotFound, nil)
+	decode(t, serve(r, http.MethodPost, "/container/stop/missing", ""), http.StatusNotFound, nil)
+	decode(t, serve(r, http.MethodPost, "/container/stop/web?t=-1", ""), http.StatusBadRequest, nil)
+}
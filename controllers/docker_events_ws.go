@@ -0,0 +1,170 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/events"
+	"github.com/docker/docker/api/types/filters"
+	timetypes "github.com/docker/docker/api/types/time"
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+// eventsPingInterval 事件可能长时间没有，定时 ping 避免代理断开空闲连接
+const eventsPingInterval = 30 * time.Second
+
+var eventTypes = map[string]bool{
+	events.ContainerEventType: true,
+	events.ImageEventType:     true,
+	events.NetworkEventType:   true,
+	events.VolumeEventType:    true,
+	events.DaemonEventType:    true,
+	events.PluginEventType:    true,
+}
+
+// DockerEventFrame 事件 WebSocket 消息，每个事件一条
+type DockerEventFrame struct {
+	Type           string            `json:"type"`             // container / image / network / volume / daemon，出错时为 error
+	Action         string            `json:"action"`           // start / die / oom / health_status / pull / delete / connect ...
+	Status         string            `json:"status,omitempty"` // health_status 的健康状态，exec_* 的命令
+	ID             string            `json:"id"`
+	Name           string            `json:"name,omitempty"`
+	ComposeProject string            `json:"compose_project,omitempty"`
+	Attributes     map[string]string `json:"attributes,omitempty"`
+	Time           string            `json:"time,omitempty"` // RFC3339Nano，断线重连时作为 since 传回即可续传
+	Error          string            `json:"error,omitempty"`
+}
+
+// DockerEventsWS Docker 事件 WebSocket
+// @Summary 实时推送 Docker 事件
+// @Description 转发 Docker 守护进程的事件流（容器 start/die/oom/health_status、镜像 pull/delete、网络和数据卷事件），每个事件一条 JSON 消息，供容器和 Compose 页面实时刷新。type / event / container 可用逗号分隔多个值，label 可重复传入且需全部匹配；compose_project 按容器的 Compose 项目标签过滤，网络和数据卷事件不带标签会被排除。出错时推送 type=error 的消息后关闭连接
+// @Tags 容器管理
+// @Param type query string false "事件类型：container,image,network,volume,daemon"
+// @Param event query string false "事件动作，如 start,die,oom,health_status"
+// @Param container query string false "容器ID或名称，逗号分隔"
+// @Param image query string false "镜像名"
+// @Param label query string false "标签过滤 key 或 key=value，可重复"
+// @Param compose_project query string false "Compose 项目名"
+// @Param since query string false "先补发该时间之后的事件：RFC3339、Unix 时间戳或相对时长 (10m)"
+// @Param host query string false "Docker 主机ID，默认使用 default_host"
+// @Success 101 {object} DockerEventFrame "WebSocket 连接已建立，逐条推送事件"
+// @Failure 400 {object} models.ErrorResponse "参数错误"
+// @Failure 404 {object} models.ErrorResponse "未知 Docker 主机"
+// @Router /ws/docker-events [get]
+func DockerEventsWS(c *gin.Context) {
+	options, err := parseEventsOptions(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request", "detail": err.Error()})
+		return
+	}
+	cli, ok := dockerFor(c)
+	if !ok {
+		return
+	}
+
+	conn, err := wsUpgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		log.Printf("WebSocket upgrade error: %v", err)
+		return
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// 客户端断开时结束事件流
+	go func() {
+		defer cancel()
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	messages, errs := cli.Events(ctx, options)
+	ping := time.NewTicker(eventsPingInterval)
+	defer ping.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg := <-messages:
+			if err := conn.WriteJSON(toEventFrame(msg)); err != nil {
+				return
+			}
+		case err := <-errs:
+			if ctx.Err() != nil {
+				return
+			}
+			log.Printf("❌ Docker events failed: %v", err)
+			conn.WriteJSON(DockerEventFrame{Type: "error", Error: err.Error()})
+			return
+		case <-ping.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(5*time.Second)); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// parseEventsOptions 把查询参数转换为 Docker 事件过滤条件
+func parseEventsOptions(c *gin.Context) (types.EventsOptions, error) {
+	args := filters.NewArgs()
+	for _, key := range []string{"type", "event", "container", "image"} {
+		for _, value := range c.QueryArray(key) {
+			for _, v := range strings.Split(value, ",") {
+				if v = strings.TrimSpace(v); v == "" {
+					continue
+				}
+				if key == "type" && !eventTypes[v] {
+					return types.EventsOptions{}, fmt.Errorf("unknown event type %q", v)
+				}
+				args.Add(key, v)
+			}
+		}
+	}
+	for _, label := range c.QueryArray("label") {
+		if label = strings.TrimSpace(label); label != "" {
+			args.Add("label", label)
+		}
+	}
+	if project := strings.TrimSpace(c.Query("compose_project")); project != "" {
+		args.Add("label", composeProjectLabel+"="+project)
+	}
+
+	options := types.EventsOptions{Filters: args}
+	if since := c.Query("since"); since != "" {
+		if _, err := timetypes.GetTimestamp(since, time.Now()); err != nil {
+			return options, fmt.Errorf("invalid since %q: %v", since, err)
+		}
+		options.Since = since
+	}
+	return options, nil
+}
+
+func toEventFrame(msg events.Message) DockerEventFrame {
+	frame := DockerEventFrame{
+		Type:           msg.Type,
+		Action:         msg.Action,
+		ID:             msg.Actor.ID,
+		Name:           msg.Actor.Attributes["name"],
+		ComposeProject: msg.Actor.Attributes[composeProjectLabel],
+		Attributes:     msg.Actor.Attributes,
+		Time:           time.Unix(0, msg.TimeNano).UTC().Format(time.RFC3339Nano),
+	}
+	if msg.TimeNano == 0 {
+		frame.Time = time.Unix(msg.Time, 0).UTC().Format(time.RFC3339Nano)
+	}
+	// health_status: healthy、exec_start: sh -c ... 拆成动作和状态
+	if action, status, ok := strings.Cut(msg.Action, ":"); ok {
+		frame.Action, frame.Status = action, strings.TrimSpace(status)
+	}
+	return frame
+}
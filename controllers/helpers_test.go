@@ -0,0 +1,55 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"auto-deploy-platform/config"
+	"auto-deploy-platform/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+// useFakeDocker 让控制器通过内存版 Docker 服务访问主机 local，测试结束后恢复原来的主机列表
+func useFakeDocker(t *testing.T) *services.FakeDockerService {
+	t.Helper()
+	fake := services.NewFakeDockerService()
+	old := dockerHosts
+	InitDockerHosts(services.NewHostRegistry(config.DockerConfig{}, func(config.DockerHostConfig) (services.DockerService, error) {
+		return fake, nil
+	}))
+	t.Cleanup(func() { dockerHosts = old })
+	return fake
+}
+
+// serve 向路由发送请求，body 非空时按 JSON 发送
+func serve(r http.Handler, method, target, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+// decode 检查状态码并把响应解析到 v
+func decode(t *testing.T, w *httptest.ResponseRecorder, status int, v interface{}) {
+	t.Helper()
+	if w.Code != status {
+		t.Fatalf("status = %d, want %d, body: %s", w.Code, status, w.Body.String())
+	}
+	if v == nil {
+		return
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), v); err != nil {
+		t.Fatalf("decode %s: %v", w.Body.String(), err)
+	}
+}
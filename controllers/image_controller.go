@@ -0,0 +1,395 @@
+package controllers
+
+import (
+	"auto-deploy-platform/models"
+	"auto-deploy-platform/services"
+	"context"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/pkg/jsonmessage"
+	"github.com/gin-gonic/gin"
+)
+
+// ListImages 列出本地镜像
+// @Summary 获取镜像列表
+// @Description 列出本地镜像的大小、标签以及正在使用它的容器
+// @Tags 镜像管理
+// @Produce json
+// @Param dangling query bool false "只列出悬空镜像"
+// @Param host query string false "Docker 主机ID，默认使用 default_host"
+// @Success 200 {object} models.ImageListResponse "成功返回镜像列表"
+// @Failure 404 {object} models.ErrorResponse "未知 Docker 主机"
+// @Failure 500 {object} models.ErrorResponse "服务器内部错误"
+// @Router /images [get]
+func ListImages(c *gin.Context) {
+	cli, ok := dockerFor(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	args := filters.NewArgs()
+	if c.Query("dangling") == "true" {
+		args.Add("dangling", "true")
+	}
+	images, err := cli.ImageList(ctx, types.ImageListOptions{Filters: args})
+	if err != nil {
+		dockerError(c, "List images failed", err)
+		return
+	}
+	users, err := imageUsers(ctx, cli)
+	if err != nil {
+		dockerError(c, "List containers failed", err)
+		return
+	}
+
+	list := []models.ImageInfo{}
+	for _, img := range images {
+		list = append(list, toImageInfo(img, users))
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Created > list[j].Created })
+
+	c.JSON(http.StatusOK, models.ImageListResponse{Images: list})
+}
+
+// InspectImage 获取镜像详情
+// @Summary 获取镜像详情
+// @Description 返回镜像配置、层列表和构建历史
+// @Tags 镜像管理
+// @Produce json
+// @Param id query string true "镜像ID或名称，如 nginx:latest"
+// @Param host query string false "Docker 主机ID，默认使用 default_host"
+// @Success 200 {object} models.ImageDetail "成功返回镜像详情"
+// @Failure 400 {object} models.ErrorResponse "参数错误"
+// @Failure 404 {object} models.ErrorResponse "镜像或 Docker 主机不存在"
+// @Failure 500 {object} models.ErrorResponse "服务器内部错误"
+// @Router /image/inspect [get]
+func InspectImage(c *gin.Context) {
+	ref := strings.TrimSpace(c.Query("id"))
+	if ref == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+	cli, ok := dockerFor(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	img, _, err := cli.ImageInspectWithRaw(ctx, ref)
+	if err != nil {
+		dockerError(c, "Inspect image failed", err)
+		return
+	}
+	history, err := cli.ImageHistory(ctx, img.ID)
+	if err != nil {
+		dockerError(c, "Get image history failed", err)
+		return
+	}
+	users, err := imageUsers(ctx, cli)
+	if err != nil {
+		dockerError(c, "List containers failed", err)
+		return
+	}
+
+	detail := models.ImageDetail{
+		ID:           img.ID,
+		Tags:         img.RepoTags,
+		Digests:      img.RepoDigests,
+		Created:      img.Created,
+		Author:       img.Author,
+		Architecture: img.Architecture,
+		OS:           img.Os,
+		Size:         img.Size,
+		Labels:       map[string]string{},
+		Layers:       img.RootFS.Layers,
+		History:      []models.ImageHistoryItem{},
+		Containers:   users[img.ID],
+	}
+	if detail.Containers == nil {
+		detail.Containers = []string{}
+	}
+	if cfg := img.Config; cfg != nil {
+		detail.Entrypoint = cfg.Entrypoint
+		detail.Command = cfg.Cmd
+		detail.WorkingDir = cfg.WorkingDir
+		detail.User = cfg.User
+		detail.Env = cfg.Env
+		for k, v := range cfg.Labels {
+			detail.Labels[k] = v
+		}
+		for p := range cfg.ExposedPorts {
+			detail.ExposedPorts = append(detail.ExposedPorts, string(p))
+		}
+		sort.Strings(detail.ExposedPorts)
+	}
+	for _, h := range history {
+		detail.History = append(detail.History, models.ImageHistoryItem{
+			ID:        h.ID,
+			Created:   h.Created,
+			CreatedBy: h.CreatedBy,
+			Size:      h.Size,
+			Comment:   h.Comment,
+			Tags:      h.Tags,
+		})
+	}
+
+	c.JSON(http.StatusOK, detail)
+}
+
+// RemoveImage 删除镜像
+// @Summary 删除镜像
+// @Description 按 ID 或标签删除镜像。按标签删除且镜像还有其他标签时只取消该标签；force 可删除被容器引用的镜像
+// @Tags 镜像管理
+// @Accept json
+// @Produce json
+// @Param image body models.ImageRemoveRequest true "删除镜像参数"
+// @Param host query string false "Docker 主机ID，默认使用 default_host"
+// @Success 200 {object} models.ImageRemoveResponse "删除成功"
+// @Failure 400 {object} models.ErrorResponse "参数错误"
+// @Failure 404 {object} models.ErrorResponse "镜像或 Docker 主机不存在"
+// @Failure 409 {object} models.ErrorResponse "镜像被容器使用"
+// @Failure 500 {object} models.ErrorResponse "服务器内部错误"
+// @Router /image/remove [post]
+func RemoveImage(c *gin.Context) {
+	var req models.ImageRemoveRequest
+	if err := c.ShouldBindJSON(&req); err != nil || strings.TrimSpace(req.ID) == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+	cli, ok := dockerFor(c)
+	if !ok {
+		return
+	}
+
+	items, err := cli.ImageRemove(c.Request.Context(), strings.TrimSpace(req.ID), types.ImageRemoveOptions{Force: req.Force, PruneChildren: true})
+	if err != nil {
+		dockerError(c, "Remove image failed", err)
+		return
+	}
+
+	resp := models.ImageRemoveResponse{Untagged: []string{}, Deleted: []string{}}
+	for _, item := range items {
+		if item.Untagged != "" {
+			resp.Untagged = append(resp.Untagged, item.Untagged)
+		}
+		if item.Deleted != "" {
+			resp.Deleted = append(resp.Deleted, item.Deleted)
+		}
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// TagImage 镜像打标签
+// @Summary 镜像打标签
+// @Description 为已有镜像添加新的仓库名:标签
+// @Tags 镜像管理
+// @Accept json
+// @Produce json
+// @Param tag body models.ImageTagRequest true "打标签参数"
+// @Param host query string false "Docker 主机ID，默认使用 default_host"
+// @Success 200 {object} models.SuccessResponse "打标签成功"
+// @Failure 400 {object} models.ErrorResponse "参数错误"
+// @Failure 404 {object} models.ErrorResponse "镜像或 Docker 主机不存在"
+// @Failure 500 {object} models.ErrorResponse "服务器内部错误"
+// @Router /image/tag [post]
+func TagImage(c *gin.Context) {
+	var req models.ImageTagRequest
+	if err := c.ShouldBindJSON(&req); err != nil || strings.TrimSpace(req.Source) == "" || strings.TrimSpace(req.Target) == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+	cli, ok := dockerFor(c)
+	if !ok {
+		return
+	}
+
+	if err := cli.ImageTag(c.Request.Context(), strings.TrimSpace(req.Source), strings.TrimSpace(req.Target)); err != nil {
+		dockerError(c, "Tag image failed", err)
+		return
+	}
+	c.JSON(http.StatusOK, models.SuccessResponse{Code: 200, Message: "Image tagged"})
+}
+
+// PruneImages 清理镜像
+// @Summary 清理悬空镜像
+// @Description 删除未被容器使用的悬空镜像；all=true 时清理所有未使用的镜像。dry_run=true 只返回将被删除的镜像及可回收空间（按镜像大小估算，共享层可能使实际回收更少）
+// @Tags 镜像管理
+// @Produce json
+// @Param dry_run query bool false "只统计不删除"
+// @Param all query bool false "清理所有未被使用的镜像，而不仅是悬空镜像"
+// @Param host query string false "Docker 主机ID，默认使用 default_host"
+// @Success 200 {object} models.ImagePruneResponse "清理结果"
+// @Failure 404 {object} models.ErrorResponse "未知 Docker 主机"
+// @Failure 500 {object} models.ErrorResponse "服务器内部错误"
+// @Router /image/prune [post]
+func PruneImages(c *gin.Context) {
+	dryRun := c.Query("dry_run") == "true"
+	all := c.Query("all") == "true"
+	cli, ok := dockerFor(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	if dryRun {
+		args := filters.NewArgs()
+		if !all {
+			args.Add("dangling", "true")
+		}
+		images, err := cli.ImageList(ctx, types.ImageListOptions{Filters: args})
+		if err != nil {
+			dockerError(c, "List images failed", err)
+			return
+		}
+		users, err := imageUsers(ctx, cli)
+		if err != nil {
+			dockerError(c, "List containers failed", err)
+			return
+		}
+
+		resp := models.ImagePruneResponse{DryRun: true, Images: []models.ImageInfo{}}
+		for _, img := range images {
+			if len(users[img.ID]) > 0 {
+				continue
+			}
+			resp.Images = append(resp.Images, toImageInfo(img, users))
+			resp.SpaceReclaimed += uint64(img.Size)
+		}
+		c.JSON(http.StatusOK, resp)
+		return
+	}
+
+	args := filters.NewArgs()
+	if all {
+		args.Add("dangling", "false")
+	}
+	report, err := cli.ImagesPrune(ctx, args)
+	if err != nil {
+		dockerError(c, "Prune images failed", err)
+		return
+	}
+
+	resp := models.ImagePruneResponse{Images: []models.ImageInfo{}, SpaceReclaimed: report.SpaceReclaimed}
+	for _, item := range report.ImagesDeleted {
+		if item.Deleted != "" {
+			resp.Images = append(resp.Images, models.ImageInfo{ID: item.Deleted, Tags: []string{}, Containers: []string{}})
+		} else if item.Untagged != "" {
+			resp.Images = append(resp.Images, models.ImageInfo{Tags: []string{item.Untagged}, Containers: []string{}})
+		}
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// ImagePullWS 镜像拉取进度 WebSocket
+// @Summary 拉取镜像并推送进度
+// @Description 通过 WebSocket 推送镜像拉取进度（每层的状态和字节数），最后一条消息 status 为 done 或 error。客户端断开连接即取消拉取
+// @Tags 镜像管理
+// @Param image query string true "镜像名，如 nginx:1.25"
+// @Param host query string false "Docker 主机ID，默认使用 default_host"
+// @Success 101 {object} models.ImagePullProgress "WebSocket 连接已建立，逐条推送进度"
+// @Failure 400 {object} models.ErrorResponse "参数错误"
+// @Failure 404 {object} models.ErrorResponse "未知 Docker 主机"
+// @Router /ws/image-pull [get]
+func ImagePullWS(c *gin.Context) {
+	ref := strings.TrimSpace(c.Query("image"))
+	if ref == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+	ref = services.NormalizeImageRef(ref)
+	cli, ok := dockerFor(c)
+	if !ok {
+		return
+	}
+
+	conn, err := wsUpgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		log.Printf("WebSocket upgrade error: %v", err)
+		return
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// 客户端断开时取消拉取
+	go func() {
+		defer cancel()
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	log.Printf("开始拉取镜像: %s", ref)
+	err = pullImage(ctx, cli, ref, func(p models.ImagePullProgress) {
+		conn.WriteJSON(p)
+	})
+	if err != nil {
+		log.Printf("❌ Image pull %s failed: %v", ref, err)
+		conn.WriteJSON(models.ImagePullProgress{Status: "error", Error: err.Error()})
+		return
+	}
+	log.Printf("镜像拉取完成: %s", ref)
+	conn.WriteJSON(models.ImagePullProgress{Status: "done"})
+}
+
+// pullImage 使用匹配的仓库凭据拉取镜像，并把 Docker 的进度消息转换为 models.ImagePullProgress，progress 可为 nil
+func pullImage(ctx context.Context, cli services.DockerService, ref string, progress func(models.ImagePullProgress)) error {
+	options := types.ImagePullOptions{RegistryAuth: registryAuthFor(ref)}
+	return services.PullImage(ctx, cli, ref, options, func(msg jsonmessage.JSONMessage) {
+		if progress == nil {
+			return
+		}
+		p := models.ImagePullProgress{ID: msg.ID, Status: msg.Status, Progress: msg.ProgressMessage}
+		if msg.Progress != nil {
+			p.Current, p.Total = msg.Progress.Current, msg.Progress.Total
+		}
+		progress(p)
+	})
+}
+
+// imageUsers 返回镜像 ID → 使用该镜像的容器名列表
+func imageUsers(ctx context.Context, cli services.DockerService) (map[string][]string, error) {
+	containers, err := cli.ContainerList(ctx, types.ContainerListOptions{All: true})
+	if err != nil {
+		return nil, err
+	}
+	users := make(map[string][]string)
+	for _, ctr := range containers {
+		name := ctr.ID
+		if len(ctr.Names) > 0 {
+			name = ctr.Names[0]
+		}
+		users[ctr.ImageID] = append(users[ctr.ImageID], name)
+	}
+	return users, nil
+}
+
+func toImageInfo(img types.ImageSummary, users map[string][]string) models.ImageInfo {
+	info := models.ImageInfo{
+		ID:         img.ID,
+		Tags:       []string{},
+		Digests:    img.RepoDigests,
+		Size:       img.Size,
+		Created:    img.Created,
+		Containers: users[img.ID],
+	}
+	for _, t := range img.RepoTags {
+		if t != "<none>:<none>" {
+			info.Tags = append(info.Tags, t)
+		}
+	}
+	info.Dangling = len(info.Tags) == 0
+	if info.Containers == nil {
+		info.Containers = []string{}
+	}
+	return info
+}
@@ -0,0 +1,339 @@
+package controllers
+
+import (
+	"auto-deploy-platform/models"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/network"
+	"github.com/gin-gonic/gin"
+)
+
+// ListNetworks 列出 Docker 网络
+// @Summary 获取网络列表
+// @Description 列出网络的驱动、子网、网关以及接入的容器数
+// @Tags 网络管理
+// @Produce json
+// @Param driver query string false "按驱动过滤，如 bridge"
+// @Param host query string false "Docker 主机ID，默认使用 default_host"
+// @Success 200 {object} models.NetworkListResponse "成功返回网络列表"
+// @Failure 404 {object} models.ErrorResponse "未知 Docker 主机"
+// @Failure 500 {object} models.ErrorResponse "服务器内部错误"
+// @Router /networks [get]
+func ListNetworks(c *gin.Context) {
+	cli, ok := dockerFor(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	args := filters.NewArgs()
+	if driver := c.Query("driver"); driver != "" {
+		args.Add("driver", driver)
+	}
+	networks, err := cli.NetworkList(ctx, types.NetworkListOptions{Filters: args})
+	if err != nil {
+		dockerError(c, "List networks failed", err)
+		return
+	}
+
+	list := []models.NetworkInfo{}
+	for _, n := range networks {
+		// 列表接口不返回接入的容器，逐个 inspect 统计
+		if detail, err := cli.NetworkInspect(ctx, n.ID, types.NetworkInspectOptions{}); err == nil {
+			n.Containers = detail.Containers
+		}
+		list = append(list, toNetworkInfo(n))
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+
+	c.JSON(http.StatusOK, models.NetworkListResponse{Networks: list})
+}
+
+// InspectNetwork 获取网络详情
+// @Summary 获取网络详情
+// @Description 返回网络的 IPAM 配置、选项以及接入的容器和地址
+// @Tags 网络管理
+// @Produce json
+// @Param id query string true "网络ID或名称"
+// @Param host query string false "Docker 主机ID，默认使用 default_host"
+// @Success 200 {object} models.NetworkDetail "成功返回网络详情"
+// @Failure 400 {object} models.ErrorResponse "参数错误"
+// @Failure 404 {object} models.ErrorResponse "网络或 Docker 主机不存在"
+// @Failure 500 {object} models.ErrorResponse "服务器内部错误"
+// @Router /network/inspect [get]
+func InspectNetwork(c *gin.Context) {
+	id := strings.TrimSpace(c.Query("id"))
+	if id == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+	cli, ok := dockerFor(c)
+	if !ok {
+		return
+	}
+
+	n, err := cli.NetworkInspect(c.Request.Context(), id, types.NetworkInspectOptions{})
+	if err != nil {
+		dockerError(c, "Inspect network failed", err)
+		return
+	}
+
+	detail := models.NetworkDetail{
+		ID:         n.ID,
+		Name:       n.Name,
+		Driver:     n.Driver,
+		Scope:      n.Scope,
+		Internal:   n.Internal,
+		Attachable: n.Attachable,
+		EnableIPv6: n.EnableIPv6,
+		Predefined: predefinedNetworks[n.Name],
+		IPAM:       []models.NetworkIPAM{},
+		Options:    n.Options,
+		Labels:     n.Labels,
+		Containers: []models.NetworkContainer{},
+		Created:    n.Created.Format(time.RFC3339),
+	}
+	for _, cfg := range n.IPAM.Config {
+		detail.IPAM = append(detail.IPAM, models.NetworkIPAM{Subnet: cfg.Subnet, Gateway: cfg.Gateway, IPRange: cfg.IPRange})
+	}
+	for id, ep := range n.Containers {
+		detail.Containers = append(detail.Containers, models.NetworkContainer{
+			ID:          shortID(id),
+			Name:        ep.Name,
+			IPv4Address: ep.IPv4Address,
+			IPv6Address: ep.IPv6Address,
+			MacAddress:  ep.MacAddress,
+		})
+	}
+	sort.Slice(detail.Containers, func(i, j int) bool { return detail.Containers[i].Name < detail.Containers[j].Name })
+
+	c.JSON(http.StatusOK, detail)
+}
+
+// CreateNetwork 创建网络
+// @Summary 创建网络
+// @Description 创建自定义网络，可指定驱动、子网、网关、IP 段和标签
+// @Tags 网络管理
+// @Accept json
+// @Produce json
+// @Param network body models.NetworkCreateRequest true "创建网络参数"
+// @Param host query string false "Docker 主机ID，默认使用 default_host"
+// @Success 200 {object} models.NetworkCreateResponse "创建成功"
+// @Failure 400 {object} models.ErrorResponse "参数错误"
+// @Failure 404 {object} models.ErrorResponse "未知 Docker 主机"
+// @Failure 409 {object} models.ErrorResponse "网络名已存在"
+// @Failure 500 {object} models.ErrorResponse "服务器内部错误"
+// @Router /network/create [post]
+func CreateNetwork(c *gin.Context) {
+	var req models.NetworkCreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil || strings.TrimSpace(req.Name) == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+	ipam, err := buildIPAM(req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request", "detail": err.Error()})
+		return
+	}
+	cli, ok := dockerFor(c)
+	if !ok {
+		return
+	}
+
+	driver := req.Driver
+	if driver == "" {
+		driver = "bridge"
+	}
+	resp, err := cli.NetworkCreate(c.Request.Context(), strings.TrimSpace(req.Name), types.NetworkCreate{
+		CheckDuplicate: true,
+		Driver:         driver,
+		IPAM:           ipam,
+		Internal:       req.Internal,
+		Attachable:     req.Attachable,
+		EnableIPv6:     req.EnableIPv6,
+		Labels:         req.Labels,
+		Options:        req.Options,
+	})
+	if err != nil {
+		dockerError(c, "Create network failed", err)
+		return
+	}
+	c.JSON(http.StatusOK, models.NetworkCreateResponse{Code: 200, Message: "Network created", ID: shortID(resp.ID)})
+}
+
+// RemoveNetwork 删除网络
+// @Summary 删除网络
+// @Description 删除自定义网络。预置网络 bridge / host / none 以及仍有容器接入的网络不能删除
+// @Tags 网络管理
+// @Accept json
+// @Produce json
+// @Param network body models.NetworkRemoveRequest true "网络ID或名称"
+// @Param host query string false "Docker 主机ID，默认使用 default_host"
+// @Success 200 {object} models.SuccessResponse "删除成功"
+// @Failure 400 {object} models.ErrorResponse "参数错误"
+// @Failure 403 {object} models.ErrorResponse "预置网络或仍有容器接入"
+// @Failure 404 {object} models.ErrorResponse "网络或 Docker 主机不存在"
+// @Failure 500 {object} models.ErrorResponse "服务器内部错误"
+// @Router /network/remove [post]
+func RemoveNetwork(c *gin.Context) {
+	var req models.NetworkRemoveRequest
+	if err := c.ShouldBindJSON(&req); err != nil || strings.TrimSpace(req.ID) == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+	cli, ok := dockerFor(c)
+	if !ok {
+		return
+	}
+
+	if err := cli.NetworkRemove(c.Request.Context(), strings.TrimSpace(req.ID)); err != nil {
+		dockerError(c, "Remove network failed", err)
+		return
+	}
+	c.JSON(http.StatusOK, models.SuccessResponse{Code: 200, Message: "Network removed"})
+}
+
+// ConnectNetwork 容器接入网络
+// @Summary 容器接入网络
+// @Description 将容器接入网络，可设置网络内别名和静态 IP（仅自定义网络）
+// @Tags 网络管理
+// @Accept json
+// @Produce json
+// @Param network body models.NetworkConnectRequest true "接入参数"
+// @Param host query string false "Docker 主机ID，默认使用 default_host"
+// @Success 200 {object} models.SuccessResponse "接入成功"
+// @Failure 400 {object} models.ValidationErrorResponse "参数错误"
+// @Failure 403 {object} models.ErrorResponse "容器已在该网络中或网络模式不允许"
+// @Failure 404 {object} models.ErrorResponse "网络、容器或 Docker 主机不存在"
+// @Failure 500 {object} models.ErrorResponse "服务器内部错误"
+// @Router /network/connect [post]
+func ConnectNetwork(c *gin.Context) {
+	var req models.NetworkConnectRequest
+	if err := c.ShouldBindJSON(&req); err != nil || strings.TrimSpace(req.Container) == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+	var errs []models.FieldError
+	fail := func(field, format string, args ...interface{}) {
+		errs = append(errs, models.FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+	ep, _ := buildEndpoint("network", models.NetworkAttachment{
+		Name:        req.Network,
+		Aliases:     req.Aliases,
+		IPv4Address: req.IPv4Address,
+		IPv6Address: req.IPv6Address,
+	}, fail)
+	if len(errs) > 0 {
+		c.JSON(http.StatusBadRequest, models.ValidationErrorResponse{Error: "Invalid request", Fields: errs})
+		return
+	}
+	cli, ok := dockerFor(c)
+	if !ok {
+		return
+	}
+
+	if err := cli.NetworkConnect(c.Request.Context(), req.Network, req.Container, ep); err != nil {
+		dockerError(c, "Connect network failed", err)
+		return
+	}
+	c.JSON(http.StatusOK, models.SuccessResponse{Code: 200, Message: "Container connected"})
+}
+
+// DisconnectNetwork 容器断开网络
+// @Summary 容器断开网络
+// @Tags 网络管理
+// @Accept json
+// @Produce json
+// @Param network body models.NetworkDisconnectRequest true "断开参数"
+// @Param host query string false "Docker 主机ID，默认使用 default_host"
+// @Success 200 {object} models.SuccessResponse "断开成功"
+// @Failure 400 {object} models.ErrorResponse "参数错误"
+// @Failure 403 {object} models.ErrorResponse "容器不在该网络中"
+// @Failure 404 {object} models.ErrorResponse "网络、容器或 Docker 主机不存在"
+// @Failure 500 {object} models.ErrorResponse "服务器内部错误"
+// @Router /network/disconnect [post]
+func DisconnectNetwork(c *gin.Context) {
+	var req models.NetworkDisconnectRequest
+	if err := c.ShouldBindJSON(&req); err != nil || strings.TrimSpace(req.Network) == "" || strings.TrimSpace(req.Container) == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+	cli, ok := dockerFor(c)
+	if !ok {
+		return
+	}
+
+	if err := cli.NetworkDisconnect(c.Request.Context(), req.Network, req.Container, req.Force); err != nil {
+		dockerError(c, "Disconnect network failed", err)
+		return
+	}
+	c.JSON(http.StatusOK, models.SuccessResponse{Code: 200, Message: "Container disconnected"})
+}
+
+// buildIPAM 校验子网、网关和 IP 段，subnet 为空时交给 Docker 自动分配
+func buildIPAM(req models.NetworkCreateRequest) (*network.IPAM, error) {
+	if req.Subnet == "" {
+		if req.Gateway != "" || req.IPRange != "" {
+			return nil, fmt.Errorf("gateway and ip_range require subnet")
+		}
+		return nil, nil
+	}
+	_, subnet, err := net.ParseCIDR(req.Subnet)
+	if err != nil {
+		return nil, fmt.Errorf("invalid subnet %q", req.Subnet)
+	}
+	if req.Gateway != "" {
+		gw := net.ParseIP(req.Gateway)
+		if gw == nil || !subnet.Contains(gw) {
+			return nil, fmt.Errorf("gateway %s is not in subnet %s", req.Gateway, req.Subnet)
+		}
+	}
+	if req.IPRange != "" {
+		ip, ipRange, err := net.ParseCIDR(req.IPRange)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ip_range %q", req.IPRange)
+		}
+		rangeOnes, _ := ipRange.Mask.Size()
+		subnetOnes, _ := subnet.Mask.Size()
+		if !subnet.Contains(ip) || rangeOnes < subnetOnes {
+			return nil, fmt.Errorf("ip_range %s is not in subnet %s", req.IPRange, req.Subnet)
+		}
+	}
+	return &network.IPAM{
+		Driver: "default",
+		Config: []network.IPAMConfig{{Subnet: req.Subnet, Gateway: req.Gateway, IPRange: req.IPRange}},
+	}, nil
+}
+
+func toNetworkInfo(n types.NetworkResource) models.NetworkInfo {
+	info := models.NetworkInfo{
+		ID:         shortID(n.ID),
+		Name:       n.Name,
+		Driver:     n.Driver,
+		Scope:      n.Scope,
+		Subnets:    []string{},
+		Gateways:   []string{},
+		Internal:   n.Internal,
+		Attachable: n.Attachable,
+		Predefined: predefinedNetworks[n.Name],
+		Labels:     n.Labels,
+		Containers: len(n.Containers),
+		Created:    n.Created.Format(time.RFC3339),
+	}
+	for _, cfg := range n.IPAM.Config {
+		if cfg.Subnet != "" {
+			info.Subnets = append(info.Subnets, cfg.Subnet)
+		}
+		if cfg.Gateway != "" {
+			info.Gateways = append(info.Gateways, cfg.Gateway)
+		}
+	}
+	return info
+}
@@ -0,0 +1,209 @@
+package controllers
+
+import (
+	"auto-deploy-platform/models"
+	"auto-deploy-platform/services"
+	"log"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/gin-gonic/gin"
+)
+
+// registryCredentials 由 main 注入的镜像仓库凭据存储
+var registryCredentials *services.CredentialStore
+
+// InitRegistryCredentials 注入镜像仓库凭据存储
+func InitRegistryCredentials(store *services.CredentialStore) {
+	registryCredentials = store
+}
+
+// registryAuthFor 按镜像所在仓库选取凭据，编码为 ImagePullOptions.RegistryAuth；无匹配凭据时匿名拉取
+func registryAuthFor(ref string) string {
+	auth := types.AuthConfig{}
+	if registryCredentials != nil {
+		auth, _ = registryCredentials.AuthFor(ref)
+	}
+	return encodeAuthToBase64(auth)
+}
+
+// withRegistryConfig 为外部 docker 命令生成临时 DOCKER_CONFIG 目录写入已保存的仓库凭据，返回清理函数。
+// 未保存任何凭据时保持命令原有环境
+func withRegistryConfig(cmd *exec.Cmd) func() {
+	if registryCredentials == nil || len(registryCredentials.List()) == 0 {
+		return func() {}
+	}
+	data, err := registryCredentials.DockerConfigJSON()
+	if err != nil {
+		log.Printf("❌ Build docker config failed: %v", err)
+		return func() {}
+	}
+	dir, err := os.MkdirTemp("", "adp-docker-config-")
+	if err != nil {
+		log.Printf("❌ Create docker config dir failed: %v", err)
+		return func() {}
+	}
+	cleanup := func() { os.RemoveAll(dir) }
+	if err := os.WriteFile(filepath.Join(dir, "config.json"), data, 0o600); err != nil {
+		log.Printf("❌ Write docker config failed: %v", err)
+		cleanup()
+		return func() {}
+	}
+	cmd.Env = append(os.Environ(), "DOCKER_CONFIG="+dir)
+	return cleanup
+}
+
+// ListRegistryCredentials 获取镜像仓库凭据列表
+// @Summary 获取镜像仓库凭据列表
+// @Description 返回已保存凭据的仓库地址和用户名，不返回密码和 token
+// @Tags 镜像仓库
+// @Produce json
+// @Success 200 {object} models.RegistryCredentialListResponse "成功返回凭据列表"
+// @Router /registry/credentials [get]
+func ListRegistryCredentials(c *gin.Context) {
+	list := []models.RegistryCredentialInfo{}
+	for _, cred := range registryCredentials.List() {
+		list = append(list, toRegistryCredentialInfo(cred))
+	}
+	c.JSON(http.StatusOK, models.RegistryCredentialListResponse{Credentials: list})
+}
+
+// CreateRegistryCredential 新增镜像仓库凭据
+// @Summary 新增镜像仓库凭据
+// @Description 保存私有仓库的用户名和密码（或 identity token），加密落盘。之后拉取该仓库的镜像时自动使用
+// @Tags 镜像仓库
+// @Accept json
+// @Produce json
+// @Param credential body models.RegistryCredentialRequest true "仓库凭据"
+// @Success 200 {object} models.RegistryCredentialInfo "保存成功"
+// @Failure 400 {object} models.ErrorResponse "参数错误"
+// @Failure 403 {object} models.ErrorResponse "未配置加密口令"
+// @Failure 409 {object} models.ErrorResponse "该仓库已有凭据"
+// @Failure 500 {object} models.ErrorResponse "服务器内部错误"
+// @Router /registry/credential/create [post]
+func CreateRegistryCredential(c *gin.Context) {
+	var req models.RegistryCredentialRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+	cred, err := registryCredentials.Create(toRegistryCredential(req))
+	if err != nil {
+		dockerError(c, "Save registry credential failed", err)
+		return
+	}
+	c.JSON(http.StatusOK, toRegistryCredentialInfo(cred))
+}
+
+// UpdateRegistryCredential 更新镜像仓库凭据
+// @Summary 更新镜像仓库凭据
+// @Description 修改已保存的仓库凭据，password、token 留空表示保持原值
+// @Tags 镜像仓库
+// @Accept json
+// @Produce json
+// @Param credential body models.RegistryCredentialRequest true "仓库凭据"
+// @Success 200 {object} models.RegistryCredentialInfo "更新成功"
+// @Failure 400 {object} models.ErrorResponse "参数错误"
+// @Failure 403 {object} models.ErrorResponse "未配置加密口令"
+// @Failure 404 {object} models.ErrorResponse "该仓库没有凭据"
+// @Failure 500 {object} models.ErrorResponse "服务器内部错误"
+// @Router /registry/credential/update [post]
+func UpdateRegistryCredential(c *gin.Context) {
+	var req models.RegistryCredentialRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+	cred, err := registryCredentials.Update(toRegistryCredential(req))
+	if err != nil {
+		dockerError(c, "Save registry credential failed", err)
+		return
+	}
+	c.JSON(http.StatusOK, toRegistryCredentialInfo(cred))
+}
+
+// DeleteRegistryCredential 删除镜像仓库凭据
+// @Summary 删除镜像仓库凭据
+// @Tags 镜像仓库
+// @Accept json
+// @Produce json
+// @Param credential body models.RegistryCredentialDeleteRequest true "仓库地址"
+// @Success 200 {object} models.SuccessResponse "删除成功"
+// @Failure 400 {object} models.ErrorResponse "参数错误"
+// @Failure 404 {object} models.ErrorResponse "该仓库没有凭据"
+// @Failure 500 {object} models.ErrorResponse "服务器内部错误"
+// @Router /registry/credential/delete [post]
+func DeleteRegistryCredential(c *gin.Context) {
+	var req models.RegistryCredentialDeleteRequest
+	if err := c.ShouldBindJSON(&req); err != nil || strings.TrimSpace(req.Registry) == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+	if err := registryCredentials.Delete(req.Registry); err != nil {
+		dockerError(c, "Delete registry credential failed", err)
+		return
+	}
+	c.JSON(http.StatusOK, models.SuccessResponse{Code: 200, Message: "Registry credential deleted"})
+}
+
+// TestRegistryCredential 测试镜像仓库凭据
+// @Summary 测试镜像仓库凭据
+// @Description 由指定 Docker 主机使用已保存的凭据登录仓库，验证凭据是否有效
+// @Tags 镜像仓库
+// @Accept json
+// @Produce json
+// @Param credential body models.RegistryCredentialDeleteRequest true "仓库地址"
+// @Param host query string false "Docker 主机ID，默认使用 default_host"
+// @Success 200 {object} models.SuccessResponse "登录成功"
+// @Failure 400 {object} models.ErrorResponse "参数错误"
+// @Failure 401 {object} models.ErrorResponse "凭据无效"
+// @Failure 404 {object} models.ErrorResponse "该仓库没有凭据或 Docker 主机不存在"
+// @Failure 500 {object} models.ErrorResponse "服务器内部错误"
+// @Router /registry/credential/test [post]
+func TestRegistryCredential(c *gin.Context) {
+	var req models.RegistryCredentialDeleteRequest
+	if err := c.ShouldBindJSON(&req); err != nil || strings.TrimSpace(req.Registry) == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+	cred, ok := registryCredentials.Get(req.Registry)
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Registry credential not found", "detail": req.Registry})
+		return
+	}
+	cli, ok := dockerFor(c)
+	if !ok {
+		return
+	}
+
+	result, err := cli.RegistryLogin(c.Request.Context(), cred.AuthConfig())
+	if err != nil {
+		dockerError(c, "Registry login failed", err)
+		return
+	}
+	c.JSON(http.StatusOK, models.SuccessResponse{Code: 200, Message: result.Status})
+}
+
+func toRegistryCredential(req models.RegistryCredentialRequest) services.RegistryCredential {
+	return services.RegistryCredential{
+		Registry: req.Registry,
+		Username: strings.TrimSpace(req.Username),
+		Password: req.Password,
+		Token:    req.Token,
+	}
+}
+
+func toRegistryCredentialInfo(cred services.RegistryCredential) models.RegistryCredentialInfo {
+	return models.RegistryCredentialInfo{
+		Registry:    cred.Registry,
+		Username:    cred.Username,
+		HasPassword: cred.Password != "",
+		HasToken:    cred.Token != "",
+		UpdatedAt:   cred.UpdatedAt.Format(time.RFC3339),
+	}
+}
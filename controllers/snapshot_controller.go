@@ -0,0 +1,280 @@
+package controllers
+
+import (
+	"auto-deploy-platform/models"
+	"auto-deploy-platform/services"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/errdefs"
+	"github.com/gin-gonic/gin"
+)
+
+// snapshotRepository 快照镜像的仓库名前缀，镜像为 adp-snapshot/<容器名>:<时间戳>
+const snapshotRepository = "adp-snapshot"
+
+// snapshots 由 main 注入的容器快照记录
+var snapshots *services.SnapshotStore
+
+// InitSnapshots 注入容器快照记录
+func InitSnapshots(store *services.SnapshotStore) {
+	snapshots = store
+}
+
+// ListSnapshots 获取容器快照列表
+// @Summary 获取容器快照列表
+// @Description 列出当前 Docker 主机上的容器快照，最新的在前。容器被删除后其快照仍会保留，可按容器名查询并回滚
+// @Tags 容器快照
+// @Produce json
+// @Param container query string false "按容器名过滤"
+// @Param host query string false "Docker 主机ID，默认使用 default_host"
+// @Success 200 {object} models.SnapshotListResponse "成功返回快照列表"
+// @Failure 404 {object} models.ErrorResponse "未知 Docker 主机"
+// @Router /snapshots [get]
+func ListSnapshots(c *gin.Context) {
+	cli, ok := dockerFor(c)
+	if !ok {
+		return
+	}
+	host, _ := dockerHosts.Host(c.Query("host"))
+
+	ctx := c.Request.Context()
+	resp := models.SnapshotListResponse{Snapshots: []models.SnapshotInfo{}}
+	for _, snap := range snapshots.List(host.ID, strings.TrimPrefix(c.Query("container"), "/")) {
+		_, _, err := cli.ImageInspectWithRaw(ctx, snap.ImageID)
+		resp.Snapshots = append(resp.Snapshots, toSnapshotInfo(snap, err == nil))
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// CreateSnapshot 创建容器快照
+// @Summary 创建容器快照
+// @Description 把容器当前的文件系统 commit 为镜像 adp-snapshot/<容器名>:<时间戳>，并记录容器配置（HostConfig、网络）和备注。快照不包含数据卷和 bind 挂载的内容，需要时配合备份接口使用。pause 默认为 true，commit 期间暂停容器
+// @Tags 容器快照
+// @Accept json
+// @Produce json
+// @Param snapshot body models.SnapshotCreateRequest true "快照参数"
+// @Param host query string false "Docker 主机ID，默认使用 default_host"
+// @Success 200 {object} models.SnapshotCreateResponse "快照成功"
+// @Failure 400 {object} models.ErrorResponse "请求参数错误"
+// @Failure 404 {object} models.ErrorResponse "容器或 Docker 主机不存在"
+// @Failure 409 {object} models.ErrorResponse "同一秒内已创建过该容器的快照"
+// @Failure 500 {object} models.ErrorResponse "服务器内部错误"
+// @Router /snapshot/create [post]
+func CreateSnapshot(c *gin.Context) {
+	var req models.SnapshotCreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil || strings.TrimSpace(req.Container) == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request", "detail": "container is required"})
+		return
+	}
+	cli, ok := dockerFor(c)
+	if !ok {
+		return
+	}
+	host, _ := dockerHosts.Host(c.Query("host"))
+
+	ctx := c.Request.Context()
+	info, err := cli.ContainerInspect(ctx, strings.TrimSpace(req.Container))
+	if err != nil {
+		dockerError(c, "Inspect container failed", err)
+		return
+	}
+	name := strings.TrimPrefix(info.Name, "/")
+	now := time.Now()
+	ts := now.Format("20060102-150405")
+	id := name + "-" + ts
+	if _, err := snapshots.Get(id); err == nil {
+		c.JSON(http.StatusConflict, gin.H{"error": "Snapshot already exists", "detail": fmt.Sprintf("snapshot %s already exists, retry in a second", id)})
+		return
+	}
+
+	pause := req.Pause == nil || *req.Pause
+	ref := fmt.Sprintf("%s/%s:%s", snapshotRepository, strings.ToLower(name), ts)
+	committed, err := cli.ContainerCommit(ctx, info.ID, types.ContainerCommitOptions{
+		Reference: ref,
+		Comment:   req.Note,
+		Author:    "auto-deploy-platform",
+		Pause:     pause,
+	})
+	if err != nil {
+		log.Printf("❌ Commit container %s failed: %v", name, err)
+		dockerError(c, "Commit container failed", err)
+		return
+	}
+
+	config, networks := reusableConfig(info)
+	snap := services.ContainerSnapshot{
+		ID:          id,
+		Host:        host.ID,
+		Container:   name,
+		ContainerID: info.ID,
+		Image:       ref,
+		ImageID:     committed.ID,
+		SourceImage: info.Config.Image,
+		Note:        req.Note,
+		Config:      config,
+		HostConfig:  info.HostConfig,
+		Networks:    networks,
+		CreatedAt:   now,
+	}
+	if err := snapshots.Add(snap); err != nil {
+		cli.ImageRemove(ctx, ref, types.ImageRemoveOptions{})
+		dockerError(c, "Save snapshot failed", err)
+		return
+	}
+	log.Printf("容器快照完成: %s -> %s", name, ref)
+	c.JSON(http.StatusOK, models.SnapshotCreateResponse{Code: 200, Message: "Snapshot created", Snapshot: toSnapshotInfo(snap, true)})
+}
+
+// RollbackSnapshot 回滚容器到快照
+// @Summary 回滚容器到快照
+// @Description 使用快照镜像和快照时的配置重建同名容器：HostConfig、网络（别名、静态 IP）和容器名保持不变。原容器先停止并改名，新容器启动失败时自动删除新容器并恢复原容器；成功后删除原容器，keep_old=true 时保留。原容器已被删除时直接按快照创建
+// @Tags 容器快照
+// @Accept json
+// @Produce json
+// @Param rollback body models.SnapshotRollbackRequest true "回滚参数"
+// @Param host query string false "Docker 主机ID，默认使用 default_host"
+// @Success 200 {object} models.SnapshotRollbackResponse "回滚成功"
+// @Failure 400 {object} models.ErrorResponse "请求参数错误或快照不属于该主机"
+// @Failure 404 {object} models.ErrorResponse "快照、快照镜像或 Docker 主机不存在"
+// @Failure 500 {object} models.ErrorResponse "重建容器失败，原容器已恢复"
+// @Router /snapshot/rollback [post]
+func RollbackSnapshot(c *gin.Context) {
+	var req models.SnapshotRollbackRequest
+	if err := c.ShouldBindJSON(&req); err != nil || strings.TrimSpace(req.ID) == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+	snap, err := snapshots.Get(req.ID)
+	if err != nil {
+		dockerError(c, "Get snapshot failed", err)
+		return
+	}
+	cli, ok := dockerFor(c)
+	if !ok {
+		return
+	}
+	if host, _ := dockerHosts.Host(c.Query("host")); host.ID != snap.Host {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request", "detail": fmt.Sprintf("snapshot %s was taken on host %s", snap.ID, snap.Host)})
+		return
+	}
+
+	ctx := c.Request.Context()
+	if _, _, err := cli.ImageInspectWithRaw(ctx, snap.ImageID); err != nil {
+		if errdefs.IsNotFound(err) {
+			err = errdefs.NotFound(fmt.Errorf("snapshot image %s has been removed", snap.Image))
+		}
+		dockerError(c, "Inspect snapshot image failed", err)
+		return
+	}
+	// 标签被移走时退回使用镜像 ID
+	image := snap.Image
+	if img, _, err := cli.ImageInspectWithRaw(ctx, snap.Image); err != nil || img.ID != snap.ImageID {
+		image = snap.ImageID
+	}
+
+	var old *types.ContainerJSON
+	info, err := cli.ContainerInspect(ctx, snap.Container)
+	switch {
+	case err == nil:
+		old = &info
+	case !errdefs.IsNotFound(err):
+		dockerError(c, "Inspect container failed", err)
+		return
+	}
+
+	config := *snap.Config
+	config.Image = image
+	hostConfig := *snap.HostConfig
+	if old != nil {
+		// 与重新部署一致，沿用原容器的匿名卷，避免回滚后数据卷为空
+		pinAnonymousVolumes(*old, &hostConfig)
+	}
+	id, oldName, err := replaceContainer(ctx, cli, old, replacement{
+		name:       snap.Container,
+		config:     &config,
+		hostConfig: &hostConfig,
+		networks:   snap.Networks,
+		keepOld:    req.KeepOld,
+	})
+	if err != nil {
+		log.Printf("❌ Rollback %s to %s failed: %v", snap.Container, snap.ID, err)
+		dockerError(c, "Rollback failed", err)
+		return
+	}
+	log.Printf("容器已回滚: %s -> %s", snap.Container, snap.Image)
+	c.JSON(http.StatusOK, models.SnapshotRollbackResponse{Code: 200, Message: "Container rolled back", ID: shortID(id), Container: snap.Container, OldContainer: oldName})
+}
+
+// DeleteSnapshot 删除容器快照
+// @Summary 删除容器快照
+// @Description 删除快照记录，remove_image=true 时同时删除快照镜像；镜像仍被容器使用（例如回滚后的容器）时返回 409 且不删除记录
+// @Tags 容器快照
+// @Accept json
+// @Produce json
+// @Param snapshot body models.SnapshotDeleteRequest true "要删除的快照"
+// @Param host query string false "Docker 主机ID，默认使用 default_host"
+// @Success 200 {object} models.SuccessResponse "删除成功"
+// @Failure 400 {object} models.ErrorResponse "请求参数错误"
+// @Failure 404 {object} models.ErrorResponse "快照或 Docker 主机不存在"
+// @Failure 409 {object} models.ErrorResponse "快照镜像正在被使用"
+// @Failure 500 {object} models.ErrorResponse "服务器内部错误"
+// @Router /snapshot/delete [post]
+func DeleteSnapshot(c *gin.Context) {
+	var req models.SnapshotDeleteRequest
+	if err := c.ShouldBindJSON(&req); err != nil || strings.TrimSpace(req.ID) == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+	snap, err := snapshots.Get(req.ID)
+	if err != nil {
+		dockerError(c, "Get snapshot failed", err)
+		return
+	}
+	if req.RemoveImage {
+		cli, ok := dockerFor(c)
+		if !ok {
+			return
+		}
+		if host, _ := dockerHosts.Host(c.Query("host")); host.ID != snap.Host {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request", "detail": fmt.Sprintf("snapshot %s was taken on host %s", snap.ID, snap.Host)})
+			return
+		}
+		_, err := cli.ImageRemove(c.Request.Context(), snap.ImageID, types.ImageRemoveOptions{PruneChildren: true})
+		if err != nil && !errdefs.IsNotFound(err) {
+			dockerError(c, "Remove snapshot image failed", err)
+			return
+		}
+	}
+	if err := snapshots.Delete(snap.ID); err != nil {
+		dockerError(c, "Delete snapshot failed", err)
+		return
+	}
+	c.JSON(http.StatusOK, models.SuccessResponse{Code: 200, Message: "Snapshot deleted"})
+}
+
+func toSnapshotInfo(snap services.ContainerSnapshot, imageExists bool) models.SnapshotInfo {
+	info := models.SnapshotInfo{
+		ID:          snap.ID,
+		Host:        snap.Host,
+		Container:   snap.Container,
+		ContainerID: shortID(snap.ContainerID),
+		Image:       snap.Image,
+		ImageID:     snap.ImageID,
+		SourceImage: snap.SourceImage,
+		Networks:    []string{},
+		Note:        snap.Note,
+		ImageExists: imageExists,
+		Created:     snap.CreatedAt.Format(time.RFC3339),
+	}
+	for name := range snap.Networks {
+		info.Networks = append(info.Networks, name)
+	}
+	sort.Strings(info.Networks)
+	return info
+}
@@ -0,0 +1,62 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"auto-deploy-platform/models"
+	"auto-deploy-platform/services"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/gin-gonic/gin"
+)
+
+func TestRollbackSnapshotKeepsAnonymousVolumes(t *testing.T) {
+	fake := useFakeDocker(t)
+	store, err := services.NewSnapshotStore(filepath.Join(t.TempDir(), "snapshots.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	old := snapshots
+	InitSnapshots(store)
+	t.Cleanup(func() { snapshots = old })
+
+	ctx := context.Background()
+	fake.AddImage("postgres:16")
+	created, err := fake.ContainerCreate(ctx, &container.Config{Image: "postgres:16"},
+		&container.HostConfig{Mounts: []mount.Mount{{Type: mount.TypeVolume, Target: "/var/lib/postgresql/data"}}}, nil, nil, "db")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fake.ContainerStart(ctx, created.ID, types.ContainerStartOptions{})
+	if err := fake.WriteFile("db", "/var/lib/postgresql/data/PG_VERSION", []byte("16")); err != nil {
+		t.Fatal(err)
+	}
+
+	r := gin.New()
+	r.POST("/snapshot/create", CreateSnapshot)
+	r.POST("/snapshot/rollback", RollbackSnapshot)
+
+	var snap models.SnapshotCreateResponse
+	decode(t, serve(r, http.MethodPost, "/snapshot/create", `{"container":"db"}`), http.StatusOK, &snap)
+	var resp models.SnapshotRollbackResponse
+	decode(t, serve(r, http.MethodPost, "/snapshot/rollback", `{"id":"`+snap.Snapshot.ID+`"}`), http.StatusOK, &resp)
+
+	if _, ok := fake.Container(created.ID); ok {
+		t.Error("old container was kept")
+	}
+	info, err := fake.ContainerInspect(ctx, "db")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(info.Mounts) != 1 || info.Mounts[0].Name != created.ID {
+		t.Fatalf("mounts after rollback = %+v, want the old anonymous volume %s", info.Mounts, created.ID)
+	}
+	if data, err := fake.ReadFile("db", "/var/lib/postgresql/data/PG_VERSION"); err != nil || string(data) != "16" {
+		t.Errorf("volume data after rollback = %q, %v", data, err)
+	}
+}
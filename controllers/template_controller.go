@@ -0,0 +1,439 @@
+package controllers
+
+import (
+	"auto-deploy-platform/models"
+	"auto-deploy-platform/services"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	templateNamePattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]*$`)
+	// ${NAME} 占位符，$$ 为转义的 $
+	placeholderPattern = regexp.MustCompile(`\$\$|\$\{([^}]*)\}`)
+	paramNamePattern   = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+)
+
+// templates 由 main 注入的容器模板
+var templates *services.TemplateStore
+
+// InitTemplates 注入容器模板存储
+func InitTemplates(store *services.TemplateStore) {
+	templates = store
+}
+
+// ListTemplates 获取容器模板列表
+// @Summary 获取容器模板列表
+// @Description 列出全部容器模板的最新版本及其参数
+// @Tags 容器模板
+// @Produce json
+// @Success 200 {object} models.ContainerTemplateListResponse "成功返回模板列表"
+// @Router /templates [get]
+func ListTemplates(c *gin.Context) {
+	resp := models.ContainerTemplateListResponse{Templates: []models.ContainerTemplateSummary{}}
+	for _, t := range templates.List() {
+		resp.Templates = append(resp.Templates, toTemplateSummary(t))
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// InspectTemplate 获取模板详情
+// @Summary 获取模板详情
+// @Description 返回模板某个版本的请求体、参数和全部历史版本号，未指定 version 时返回最新版本
+// @Tags 容器模板
+// @Produce json
+// @Param name query string true "模板名"
+// @Param version query int false "版本号，默认最新"
+// @Success 200 {object} models.ContainerTemplateDetail "成功返回模板"
+// @Failure 400 {object} models.ErrorResponse "请求参数错误"
+// @Failure 404 {object} models.ErrorResponse "模板或版本不存在"
+// @Router /template/inspect [get]
+func InspectTemplate(c *gin.Context) {
+	version, err := strconv.Atoi(c.DefaultQuery("version", "0"))
+	if err != nil || version < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request", "detail": "version must be a non-negative number"})
+		return
+	}
+	t, err := templates.Get(c.Query("name"), version)
+	if err != nil {
+		dockerError(c, "Get template failed", err)
+		return
+	}
+	c.JSON(http.StatusOK, models.ContainerTemplateDetail{
+		ContainerTemplateSummary: toTemplateSummary(t),
+		Payload:                  t.Payload,
+		Versions:                 templates.Versions(t.Name),
+	})
+}
+
+// CreateTemplate 新建容器模板
+// @Summary 新建容器模板
+// @Description 把创建容器的请求体保存为模板，版本号为 1。请求体中的字符串（包括标签等对象的键）可使用 ${NAME} 占位符，实例化时替换为参数值，$$ 表示字面量 $；数字、布尔字段不能使用占位符。请求体字段会按 format 对应的创建容器接口校验
+// @Tags 容器模板
+// @Accept json
+// @Produce json
+// @Param template body models.ContainerTemplateRequest true "模板内容"
+// @Success 200 {object} models.ContainerTemplateSaveResponse "保存成功"
+// @Failure 400 {object} models.ValidationErrorResponse "请求参数错误"
+// @Failure 409 {object} models.ErrorResponse "模板已存在"
+// @Router /template/create [post]
+func CreateTemplate(c *gin.Context) {
+	t, ok := bindTemplate(c)
+	if !ok {
+		return
+	}
+	saved, err := templates.Create(t)
+	if err != nil {
+		dockerError(c, "Create template failed", err)
+		return
+	}
+	c.JSON(http.StatusOK, models.ContainerTemplateSaveResponse{Code: 200, Message: "Template created", Name: saved.Name, Version: saved.Version, Parameters: toTemplateParameters(saved.Parameters)})
+}
+
+// UpdateTemplate 修改容器模板
+// @Summary 修改容器模板
+// @Description 以完整的模板内容保存为新版本，版本号加 1，旧版本保留，可按版本号查看和实例化
+// @Tags 容器模板
+// @Accept json
+// @Produce json
+// @Param template body models.ContainerTemplateRequest true "模板内容"
+// @Success 200 {object} models.ContainerTemplateSaveResponse "保存成功"
+// @Failure 400 {object} models.ValidationErrorResponse "请求参数错误"
+// @Failure 404 {object} models.ErrorResponse "模板不存在"
+// @Router /template/update [post]
+func UpdateTemplate(c *gin.Context) {
+	t, ok := bindTemplate(c)
+	if !ok {
+		return
+	}
+	saved, err := templates.Update(t)
+	if err != nil {
+		dockerError(c, "Update template failed", err)
+		return
+	}
+	c.JSON(http.StatusOK, models.ContainerTemplateSaveResponse{Code: 200, Message: "Template updated", Name: saved.Name, Version: saved.Version, Parameters: toTemplateParameters(saved.Parameters)})
+}
+
+// DeleteTemplate 删除容器模板
+// @Summary 删除容器模板
+// @Description 删除模板及其全部历史版本，已创建的容器不受影响
+// @Tags 容器模板
+// @Accept json
+// @Produce json
+// @Param template body models.ContainerTemplateDeleteRequest true "模板名"
+// @Success 200 {object} models.SuccessResponse "删除成功"
+// @Failure 400 {object} models.ErrorResponse "请求参数错误"
+// @Failure 404 {object} models.ErrorResponse "模板不存在"
+// @Router /template/delete [post]
+func DeleteTemplate(c *gin.Context) {
+	var req models.ContainerTemplateDeleteRequest
+	if err := c.ShouldBindJSON(&req); err != nil || req.Name == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request", "detail": "name is required"})
+		return
+	}
+	if err := templates.Delete(req.Name); err != nil {
+		dockerError(c, "Delete template failed", err)
+		return
+	}
+	c.JSON(http.StatusOK, models.SuccessResponse{Code: 200, Message: "Template deleted"})
+}
+
+// InstantiateTemplate 用模板创建容器
+// @Summary 用模板创建容器
+// @Description 用参数值替换模板中的占位符，然后与对应版本的创建容器接口一样校验、拉取镜像、创建并启动容器（支持 wait 等全部选项）。未提供的参数使用默认值，缺少必填参数或提供了模板中不存在的参数时返回 400。dry_run=true 时只返回替换后的请求体
+// @Tags 容器模板
+// @Accept json
+// @Produce json
+// @Param instantiate body models.TemplateInstantiateRequest true "模板名、版本和参数值"
+// @Param host query string false "Docker 主机ID，默认使用 default_host"
+// @Success 200 {object} models.TemplateInstantiateResponse "创建成功或 dry_run 结果"
+// @Failure 400 {object} models.ValidationErrorResponse "参数缺失或替换后的请求不合法"
+// @Failure 404 {object} models.ErrorResponse "模板、版本或 Docker 主机不存在"
+// @Failure 409 {object} models.ErrorResponse "容器名已被占用"
+// @Failure 500 {object} models.ContainerNotReadyResponse "容器启动失败或未就绪"
+// @Router /template/instantiate [post]
+func InstantiateTemplate(c *gin.Context) {
+	var req models.TemplateInstantiateRequest
+	if err := c.ShouldBindJSON(&req); err != nil || req.Name == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request", "detail": "name is required"})
+		return
+	}
+	t, err := templates.Get(req.Name, req.Version)
+	if err != nil {
+		dockerError(c, "Get template failed", err)
+		return
+	}
+	payload, errs := renderTemplate(t, req.Params)
+	if len(errs) > 0 {
+		c.JSON(http.StatusBadRequest, models.ValidationErrorResponse{Error: "Invalid request", Fields: errs})
+		return
+	}
+	spec, err := templateRequest(t.Format, payload)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ValidationErrorResponse{Error: "Invalid request", Fields: []models.FieldError{{Field: "payload", Message: err.Error()}}})
+		return
+	}
+	resp := models.TemplateInstantiateResponse{Code: 200, Template: t.Name, Version: t.Version, Payload: payload}
+	if req.DryRun {
+		resp.Message = "Dry run, container not created"
+		c.JSON(http.StatusOK, resp)
+		return
+	}
+
+	id, ok := createContainer(c, spec)
+	if !ok {
+		return
+	}
+	resp.Message, resp.ID = "Container created", id
+	c.JSON(http.StatusOK, resp)
+}
+
+// bindTemplate 解析并校验模板请求，失败时已写入响应
+func bindTemplate(c *gin.Context) (services.ContainerTemplate, bool) {
+	var req models.ContainerTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request", "detail": err.Error()})
+		return services.ContainerTemplate{}, false
+	}
+	t, errs := buildTemplate(req)
+	if len(errs) > 0 {
+		c.JSON(http.StatusBadRequest, models.ValidationErrorResponse{Error: "Invalid request", Fields: errs})
+		return t, false
+	}
+	return t, true
+}
+
+// buildTemplate 校验模板名、格式和请求体，找出占位符并与声明的参数合并，收集全部字段错误一并返回
+func buildTemplate(req models.ContainerTemplateRequest) (services.ContainerTemplate, []models.FieldError) {
+	var errs []models.FieldError
+	fail := func(field, format string, args ...interface{}) {
+		errs = append(errs, models.FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+	t := services.ContainerTemplate{
+		Name:        strings.TrimSpace(req.Name),
+		Description: req.Description,
+		Format:      req.Format,
+		CreatedAt:   time.Now(),
+	}
+	if !templateNamePattern.MatchString(t.Name) {
+		fail("name", "invalid template name %q, only [a-zA-Z0-9][a-zA-Z0-9_.-] are allowed", t.Name)
+	}
+	if t.Format == "" {
+		t.Format = services.TemplateFormatV2
+	}
+	if t.Format != services.TemplateFormatV1 && t.Format != services.TemplateFormatV2 {
+		fail("format", "format must be v1 or v2")
+	}
+
+	// 请求体原样保存，只去掉多余的空白
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, req.Payload); err != nil || len(req.Payload) == 0 || req.Payload[0] != '{' {
+		fail("payload", "payload must be a JSON object")
+		return t, errs
+	}
+	t.Payload = compact.Bytes()
+
+	var used []string
+	seen := make(map[string]bool)
+	_, err := expandPayload(t.Payload, func(name string) (string, error) {
+		if !paramNamePattern.MatchString(name) {
+			return "", fmt.Errorf("invalid placeholder ${%s}", name)
+		}
+		if !seen[name] {
+			seen[name] = true
+			used = append(used, name)
+		}
+		return "${" + name + "}", nil
+	})
+	if err != nil {
+		fail("payload", "%v", err)
+	}
+	if t.Format == services.TemplateFormatV1 || t.Format == services.TemplateFormatV2 {
+		if _, err := templateRequest(t.Format, t.Payload); err != nil {
+			fail("payload", "%v", err)
+		}
+	}
+
+	declared := make(map[string]models.TemplateParameter)
+	for i, p := range req.Parameters {
+		switch {
+		case !paramNamePattern.MatchString(p.Name):
+			fail(fmt.Sprintf("parameters[%d].name", i), "invalid parameter name %q", p.Name)
+		case !seen[p.Name]:
+			fail(fmt.Sprintf("parameters[%d].name", i), "parameter %s is not used in payload", p.Name)
+		case declared[p.Name].Name != "":
+			fail(fmt.Sprintf("parameters[%d].name", i), "duplicate parameter %s", p.Name)
+		default:
+			declared[p.Name] = p
+		}
+	}
+	t.Parameters = []services.TemplateParameter{}
+	for _, name := range used {
+		p := declared[name]
+		param := services.TemplateParameter{Name: name, Description: p.Description, Required: p.Default == nil}
+		if p.Default != nil {
+			param.Default = *p.Default
+		}
+		t.Parameters = append(t.Parameters, param)
+	}
+	return t, errs
+}
+
+// renderTemplate 用参数值（未提供时为默认值）替换模板中的占位符，返回替换后的请求体
+func renderTemplate(t services.ContainerTemplate, params map[string]string) (json.RawMessage, []models.FieldError) {
+	var errs []models.FieldError
+	values := make(map[string]string, len(t.Parameters))
+	for _, p := range t.Parameters {
+		if v, ok := params[p.Name]; ok {
+			values[p.Name] = v
+		} else if !p.Required {
+			values[p.Name] = p.Default
+		} else {
+			errs = append(errs, models.FieldError{Field: "params." + p.Name, Message: fmt.Sprintf("parameter %s is required", p.Name)})
+		}
+	}
+	var unknown []string
+	for name := range params {
+		if !templateHasParameter(t, name) {
+			unknown = append(unknown, name)
+		}
+	}
+	sort.Strings(unknown)
+	for _, name := range unknown {
+		errs = append(errs, models.FieldError{Field: "params." + name, Message: fmt.Sprintf("template %s has no parameter %s", t.Name, name)})
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	payload, err := expandPayload(t.Payload, func(name string) (string, error) {
+		return values[name], nil
+	})
+	if err != nil {
+		return nil, []models.FieldError{{Field: "payload", Message: err.Error()}}
+	}
+	return payload, nil
+}
+
+func templateHasParameter(t services.ContainerTemplate, name string) bool {
+	for _, p := range t.Parameters {
+		if p.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// expandPayload 替换请求体所有字符串（包括对象的键）中的 ${NAME} 占位符，$$ 替换为 $。
+// 在解析后的 JSON 上替换，参数值中的引号等字符不会破坏请求体结构
+func expandPayload(payload json.RawMessage, lookup func(name string) (string, error)) (json.RawMessage, error) {
+	dec := json.NewDecoder(bytes.NewReader(payload))
+	dec.UseNumber()
+	var doc interface{}
+	if err := dec.Decode(&doc); err != nil {
+		return nil, err
+	}
+	expanded, err := expandValue(doc, lookup)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(expanded); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSpace(buf.Bytes()), nil
+}
+
+func expandValue(v interface{}, lookup func(name string) (string, error)) (interface{}, error) {
+	switch v := v.(type) {
+	case string:
+		return expandString(v, lookup)
+	case []interface{}:
+		for i, item := range v {
+			expanded, err := expandValue(item, lookup)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = expanded
+		}
+		return v, nil
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			k, err := expandString(key, lookup)
+			if err != nil {
+				return nil, err
+			}
+			if out[k], err = expandValue(item, lookup); err != nil {
+				return nil, err
+			}
+		}
+		return out, nil
+	}
+	return v, nil
+}
+
+func expandString(s string, lookup func(name string) (string, error)) (string, error) {
+	var err error
+	out := placeholderPattern.ReplaceAllStringFunc(s, func(m string) string {
+		if m == "$$" {
+			return "$"
+		}
+		value, lookupErr := lookup(m[2 : len(m)-1])
+		if lookupErr != nil && err == nil {
+			err = lookupErr
+		}
+		return value
+	})
+	return out, err
+}
+
+// templateRequest 按格式解析请求体，不允许出现创建容器接口不支持的字段
+func templateRequest(format string, payload json.RawMessage) (models.CreateContainerV2Request, error) {
+	dec := json.NewDecoder(bytes.NewReader(payload))
+	dec.DisallowUnknownFields()
+	if format == services.TemplateFormatV1 {
+		var req models.CreateContainerRequest
+		if err := dec.Decode(&req); err != nil {
+			return models.CreateContainerV2Request{}, err
+		}
+		return legacyCreateRequest(req), nil
+	}
+	var req models.CreateContainerV2Request
+	err := dec.Decode(&req)
+	return req, err
+}
+
+func toTemplateSummary(t services.ContainerTemplate) models.ContainerTemplateSummary {
+	return models.ContainerTemplateSummary{
+		Name:        t.Name,
+		Description: t.Description,
+		Format:      t.Format,
+		Version:     t.Version,
+		Parameters:  toTemplateParameters(t.Parameters),
+		UpdatedAt:   t.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func toTemplateParameters(params []services.TemplateParameter) []models.TemplateParameter {
+	list := make([]models.TemplateParameter, 0, len(params))
+	for _, p := range params {
+		param := models.TemplateParameter{Name: p.Name, Description: p.Description, Required: p.Required}
+		if !p.Required {
+			param.Default = &p.Default
+		}
+		list = append(list, param)
+	}
+	return list
+}
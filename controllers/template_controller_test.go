@@ -0,0 +1,60 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"auto-deploy-platform/models"
+	"auto-deploy-platform/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestTemplateParameterWithEmptyDefaultIsOptional(t *testing.T) {
+	store, err := services.NewTemplateStore(filepath.Join(t.TempDir(), "templates.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	old := templates
+	InitTemplates(store)
+	t.Cleanup(func() { templates = old })
+
+	r := gin.New()
+	r.POST("/template/create", CreateTemplate)
+	r.POST("/template/instantiate", InstantiateTemplate)
+
+	var saved models.ContainerTemplateSaveResponse
+	decode(t, serve(r, http.MethodPost, "/template/create",
+		`{"name":"web","payload":{"name":"web${SUFFIX}","image":"nginx:${TAG}"},"parameters":[{"name":"SUFFIX","default":""},{"name":"TAG"}]}`),
+		http.StatusOK, &saved)
+	required := make(map[string]bool)
+	for _, p := range saved.Parameters {
+		required[p.Name] = p.Required
+	}
+	if required["SUFFIX"] || !required["TAG"] {
+		t.Fatalf("required = %v, want SUFFIX optional and TAG required", required)
+	}
+
+	var invalid models.ValidationErrorResponse
+	decode(t, serve(r, http.MethodPost, "/template/instantiate", `{"name":"web","dry_run":true}`), http.StatusBadRequest, &invalid)
+	if got := fieldNames(invalid.Fields); len(got) != 1 || got[0] != "params.TAG" {
+		t.Errorf("fields = %v, want [params.TAG]", got)
+	}
+
+	for params, want := range map[string]string{
+		`{"TAG":"1.27"}`:                 "web",
+		`{"TAG":"1.27","SUFFIX":"-dev"}`: "web-dev",
+	} {
+		var resp models.TemplateInstantiateResponse
+		decode(t, serve(r, http.MethodPost, "/template/instantiate", `{"name":"web","dry_run":true,"params":`+params+`}`), http.StatusOK, &resp)
+		var payload models.CreateContainerV2Request
+		if err := json.Unmarshal(resp.Payload, &payload); err != nil {
+			t.Fatal(err)
+		}
+		if payload.Name != want || payload.Image != "nginx:1.27" {
+			t.Errorf("params %s: name = %q, image = %q, want %q, nginx:1.27", params, payload.Name, payload.Image, want)
+		}
+	}
+}
@@ -0,0 +1,343 @@
+package controllers
+
+import (
+	"auto-deploy-platform/models"
+	"auto-deploy-platform/services"
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/volume"
+	"github.com/gin-gonic/gin"
+)
+
+var volumeNamePattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]+$`)
+
+// volumeUsage 数据卷的挂载情况和磁盘占用
+type volumeUsage struct {
+	containers map[string][]models.VolumeContainer // 数据卷名 → 挂载它的容器
+	projects   map[string][]string                 // 数据卷名 → 挂载它的容器所属的 compose 项目
+	sizes      map[string]int64                    // 数据卷名 → 占用字节数，未统计到时不存在
+}
+
+// ListVolumes 列出数据卷
+// @Summary 获取数据卷列表
+// @Description 列出数据卷的驱动、磁盘占用、挂载它的容器以及所属 compose 项目；dangling=true 只列出没有容器挂载的孤立数据卷
+// @Tags 数据卷管理
+// @Produce json
+// @Param dangling query bool false "只列出未被任何容器使用的数据卷"
+// @Param driver query string false "按驱动过滤，如 local"
+// @Param compose_project query string false "只列出属于该 compose 项目的数据卷"
+// @Param host query string false "Docker 主机ID，默认使用 default_host"
+// @Success 200 {object} models.VolumeListResponse "成功返回数据卷列表"
+// @Failure 404 {object} models.ErrorResponse "未知 Docker 主机"
+// @Failure 500 {object} models.ErrorResponse "服务器内部错误"
+// @Router /volumes [get]
+func ListVolumes(c *gin.Context) {
+	cli, ok := dockerFor(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	args := filters.NewArgs()
+	if c.Query("dangling") == "true" {
+		args.Add("dangling", "true")
+	}
+	if driver := c.Query("driver"); driver != "" {
+		args.Add("driver", driver)
+	}
+	body, err := cli.VolumeList(ctx, args)
+	if err != nil {
+		dockerError(c, "List volumes failed", err)
+		return
+	}
+	usage, err := volumeUsages(ctx, cli)
+	if err != nil {
+		dockerError(c, "List containers failed", err)
+		return
+	}
+
+	project := c.Query("compose_project")
+	resp := models.VolumeListResponse{Volumes: []models.VolumeInfo{}}
+	for _, v := range body.Volumes {
+		info := toVolumeInfo(*v, usage)
+		if project != "" && !slices.Contains(info.ComposeProjects, project) {
+			continue
+		}
+		if info.Size > 0 {
+			resp.TotalSize += info.Size
+		}
+		resp.Volumes = append(resp.Volumes, info)
+	}
+	sort.Slice(resp.Volumes, func(i, j int) bool { return resp.Volumes[i].Name < resp.Volumes[j].Name })
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// InspectVolume 获取数据卷详情
+// @Summary 获取数据卷详情
+// @Description 返回数据卷的配置、磁盘占用以及挂载它的容器和挂载路径
+// @Tags 数据卷管理
+// @Produce json
+// @Param name query string true "数据卷名称"
+// @Param host query string false "Docker 主机ID，默认使用 default_host"
+// @Success 200 {object} models.VolumeInfo "成功返回数据卷详情"
+// @Failure 400 {object} models.ErrorResponse "参数错误"
+// @Failure 404 {object} models.ErrorResponse "数据卷或 Docker 主机不存在"
+// @Failure 500 {object} models.ErrorResponse "服务器内部错误"
+// @Router /volume/inspect [get]
+func InspectVolume(c *gin.Context) {
+	name := strings.TrimSpace(c.Query("name"))
+	if name == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+	cli, ok := dockerFor(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	v, err := cli.VolumeInspect(ctx, name)
+	if err != nil {
+		dockerError(c, "Inspect volume failed", err)
+		return
+	}
+	usage, err := volumeUsages(ctx, cli)
+	if err != nil {
+		dockerError(c, "List containers failed", err)
+		return
+	}
+	c.JSON(http.StatusOK, toVolumeInfo(v, usage))
+}
+
+// CreateVolume 创建数据卷
+// @Summary 创建数据卷
+// @Description 创建命名数据卷，可指定驱动、驱动参数和标签
+// @Tags 数据卷管理
+// @Accept json
+// @Produce json
+// @Param volume body models.VolumeCreateRequest true "创建数据卷参数"
+// @Param host query string false "Docker 主机ID，默认使用 default_host"
+// @Success 200 {object} models.VolumeCreateResponse "创建成功"
+// @Failure 400 {object} models.ErrorResponse "参数错误"
+// @Failure 404 {object} models.ErrorResponse "未知 Docker 主机"
+// @Failure 409 {object} models.ErrorResponse "同名数据卷已使用其他驱动创建"
+// @Failure 500 {object} models.ErrorResponse "服务器内部错误"
+// @Router /volume/create [post]
+func CreateVolume(c *gin.Context) {
+	var req models.VolumeCreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+	req.Name = strings.TrimSpace(req.Name)
+	if !volumeNamePattern.MatchString(req.Name) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request", "detail": fmt.Sprintf("invalid volume name %q, only [a-zA-Z0-9][a-zA-Z0-9_.-] are allowed", req.Name)})
+		return
+	}
+	cli, ok := dockerFor(c)
+	if !ok {
+		return
+	}
+
+	driver := req.Driver
+	if driver == "" {
+		driver = "local"
+	}
+	v, err := cli.VolumeCreate(c.Request.Context(), volume.VolumeCreateBody{
+		Name:       req.Name,
+		Driver:     driver,
+		DriverOpts: req.DriverOpts,
+		Labels:     req.Labels,
+	})
+	if err != nil {
+		dockerError(c, "Create volume failed", err)
+		return
+	}
+	c.JSON(http.StatusOK, models.VolumeCreateResponse{Code: 200, Message: "Volume created", Name: v.Name})
+}
+
+// RemoveVolume 删除数据卷
+// @Summary 删除数据卷
+// @Description 删除数据卷，仍有容器挂载时返回 409
+// @Tags 数据卷管理
+// @Accept json
+// @Produce json
+// @Param volume body models.VolumeRemoveRequest true "数据卷名称"
+// @Param host query string false "Docker 主机ID，默认使用 default_host"
+// @Success 200 {object} models.SuccessResponse "删除成功"
+// @Failure 400 {object} models.ErrorResponse "参数错误"
+// @Failure 404 {object} models.ErrorResponse "数据卷或 Docker 主机不存在"
+// @Failure 409 {object} models.ErrorResponse "数据卷正在使用"
+// @Failure 500 {object} models.ErrorResponse "服务器内部错误"
+// @Router /volume/remove [post]
+func RemoveVolume(c *gin.Context) {
+	var req models.VolumeRemoveRequest
+	if err := c.ShouldBindJSON(&req); err != nil || strings.TrimSpace(req.Name) == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+	cli, ok := dockerFor(c)
+	if !ok {
+		return
+	}
+
+	if err := cli.VolumeRemove(c.Request.Context(), strings.TrimSpace(req.Name), req.Force); err != nil {
+		dockerError(c, "Remove volume failed", err)
+		return
+	}
+	c.JSON(http.StatusOK, models.SuccessResponse{Code: 200, Message: "Volume removed"})
+}
+
+// PruneVolumes 清理数据卷
+// @Summary 清理未使用的数据卷
+// @Description 删除没有任何容器（包括已停止的容器）挂载的 local 数据卷。dry_run=true 只返回将被删除的数据卷及可回收空间；label 只清理带指定标签的数据卷
+// @Tags 数据卷管理
+// @Produce json
+// @Param dry_run query bool false "只统计不删除"
+// @Param label query string false "按标签过滤，key 或 key=value"
+// @Param host query string false "Docker 主机ID，默认使用 default_host"
+// @Success 200 {object} models.VolumePruneResponse "清理结果"
+// @Failure 404 {object} models.ErrorResponse "未知 Docker 主机"
+// @Failure 500 {object} models.ErrorResponse "服务器内部错误"
+// @Router /volume/prune [post]
+func PruneVolumes(c *gin.Context) {
+	dryRun := c.Query("dry_run") == "true"
+	args := filters.NewArgs()
+	if label := c.Query("label"); label != "" {
+		args.Add("label", label)
+	}
+	cli, ok := dockerFor(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	if dryRun {
+		listArgs := args.Clone()
+		listArgs.Add("dangling", "true")
+		listArgs.Add("driver", "local")
+		body, err := cli.VolumeList(ctx, listArgs)
+		if err != nil {
+			dockerError(c, "List volumes failed", err)
+			return
+		}
+		usage, err := volumeUsages(ctx, cli)
+		if err != nil {
+			dockerError(c, "List containers failed", err)
+			return
+		}
+
+		resp := models.VolumePruneResponse{DryRun: true, Volumes: []models.VolumeInfo{}}
+		for _, v := range body.Volumes {
+			info := toVolumeInfo(*v, usage)
+			resp.Volumes = append(resp.Volumes, info)
+			if info.Size > 0 {
+				resp.SpaceReclaimed += uint64(info.Size)
+			}
+		}
+		sort.Slice(resp.Volumes, func(i, j int) bool { return resp.Volumes[i].Name < resp.Volumes[j].Name })
+		c.JSON(http.StatusOK, resp)
+		return
+	}
+
+	report, err := cli.VolumesPrune(ctx, args)
+	if err != nil {
+		dockerError(c, "Prune volumes failed", err)
+		return
+	}
+
+	resp := models.VolumePruneResponse{Volumes: []models.VolumeInfo{}, SpaceReclaimed: report.SpaceReclaimed}
+	for _, name := range report.VolumesDeleted {
+		resp.Volumes = append(resp.Volumes, models.VolumeInfo{Name: name, Size: -1, Containers: []models.VolumeContainer{}, ComposeProjects: []string{}})
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// volumeUsages 汇总所有容器的数据卷挂载，并通过 docker system df 取得各数据卷的磁盘占用。
+// 统计占用需要遍历卷目录，较慢且可能失败，失败时只记录日志，大小返回 -1
+func volumeUsages(ctx context.Context, cli services.DockerService) (volumeUsage, error) {
+	usage := volumeUsage{
+		containers: make(map[string][]models.VolumeContainer),
+		projects:   make(map[string][]string),
+		sizes:      make(map[string]int64),
+	}
+	containers, err := cli.ContainerList(ctx, types.ContainerListOptions{All: true})
+	if err != nil {
+		return usage, err
+	}
+	for _, ctr := range containers {
+		name := ctr.ID
+		if len(ctr.Names) > 0 {
+			name = ctr.Names[0]
+		}
+		for _, m := range ctr.Mounts {
+			if m.Type != "volume" || m.Name == "" {
+				continue
+			}
+			usage.containers[m.Name] = append(usage.containers[m.Name], models.VolumeContainer{
+				ID:          shortID(ctr.ID),
+				Name:        name,
+				State:       ctr.State,
+				Destination: m.Destination,
+				ReadOnly:    !m.RW,
+			})
+			if project := ctr.Labels[composeProjectLabel]; project != "" && !slices.Contains(usage.projects[m.Name], project) {
+				usage.projects[m.Name] = append(usage.projects[m.Name], project)
+			}
+		}
+	}
+
+	du, err := cli.DiskUsage(ctx)
+	if err != nil {
+		log.Printf("⚠️ 获取数据卷磁盘占用失败: %v", err)
+		return usage, nil
+	}
+	for _, v := range du.Volumes {
+		if v.UsageData != nil {
+			usage.sizes[v.Name] = v.UsageData.Size
+		}
+	}
+	return usage, nil
+}
+
+func toVolumeInfo(v types.Volume, usage volumeUsage) models.VolumeInfo {
+	info := models.VolumeInfo{
+		Name:            v.Name,
+		Driver:          v.Driver,
+		Mountpoint:      v.Mountpoint,
+		Scope:           v.Scope,
+		Created:         v.CreatedAt,
+		Labels:          v.Labels,
+		Options:         v.Options,
+		Size:            -1,
+		Containers:      usage.containers[v.Name],
+		ComposeProjects: []string{},
+	}
+	if size, ok := usage.sizes[v.Name]; ok {
+		info.Size = size
+	}
+	if info.Containers == nil {
+		info.Containers = []models.VolumeContainer{}
+	}
+	info.InUse = len(info.Containers) > 0
+	// compose 创建的数据卷自带项目标签，即使已经没有容器挂载也能找到归属
+	if project := v.Labels[composeProjectLabel]; project != "" {
+		info.ComposeProjects = append(info.ComposeProjects, project)
+	}
+	for _, project := range usage.projects[v.Name] {
+		if !slices.Contains(info.ComposeProjects, project) {
+			info.ComposeProjects = append(info.ComposeProjects, project)
+		}
+	}
+	return info
+}
@@ -471,9 +471,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/compose/delete": {
+        "/api/v2/container/create": {
             "post": {
-                "description": "删除指定 Compose 应用及其目录",
+                "description": "使用结构化参数创建并启动容器：端口支持协议、宿主机 IP 和范围，挂载支持 bind / volume / tmpfs 及只读，另可设置命令、入口、标签、用户、工作目录、capabilities 和健康检查。所有字段先做校验，校验失败时返回全部错误字段且不会调用 Docker。wait=true 时等待容器运行并在配置了健康检查时等到 healthy 再返回，容器退出、unhealthy 或超时时返回退出码和最后几行日志，remove_on_failure=true 时同时删除该容器",
                 "consumes": [
                     "application/json"
                 ],
@@ -481,107 +481,94 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Compose管理"
+                    "容器管理"
                 ],
-                "summary": "删除 Compose 应用",
+                "summary": "创建容器 (v2)",
                 "parameters": [
                     {
-                        "description": "Compose 应用名称",
-                        "name": "compose",
+                        "description": "创建容器请求参数",
+                        "name": "container",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.ComposeActionRequest"
+                            "$ref": "#/definitions/models.CreateContainerV2Request"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Docker 主机ID，默认使用 default_host",
+                        "name": "host",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "删除成功",
+                        "description": "创建成功返回容器ID",
                         "schema": {
-                            "$ref": "#/definitions/models.SuccessResponse"
+                            "$ref": "#/definitions/models.CreateContainerResponse"
                         }
                     },
                     "400": {
-                        "description": "参数错误",
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "私有仓库认证失败",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "删除失败",
+                    "404": {
+                        "description": "未知 Docker 主机",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
-                    }
-                }
-            }
-        },
-        "/compose/list": {
-            "get": {
-                "description": "列出当前存在的所有 Compose 应用",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Compose管理"
-                ],
-                "summary": "获取 Compose 应用列表",
-                "responses": {
-                    "200": {
-                        "description": "成功返回 Compose 应用列表",
+                    },
+                    "409": {
+                        "description": "容器名已被占用",
                         "schema": {
-                            "$ref": "#/definitions/models.ListComposeResponse"
+                            "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "读取目录失败",
+                        "description": "容器启动失败或未就绪",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "$ref": "#/definitions/models.ContainerNotReadyResponse"
                         }
                     }
                 }
             }
         },
-        "/compose/start": {
-            "post": {
-                "description": "通过应用名称启动对应 Compose 应用",
-                "consumes": [
-                    "application/json"
-                ],
+        "/autoupdate/report/{id}": {
+            "get": {
+                "description": "返回一次检查中每个容器的结果：本地与仓库的镜像摘要、是否重建以及失败原因",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Compose管理"
+                    "自动更新"
                 ],
-                "summary": "启动 Compose 应用",
+                "summary": "自动更新报告详情",
                 "parameters": [
                     {
-                        "description": "Compose 应用名称",
-                        "name": "compose",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.ComposeActionRequest"
-                        }
+                        "type": "string",
+                        "description": "报告ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "启动成功",
-                        "schema": {
-                            "$ref": "#/definitions/models.SuccessResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "参数错误",
+                        "description": "成功返回报告",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "$ref": "#/definitions/models.AutoUpdateReport"
                         }
                     },
-                    "500": {
-                        "description": "启动失败",
+                    "404": {
+                        "description": "报告不存在",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
@@ -589,35 +576,29 @@ const docTemplate = `{
                 }
             }
         },
-        "/compose/status": {
+        "/autoupdate/reports": {
             "get": {
-                "description": "查看各 Compose 应用包含的容器及运行状态",
+                "description": "返回自动更新配置、下次检查时间和最近的检查报告概要，最新的在前",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Compose管理"
+                    "自动更新"
                 ],
-                "summary": "获取 Compose 应用状态",
+                "summary": "自动更新状态和报告",
                 "responses": {
                     "200": {
-                        "description": "成功返回 Compose 容器状态",
-                        "schema": {
-                            "$ref": "#/definitions/models.ComposeStatusResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Docker client 初始化或容器列表失败",
+                        "description": "成功返回状态和报告列表",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "$ref": "#/definitions/models.AutoUpdateStatusResponse"
                         }
                     }
                 }
             }
         },
-        "/compose/stop": {
+        "/autoupdate/run": {
             "post": {
-                "description": "通过应用名称停止对应 Compose 应用",
+                "description": "立即检查所有 Docker 主机上带自动更新标签的运行中容器，不受维护窗口和启用开关限制；dry_run=true 时只比较摘要不重建。请求等待检查完成后返回报告，客户端断开不会中断检查",
                 "consumes": [
                     "application/json"
                 ],
@@ -625,35 +606,28 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Compose管理"
+                    "自动更新"
                 ],
-                "summary": "停止 Compose 应用",
+                "summary": "立即检查镜像更新",
                 "parameters": [
                     {
-                        "description": "Compose 应用名称",
-                        "name": "compose",
+                        "description": "检查选项",
+                        "name": "run",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.ComposeActionRequest"
+                            "$ref": "#/definitions/models.AutoUpdateRunRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "停止成功",
-                        "schema": {
-                            "$ref": "#/definitions/models.SuccessResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "参数错误",
+                        "description": "检查完成",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "$ref": "#/definitions/models.AutoUpdateRunResponse"
                         }
                     },
-                    "500": {
-                        "description": "停止失败",
+                    "409": {
+                        "description": "已有检查正在进行",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
@@ -661,40 +635,41 @@ const docTemplate = `{
                 }
             }
         },
-        "/compose/upload": {
+        "/backup/create": {
             "post": {
-                "description": "上传并保存 Docker Compose 文件",
+                "description": "把命名数据卷（volume）或容器的全部 volume / bind 挂载（container）打包为带时间戳的 tar.gz。数据卷通过只读挂载它的辅助容器读取，不需要访问 Docker 主机的文件系统。stop_container=true 时备份期间停止使用这些数据的运行中容器，完成后（包括失败时）重新启动",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Compose管理"
+                    "备份恢复"
                 ],
-                "summary": "上传 Compose 文件",
+                "summary": "创建备份",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Compose 文件名称",
-                        "name": "name",
-                        "in": "formData",
-                        "required": true
+                        "description": "备份参数",
+                        "name": "backup",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.BackupCreateRequest"
+                        }
                     },
                     {
-                        "type": "file",
-                        "description": "Compose 文件 (YAML格式)",
-                        "name": "compose_file",
-                        "in": "formData",
-                        "required": true
+                        "type": "string",
+                        "description": "Docker 主机ID，默认使用 default_host",
+                        "name": "host",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "上传成功",
+                        "description": "备份成功",
                         "schema": {
-                            "$ref": "#/definitions/models.SuccessResponse"
+                            "$ref": "#/definitions/models.BackupCreateResponse"
                         }
                     },
                     "400": {
@@ -703,6 +678,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "数据卷、容器或 Docker 主机不存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "服务器内部错误",
                         "schema": {
@@ -712,9 +693,8 @@ const docTemplate = `{
                 }
             }
         },
-        "/container/create": {
+        "/backup/delete": {
             "post": {
-                "description": "创建一个新的 Docker 容器，支持设置端口映射、卷挂载、环境变量、资源限制等",
                 "consumes": [
                     "application/json"
                 ],
@@ -722,35 +702,35 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "容器管理"
+                    "备份恢复"
                 ],
-                "summary": "创建容器",
+                "summary": "删除备份",
                 "parameters": [
                     {
-                        "description": "创建容器请求参数",
-                        "name": "container",
+                        "description": "备份ID",
+                        "name": "backup",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.CreateContainerRequest"
+                            "$ref": "#/definitions/models.BackupDeleteRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "创建成功返回容器ID",
+                        "description": "删除成功",
                         "schema": {
-                            "$ref": "#/definitions/models.CreateContainerResponse"
+                            "$ref": "#/definitions/models.SuccessResponse"
                         }
                     },
                     "400": {
-                        "description": "请求参数错误",
+                        "description": "参数错误",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "服务器内部错误",
+                    "404": {
+                        "description": "备份不存在",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
@@ -758,34 +738,33 @@ const docTemplate = `{
                 }
             }
         },
-        "/container/{id}/logs/ws": {
+        "/backup/download/{id}": {
             "get": {
-                "description": "通过 WebSocket 连接获取指定容器的实时日志流，连接后服务器持续推送日志内容",
                 "produces": [
-                    "text/plain"
+                    "application/gzip"
                 ],
                 "tags": [
-                    "容器管理"
+                    "备份恢复"
                 ],
-                "summary": "实时获取容器日志",
+                "summary": "下载备份",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "容器ID",
+                        "description": "备份ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "101": {
-                        "description": "WebSocket 连接已建立，开始推送日志",
+                    "200": {
+                        "description": "tar.gz 归档",
                         "schema": {
-                            "type": "string"
+                            "type": "file"
                         }
                     },
-                    "500": {
-                        "description": "Docker client 初始化失败 或 WebSocket 升级失败",
+                    "404": {
+                        "description": "备份不存在",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
@@ -793,37 +772,57 @@ const docTemplate = `{
                 }
             }
         },
-        "/container/{id}/start": {
+        "/backup/restore": {
             "post": {
-                "description": "启动指定容器",
+                "description": "校验 sha256 后把备份写回数据卷或容器。数据卷备份可恢复到原数据卷或新数据卷（不存在时自动创建）；容器备份恢复到原容器，或挂载点相同的其他容器。clean=true 时先清空目标，否则只覆盖同名文件。stop_container=true 时恢复期间停止使用目标数据的运行中容器，完成后重新启动",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "容器管理"
+                    "备份恢复"
                 ],
-                "summary": "启动容器",
+                "summary": "恢复备份",
                 "parameters": [
+                    {
+                        "description": "恢复参数",
+                        "name": "backup",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.BackupRestoreRequest"
+                        }
+                    },
                     {
                         "type": "string",
-                        "description": "容器ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Docker 主机ID，默认使用 default_host",
+                        "name": "host",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "成功启动容器",
+                        "description": "恢复成功",
                         "schema": {
-                            "$ref": "#/definitions/models.SuccessResponse"
+                            "$ref": "#/definitions/models.BackupRestoreResponse"
                         }
                     },
                     "400": {
-                        "description": "请求参数错误",
+                        "description": "参数错误或目标容器缺少挂载点",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "备份、容器或 Docker 主机不存在",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "服务器内部错误",
+                        "description": "备份文件损坏或服务器内部错误",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
@@ -831,37 +830,39 @@ const docTemplate = `{
                 }
             }
         },
-        "/container/{id}/stop": {
-            "post": {
-                "description": "通过容器ID停止正在运行的容器",
+        "/backups": {
+            "get": {
+                "description": "列出备份目录中的全部备份（大小、sha256、来源），最新的在前",
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "容器管理"
+                    "备份恢复"
                 ],
-                "summary": "停止容器",
+                "summary": "获取备份列表",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "容器ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "按类型过滤：volume / container",
+                        "name": "kind",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "按来源数据卷或容器名过滤",
+                        "name": "source",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "成功停止容器",
-                        "schema": {
-                            "$ref": "#/definitions/models.SuccessResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "请求参数错误",
+                        "description": "成功返回备份列表",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "$ref": "#/definitions/models.BackupListResponse"
                         }
                     },
                     "500": {
-                        "description": "服务器内部错误",
+                        "description": "读取备份目录失败",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
@@ -869,25 +870,33 @@ const docTemplate = `{
                 }
             }
         },
-        "/containers": {
+        "/bluegreen/apps": {
             "get": {
-                "description": "获取本机所有 Docker 容器的详细信息",
+                "description": "列出当前 Docker 主机上做过蓝绿部署的应用：在线的颜色、两个颜色的容器、镜像和状态，以及最近的部署 / 回滚记录",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "容器管理"
+                    "蓝绿部署"
+                ],
+                "summary": "蓝绿部署应用列表",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Docker 主机ID，默认使用 default_host",
+                        "name": "host",
+                        "in": "query"
+                    }
                 ],
-                "summary": "获取容器列表",
                 "responses": {
                     "200": {
-                        "description": "成功返回容器列表",
+                        "description": "成功返回应用列表",
                         "schema": {
-                            "$ref": "#/definitions/models.ContainerListResponse"
+                            "$ref": "#/definitions/models.BlueGreenListResponse"
                         }
                     },
-                    "500": {
-                        "description": "服务器内部错误",
+                    "404": {
+                        "description": "未知 Docker 主机",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
@@ -895,9 +904,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/files/batch-chmod": {
+        "/bluegreen/deploy": {
             "post": {
-                "description": "一次性修改多个文件/目录的权限",
+                "description": "按在线容器的配置用新镜像在另一个颜色上部署：先以临时随机端口启动候选容器并等待就绪（有健康检查时为 healthy），通过后停止在线容器，按公开端口重建 \u003capp\u003e-\u003c颜色\u003e 容器并再次确认就绪。原在线容器保持停止，可通过回滚接口立即切回；上一次的备用容器在新版本就绪后才删除。首次部署时 app 为已有容器的名称，该容器成为 blue 并改名为 \u003capp\u003e-blue",
                 "consumes": [
                     "application/json"
                 ],
@@ -905,91 +914,127 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "文件管理"
+                    "蓝绿部署"
                 ],
-                "summary": "批量修改文件权限",
+                "summary": "蓝绿部署新版本",
                 "parameters": [
                     {
-                        "description": "批量权限修改参数",
-                        "name": "chmod",
+                        "description": "应用名和新镜像",
+                        "name": "deploy",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.BatchChmodRequest"
+                            "$ref": "#/definitions/models.BlueGreenDeployRequest"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Docker 主机ID，默认使用 default_host",
+                        "name": "host",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "修改成功",
+                        "description": "已切换到新版本",
                         "schema": {
-                            "$ref": "#/definitions/models.SuccessResponse"
+                            "$ref": "#/definitions/models.BlueGreenResponse"
                         }
                     },
                     "400": {
-                        "description": "权限格式错误",
+                        "description": "请求参数错误",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "修改失败",
+                    "404": {
+                        "description": "应用、容器或 Docker 主机不存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "已有部署或回滚正在进行，或 \u003capp\u003e-\u003c颜色\u003e 已被其他容器占用",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
+                    },
+                    "500": {
+                        "description": "新版本未就绪，原版本仍在线",
+                        "schema": {
+                            "$ref": "#/definitions/models.ContainerNotReadyResponse"
+                        }
                     }
                 }
             }
         },
-        "/files/batch-download": {
+        "/bluegreen/rollback": {
             "post": {
-                "description": "选中多个文件，打包为 zip 下载",
+                "description": "停止在线容器，启动保持停止的另一个颜色的容器并等待就绪，无需重新创建容器。备用容器未就绪时恢复原在线容器",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
-                    "application/zip"
+                    "application/json"
                 ],
                 "tags": [
-                    "文件管理"
+                    "蓝绿部署"
                 ],
-                "summary": "批量下载文件",
+                "summary": "蓝绿回滚",
                 "parameters": [
                     {
-                        "description": "批量下载参数",
-                        "name": "download",
+                        "description": "应用名",
+                        "name": "rollback",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.BatchDownloadRequest"
+                            "$ref": "#/definitions/models.BlueGreenRollbackRequest"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Docker 主机ID，默认使用 default_host",
+                        "name": "host",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "zip 文件",
+                        "description": "已切回",
                         "schema": {
-                            "type": "file"
+                            "$ref": "#/definitions/models.BlueGreenResponse"
                         }
                     },
                     "400": {
-                        "description": "参数错误",
+                        "description": "请求参数错误",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "下载失败",
+                    "404": {
+                        "description": "应用、备用容器或 Docker 主机不存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "没有可回滚的版本或已有部署正在进行",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
+                    },
+                    "500": {
+                        "description": "备用容器未就绪，原版本仍在线",
+                        "schema": {
+                            "$ref": "#/definitions/models.ContainerNotReadyResponse"
+                        }
                     }
                 }
             }
         },
-        "/files/delete": {
+        "/compose/delete": {
             "post": {
-                "description": "删除指定路径下的文件或目录",
+                "description": "删除指定 Compose 应用及其目录",
                 "consumes": [
                     "application/json"
                 ],
@@ -997,17 +1042,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "文件管理"
+                    "Compose管理"
                 ],
-                "summary": "删除文件或文件夹",
+                "summary": "删除 Compose 应用",
                 "parameters": [
                     {
-                        "description": "删除文件参数",
-                        "name": "file",
+                        "description": "Compose 应用名称",
+                        "name": "compose",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.FileDeleteRequest"
+                            "$ref": "#/definitions/models.ComposeActionRequest"
                         }
                     }
                 ],
@@ -1018,8 +1063,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.SuccessResponse"
                         }
                     },
-                    "403": {
-                        "description": "越权",
+                    "400": {
+                        "description": "参数错误",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
@@ -1033,40 +1078,72 @@ const docTemplate = `{
                 }
             }
         },
-        "/files/download": {
+        "/compose/list": {
             "get": {
-                "description": "下载指定路径文件",
+                "description": "列出当前存在的所有 Compose 应用",
                 "produces": [
-                    "application/octet-stream"
+                    "application/json"
                 ],
                 "tags": [
-                    "文件管理"
+                    "Compose管理"
                 ],
-                "summary": "下载文件",
+                "summary": "获取 Compose 应用列表",
+                "responses": {
+                    "200": {
+                        "description": "成功返回 Compose 应用列表",
+                        "schema": {
+                            "$ref": "#/definitions/models.ListComposeResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "读取目录失败",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/compose/logs/ws": {
+            "get": {
+                "description": "通过 WebSocket 连接实时获取指定 Compose 应用的日志流",
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "Compose管理"
+                ],
+                "summary": "获取 Compose 应用日志",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "文件路径",
-                        "name": "path",
+                        "description": "Compose 应用名称",
+                        "name": "name",
                         "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Docker 主机ID，默认使用 default_host",
+                        "name": "host",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "文件流",
+                    "101": {
+                        "description": "WebSocket 连接已建立，开始推送日志",
                         "schema": {
-                            "type": "file"
+                            "type": "string"
                         }
                     },
-                    "403": {
-                        "description": "越权",
+                    "400": {
+                        "description": "参数错误",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "下载失败",
+                        "description": "WebSocket 升级失败 或 日志启动失败",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
@@ -1074,9 +1151,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/files/mkdir": {
+        "/compose/start": {
             "post": {
-                "description": "在指定路径下创建新目录",
+                "description": "通过应用名称启动对应 Compose 应用",
                 "consumes": [
                     "application/json"
                 ],
@@ -1084,35 +1161,41 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "文件管理"
+                    "Compose管理"
                 ],
-                "summary": "创建文件夹",
+                "summary": "启动 Compose 应用",
                 "parameters": [
                     {
-                        "description": "创建目录参数",
-                        "name": "dir",
+                        "description": "Compose 应用名称",
+                        "name": "compose",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.MkdirRequest"
+                            "$ref": "#/definitions/models.ComposeActionRequest"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Docker 主机ID，默认使用 default_host",
+                        "name": "host",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "创建成功",
+                        "description": "启动成功",
                         "schema": {
                             "$ref": "#/definitions/models.SuccessResponse"
                         }
                     },
-                    "403": {
-                        "description": "越权",
+                    "400": {
+                        "description": "参数错误",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "创建失败",
+                        "description": "启动失败",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
@@ -1120,9 +1203,49 @@ const docTemplate = `{
                 }
             }
         },
-        "/files/move": {
+        "/compose/status": {
+            "get": {
+                "description": "查看各 Compose 应用包含的容器及运行状态",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Compose管理"
+                ],
+                "summary": "获取 Compose 应用状态",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Docker 主机ID，默认使用 default_host",
+                        "name": "host",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功返回 Compose 容器状态",
+                        "schema": {
+                            "$ref": "#/definitions/models.ComposeStatusResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "未知 Docker 主机",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Docker client 初始化或容器列表失败",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/compose/stop": {
             "post": {
-                "description": "将指定文件或目录移动到目标目录",
+                "description": "通过应用名称停止对应 Compose 应用",
                 "consumes": [
                     "application/json"
                 ],
@@ -1130,35 +1253,41 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "文件管理"
+                    "Compose管理"
                 ],
-                "summary": "移动文件或目录",
+                "summary": "停止 Compose 应用",
                 "parameters": [
                     {
-                        "description": "移动文件参数",
-                        "name": "move",
+                        "description": "Compose 应用名称",
+                        "name": "compose",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.MoveFileRequest"
+                            "$ref": "#/definitions/models.ComposeActionRequest"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Docker 主机ID，默认使用 default_host",
+                        "name": "host",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "移动成功",
+                        "description": "停止成功",
                         "schema": {
                             "$ref": "#/definitions/models.SuccessResponse"
                         }
                     },
                     "400": {
-                        "description": "请求参数错误",
+                        "description": "参数错误",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "移动失败",
+                        "description": "停止失败",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
@@ -1166,9 +1295,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/files/upload": {
+        "/compose/upload": {
             "post": {
-                "description": "上传文件到指定路径",
+                "description": "上传并保存 Docker Compose 文件",
                 "consumes": [
                     "multipart/form-data"
                 ],
@@ -1176,20 +1305,21 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "文件管理"
+                    "Compose管理"
                 ],
-                "summary": "上传文件",
+                "summary": "上传 Compose 文件",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "目标路径，默认基础目录",
-                        "name": "path",
-                        "in": "formData"
+                        "description": "Compose 文件名称",
+                        "name": "name",
+                        "in": "formData",
+                        "required": true
                     },
                     {
                         "type": "file",
-                        "description": "上传文件",
-                        "name": "file",
+                        "description": "Compose 文件 (YAML格式)",
+                        "name": "compose_file",
                         "in": "formData",
                         "required": true
                     }
@@ -1201,450 +1331,6818 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.SuccessResponse"
                         }
                     },
-                    "403": {
-                        "description": "越权",
+                    "400": {
+                        "description": "参数错误",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "上传失败",
+                        "description": "服务器内部错误",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "models.BatchChmodRequest": {
-            "type": "object",
-            "properties": {
-                "mode": {
-                    "type": "string",
-                    "example": "755"
-                },
-                "names": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    },
-                    "example": [
-                        "[\"file1\"",
-                        " \"folder1\"]"
-                    ]
-                },
-                "path": {
-                    "type": "string",
-                    "example": "/tmp/file-manager"
-                }
-            }
         },
-        "models.BatchDeleteRequest": {
-            "type": "object",
-            "properties": {
-                "names": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
+        "/container/create": {
+            "post": {
+                "description": "创建一个新的 Docker 容器，支持设置端口映射、卷挂载、环境变量、资源限制等；wait=true 时等待容器就绪（有健康检查时为 healthy）再返回",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "容器管理"
+                ],
+                "summary": "创建容器",
+                "parameters": [
+                    {
+                        "description": "创建容器请求参数",
+                        "name": "container",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateContainerRequest"
+                        }
                     },
-                    "example": [
-                        "[\"file1.txt\"",
-                        " \"folder2\"]"
-                    ]
-                },
-                "path": {
-                    "type": "string",
-                    "example": "/data"
-                }
-            }
-        },
-        "models.BatchDownloadRequest": {
-            "type": "object",
+                    {
+                        "type": "string",
+                        "description": "Docker 主机ID，默认使用 default_host",
+                        "name": "host",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "创建成功返回容器ID",
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateContainerResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "私有仓库认证失败",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "未知 Docker 主机",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "容器启动失败或未就绪",
+                        "schema": {
+                            "$ref": "#/definitions/models.ContainerNotReadyResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/container/files/download/{id}": {
+            "get": {
+                "description": "下载容器内的文件或目录。format 为空时文件按原内容下载、目录打包为 tar；format=tar 或 zip 时统一打包，归档内以文件或目录名为根",
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "容器管理"
+                ],
+                "summary": "下载容器文件",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "容器ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "容器内路径",
+                        "name": "path",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "tar / zip，默认文件原样下载、目录为 tar",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Docker 主机ID，默认使用 default_host",
+                        "name": "host",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "文件或归档",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "容器或路径不存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/container/files/list/{id}": {
+            "get": {
+                "description": "通过 Docker 归档接口读取容器内目录，返回与主机文件管理相同的文件列表，容器停止时同样可用。路径是指向目录的符号链接时列出链接目标；目录较大时（如 /）需要读取整个目录归档，耗时较长",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "容器管理"
+                ],
+                "summary": "浏览容器文件",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "容器ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "容器内目录，默认 /",
+                        "name": "path",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Docker 主机ID，默认使用 default_host",
+                        "name": "host",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功返回文件列表",
+                        "schema": {
+                            "$ref": "#/definitions/models.ListFilesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "路径不是目录",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "容器或路径不存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/container/files/upload/{id}": {
+            "post": {
+                "description": "把上传的文件写入容器内的目录，同名文件会被覆盖。extract=true 时上传的 .tar / .tar.gz / .tgz / .zip 归档会解压到该目录",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "容器管理"
+                ],
+                "summary": "上传文件到容器",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "容器ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "容器内目标目录",
+                        "name": "path",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "上传文件",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "解压归档到目标目录",
+                        "name": "extract",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Docker 主机ID，默认使用 default_host",
+                        "name": "host",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "上传成功",
+                        "schema": {
+                            "$ref": "#/definitions/models.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误或目标不是目录",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "容器或目录不存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/container/kill/{id}": {
+            "post": {
+                "description": "向容器主进程发送指定信号，默认 SIGKILL",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "容器管理"
+                ],
+                "summary": "强制终止容器",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "容器ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "信号名或编号，如 SIGTERM、SIGHUP、9",
+                        "name": "signal",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Docker 主机ID，默认使用 default_host",
+                        "name": "host",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "信号发送成功",
+                        "schema": {
+                            "$ref": "#/definitions/models.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "信号无效",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "容器或 Docker 主机不存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "容器未运行",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/container/logs/{id}": {
+            "get": {
+                "description": "按时间窗口导出容器日志，边读取边过滤边输出，不在内存中缓存整份日志。format=text 输出纯文本，format=ndjson 每行一条 {\"stream\",\"timestamp\",\"text\"}；gzip=true 时压缩并作为附件下载。grep 按子串过滤，regex=true 时按正则过滤，limit 限制输出的匹配行数",
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "容器管理"
+                ],
+                "summary": "导出/搜索容器历史日志",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "容器ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "起始时间：RFC3339、Unix 时间戳或相对时长 (1h)",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "截止时间，格式同 since",
+                        "name": "until",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "从末尾开始的行数，all 表示全部，默认 all",
+                        "name": "tail",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "stdout / stderr / all，默认 all",
+                        "name": "stream",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "过滤关键字",
+                        "name": "grep",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "grep 是否为正则表达式",
+                        "name": "regex",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "过滤时忽略大小写",
+                        "name": "ignore_case",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "最多输出的行数，0 表示不限制",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "是否输出时间戳，默认 true",
+                        "name": "timestamps",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "text / ndjson，默认 text",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "是否 gzip 压缩下载",
+                        "name": "gzip",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Docker 主机ID，默认使用 default_host",
+                        "name": "host",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "日志内容",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "容器或 Docker 主机不存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/container/pause/{id}": {
+            "post": {
+                "description": "冻结指定容器内的所有进程",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "容器管理"
+                ],
+                "summary": "暂停容器",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "容器ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Docker 主机ID，默认使用 default_host",
+                        "name": "host",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功暂停容器",
+                        "schema": {
+                            "$ref": "#/definitions/models.SuccessResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "容器或 Docker 主机不存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "容器未运行",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/container/redeploy/{id}": {
+            "post": {
+                "description": "拉取镜像后按原容器的配置、主机配置和网络（别名、静态 IP）重建同名容器：停止并改名原容器，创建并启动新容器。新容器启动失败、退出、unhealthy 或在 health_timeout 内未 healthy 时自动删除新容器并恢复原容器。继承自旧镜像的默认环境变量、命令、标签等不会带到新容器，由新镜像提供；镜像未变化时默认不重建",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "容器管理"
+                ],
+                "summary": "用新镜像重建容器",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "容器ID或名称",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "新镜像和重建选项",
+                        "name": "redeploy",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.RedeployContainerRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Docker 主机ID，默认使用 default_host",
+                        "name": "host",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "重建成功或镜像未变化",
+                        "schema": {
+                            "$ref": "#/definitions/models.RedeployContainerResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "容器、镜像或 Docker 主机不存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "重建失败，原容器已恢复",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/container/remove/{id}": {
+            "post": {
+                "description": "删除指定容器，可选同时删除匿名卷、强制删除运行中的容器",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "容器管理"
+                ],
+                "summary": "删除容器",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "容器ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "强制删除运行中的容器",
+                        "name": "force",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "同时删除容器的匿名卷",
+                        "name": "volumes",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Docker 主机ID，默认使用 default_host",
+                        "name": "host",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "删除成功",
+                        "schema": {
+                            "$ref": "#/definitions/models.SuccessResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "容器或 Docker 主机不存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "容器运行中且未指定 force",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/container/rename/{id}": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "容器管理"
+                ],
+                "summary": "重命名容器",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "容器ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "新容器名",
+                        "name": "rename",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.RenameContainerRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Docker 主机ID，默认使用 default_host",
+                        "name": "host",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "重命名成功",
+                        "schema": {
+                            "$ref": "#/definitions/models.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "容器或 Docker 主机不存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "名称已被占用",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/container/restart/{id}": {
+            "post": {
+                "description": "重启指定容器，可指定停止等待秒数",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "容器管理"
+                ],
+                "summary": "重启容器",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "容器ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "停止等待秒数，默认 10",
+                        "name": "t",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Docker 主机ID，默认使用 default_host",
+                        "name": "host",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功重启容器",
+                        "schema": {
+                            "$ref": "#/definitions/models.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "容器或 Docker 主机不存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/container/start/{id}": {
+            "post": {
+                "description": "启动指定容器",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "容器管理"
+                ],
+                "summary": "启动容器",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "容器ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Docker 主机ID，默认使用 default_host",
+                        "name": "host",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功启动容器",
+                        "schema": {
+                            "$ref": "#/definitions/models.SuccessResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "容器或 Docker 主机不存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/container/stop/{id}": {
+            "post": {
+                "description": "通过容器ID停止正在运行的容器，可指定停止等待秒数",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "容器管理"
+                ],
+                "summary": "停止容器",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "容器ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "停止等待秒数，默认 10",
+                        "name": "t",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Docker 主机ID，默认使用 default_host",
+                        "name": "host",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功停止容器",
+                        "schema": {
+                            "$ref": "#/definitions/models.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "容器或 Docker 主机不存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/container/unpause/{id}": {
+            "post": {
+                "description": "恢复被暂停容器内的所有进程",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "容器管理"
+                ],
+                "summary": "恢复暂停的容器",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "容器ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Docker 主机ID，默认使用 default_host",
+                        "name": "host",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功恢复容器",
+                        "schema": {
+                            "$ref": "#/definitions/models.SuccessResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "容器或 Docker 主机不存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "容器未暂停",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/container/update/{id}": {
+            "post": {
+                "description": "通过 docker update 在线修改 CPU（NanoCPUs）、内存 / swap / 内存预留、pids 上限和重启策略，容器无需重建。未填写的字段保持不变，cpus / memory 的格式和校验与创建容器相同；调大 memory 超过当前 memory_swap 时需同时修改 memory_swap",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "容器管理"
+                ],
+                "summary": "修改容器资源限制",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "容器ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "要修改的资源限制",
+                        "name": "update",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateContainerRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Docker 主机ID，默认使用 default_host",
+                        "name": "host",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "修改成功，返回修改后的配置",
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateContainerResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "容器或 Docker 主机不存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "容器状态不允许修改",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/container/{id}": {
+            "get": {
+                "description": "返回容器挂载、网络、端口、重启策略、资源限制、健康状态、标签、启动命令及环境变量。名称匹配敏感关键字的环境变量默认脱敏，reveal=true 时显示原值",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "容器管理"
+                ],
+                "summary": "获取容器详情",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "容器ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "显示敏感环境变量原值",
+                        "name": "reveal",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Docker 主机ID，默认使用 default_host",
+                        "name": "host",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功返回容器详情",
+                        "schema": {
+                            "$ref": "#/definitions/models.ContainerDetail"
+                        }
+                    },
+                    "404": {
+                        "description": "容器或 Docker 主机不存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/containers": {
+            "get": {
+                "description": "获取容器列表，包含状态、健康、端口、标签、网络和 Compose 项目。state / label / health / compose_project 由 Docker 过滤，name / image 为不区分大小写的子串匹配；结果按 sort 排序后分页，page_size 为 0 时返回全部",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "容器管理"
+                ],
+                "summary": "获取容器列表",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "容器状态，逗号分隔：created,running,paused,restarting,exited,dead",
+                        "name": "state",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "容器名子串",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "镜像名子串",
+                        "name": "image",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "标签过滤 key 或 key=value，可重复，需全部匹配",
+                        "name": "label",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Compose 项目名",
+                        "name": "compose_project",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "健康状态：healthy / unhealthy / starting / none",
+                        "name": "health",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "排序字段：created（默认）/ name / image / state",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "asc / desc，created 默认 desc，其余默认 asc",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "页码，从 1 开始",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页条数，默认 0 不分页，最大 500",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "返回容器可写层及总大小（较慢）",
+                        "name": "size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Docker 主机ID，默认使用 default_host",
+                        "name": "host",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功返回容器列表",
+                        "schema": {
+                            "$ref": "#/definitions/models.ContainerListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "未知 Docker 主机",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/docker/hosts": {
+            "get": {
+                "description": "返回配置中登记的 Docker 主机，其余 Docker 接口通过 host 参数指定主机",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "容器管理"
+                ],
+                "summary": "获取 Docker 主机列表",
+                "responses": {
+                    "200": {
+                        "description": "成功返回主机列表",
+                        "schema": {
+                            "$ref": "#/definitions/models.DockerHostListResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/docker/info": {
+            "get": {
+                "description": "返回 Docker 守护进程版本、协商后的 API 版本及资源概况",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "容器管理"
+                ],
+                "summary": "获取 Docker 信息",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Docker 主机ID，默认使用 default_host",
+                        "name": "host",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功返回 Docker 信息",
+                        "schema": {
+                            "$ref": "#/definitions/models.DockerInfoResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "未知 Docker 主机",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/files/batch-chmod": {
+            "post": {
+                "description": "一次性修改多个文件/目录的权限",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "文件管理"
+                ],
+                "summary": "批量修改文件权限",
+                "parameters": [
+                    {
+                        "description": "批量权限修改参数",
+                        "name": "chmod",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.BatchChmodRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "修改成功",
+                        "schema": {
+                            "$ref": "#/definitions/models.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "权限格式错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "修改失败",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/files/batch-download": {
+            "post": {
+                "description": "选中多个文件，打包为 zip 下载",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/zip"
+                ],
+                "tags": [
+                    "文件管理"
+                ],
+                "summary": "批量下载文件",
+                "parameters": [
+                    {
+                        "description": "批量下载参数",
+                        "name": "download",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.BatchDownloadRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "zip 文件",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "下载失败",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/files/delete": {
+            "post": {
+                "description": "删除指定路径下的文件或目录",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "文件管理"
+                ],
+                "summary": "删除文件或文件夹",
+                "parameters": [
+                    {
+                        "description": "删除文件参数",
+                        "name": "file",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.FileDeleteRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "删除成功",
+                        "schema": {
+                            "$ref": "#/definitions/models.SuccessResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "越权",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "删除失败",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/files/download": {
+            "get": {
+                "description": "下载指定路径文件",
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "文件管理"
+                ],
+                "summary": "下载文件",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "文件路径",
+                        "name": "path",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "文件流",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "403": {
+                        "description": "越权",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "下载失败",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/files/mkdir": {
+            "post": {
+                "description": "在指定路径下创建新目录",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "文件管理"
+                ],
+                "summary": "创建文件夹",
+                "parameters": [
+                    {
+                        "description": "创建目录参数",
+                        "name": "dir",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.MkdirRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "创建成功",
+                        "schema": {
+                            "$ref": "#/definitions/models.SuccessResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "越权",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "创建失败",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/files/move": {
+            "post": {
+                "description": "将指定文件或目录移动到目标目录",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "文件管理"
+                ],
+                "summary": "移动文件或目录",
+                "parameters": [
+                    {
+                        "description": "移动文件参数",
+                        "name": "move",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.MoveFileRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "移动成功",
+                        "schema": {
+                            "$ref": "#/definitions/models.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "移动失败",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/files/upload": {
+            "post": {
+                "description": "上传文件到指定路径",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "文件管理"
+                ],
+                "summary": "上传文件",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "目标路径，默认基础目录",
+                        "name": "path",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "上传文件",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "上传成功",
+                        "schema": {
+                            "$ref": "#/definitions/models.SuccessResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "越权",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "上传失败",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/image/inspect": {
+            "get": {
+                "description": "返回镜像配置、层列表和构建历史",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "镜像管理"
+                ],
+                "summary": "获取镜像详情",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "镜像ID或名称，如 nginx:latest",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Docker 主机ID，默认使用 default_host",
+                        "name": "host",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功返回镜像详情",
+                        "schema": {
+                            "$ref": "#/definitions/models.ImageDetail"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "镜像或 Docker 主机不存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/image/prune": {
+            "post": {
+                "description": "删除未被容器使用的悬空镜像；all=true 时清理所有未使用的镜像。dry_run=true 只返回将被删除的镜像及可回收空间（按镜像大小估算，共享层可能使实际回收更少）",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "镜像管理"
+                ],
+                "summary": "清理悬空镜像",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "只统计不删除",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "清理所有未被使用的镜像，而不仅是悬空镜像",
+                        "name": "all",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Docker 主机ID，默认使用 default_host",
+                        "name": "host",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "清理结果",
+                        "schema": {
+                            "$ref": "#/definitions/models.ImagePruneResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "未知 Docker 主机",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/image/remove": {
+            "post": {
+                "description": "按 ID 或标签删除镜像。按标签删除且镜像还有其他标签时只取消该标签；force 可删除被容器引用的镜像",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "镜像管理"
+                ],
+                "summary": "删除镜像",
+                "parameters": [
+                    {
+                        "description": "删除镜像参数",
+                        "name": "image",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ImageRemoveRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Docker 主机ID，默认使用 default_host",
+                        "name": "host",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "删除成功",
+                        "schema": {
+                            "$ref": "#/definitions/models.ImageRemoveResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "镜像或 Docker 主机不存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "镜像被容器使用",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/image/tag": {
+            "post": {
+                "description": "为已有镜像添加新的仓库名:标签",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "镜像管理"
+                ],
+                "summary": "镜像打标签",
+                "parameters": [
+                    {
+                        "description": "打标签参数",
+                        "name": "tag",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ImageTagRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Docker 主机ID，默认使用 default_host",
+                        "name": "host",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "打标签成功",
+                        "schema": {
+                            "$ref": "#/definitions/models.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "镜像或 Docker 主机不存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/images": {
+            "get": {
+                "description": "列出本地镜像的大小、标签以及正在使用它的容器",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "镜像管理"
+                ],
+                "summary": "获取镜像列表",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "只列出悬空镜像",
+                        "name": "dangling",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Docker 主机ID，默认使用 default_host",
+                        "name": "host",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功返回镜像列表",
+                        "schema": {
+                            "$ref": "#/definitions/models.ImageListResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "未知 Docker 主机",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/network/connect": {
+            "post": {
+                "description": "将容器接入网络，可设置网络内别名和静态 IP（仅自定义网络）",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "网络管理"
+                ],
+                "summary": "容器接入网络",
+                "parameters": [
+                    {
+                        "description": "接入参数",
+                        "name": "network",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.NetworkConnectRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Docker 主机ID，默认使用 default_host",
+                        "name": "host",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "接入成功",
+                        "schema": {
+                            "$ref": "#/definitions/models.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "容器已在该网络中或网络模式不允许",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "网络、容器或 Docker 主机不存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/network/create": {
+            "post": {
+                "description": "创建自定义网络，可指定驱动、子网、网关、IP 段和标签",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "网络管理"
+                ],
+                "summary": "创建网络",
+                "parameters": [
+                    {
+                        "description": "创建网络参数",
+                        "name": "network",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.NetworkCreateRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Docker 主机ID，默认使用 default_host",
+                        "name": "host",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "创建成功",
+                        "schema": {
+                            "$ref": "#/definitions/models.NetworkCreateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "未知 Docker 主机",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "网络名已存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/network/disconnect": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "网络管理"
+                ],
+                "summary": "容器断开网络",
+                "parameters": [
+                    {
+                        "description": "断开参数",
+                        "name": "network",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.NetworkDisconnectRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Docker 主机ID，默认使用 default_host",
+                        "name": "host",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "断开成功",
+                        "schema": {
+                            "$ref": "#/definitions/models.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "容器不在该网络中",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "网络、容器或 Docker 主机不存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/network/inspect": {
+            "get": {
+                "description": "返回网络的 IPAM 配置、选项以及接入的容器和地址",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "网络管理"
+                ],
+                "summary": "获取网络详情",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "网络ID或名称",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Docker 主机ID，默认使用 default_host",
+                        "name": "host",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功返回网络详情",
+                        "schema": {
+                            "$ref": "#/definitions/models.NetworkDetail"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "网络或 Docker 主机不存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/network/remove": {
+            "post": {
+                "description": "删除自定义网络。预置网络 bridge / host / none 以及仍有容器接入的网络不能删除",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "网络管理"
+                ],
+                "summary": "删除网络",
+                "parameters": [
+                    {
+                        "description": "网络ID或名称",
+                        "name": "network",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.NetworkRemoveRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Docker 主机ID，默认使用 default_host",
+                        "name": "host",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "删除成功",
+                        "schema": {
+                            "$ref": "#/definitions/models.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "预置网络或仍有容器接入",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "网络或 Docker 主机不存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/networks": {
+            "get": {
+                "description": "列出网络的驱动、子网、网关以及接入的容器数",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "网络管理"
+                ],
+                "summary": "获取网络列表",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "按驱动过滤，如 bridge",
+                        "name": "driver",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Docker 主机ID，默认使用 default_host",
+                        "name": "host",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功返回网络列表",
+                        "schema": {
+                            "$ref": "#/definitions/models.NetworkListResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "未知 Docker 主机",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/registry/credential/create": {
+            "post": {
+                "description": "保存私有仓库的用户名和密码（或 identity token），加密落盘。之后拉取该仓库的镜像时自动使用",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "镜像仓库"
+                ],
+                "summary": "新增镜像仓库凭据",
+                "parameters": [
+                    {
+                        "description": "仓库凭据",
+                        "name": "credential",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.RegistryCredentialRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "保存成功",
+                        "schema": {
+                            "$ref": "#/definitions/models.RegistryCredentialInfo"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "未配置加密口令",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "该仓库已有凭据",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/registry/credential/delete": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "镜像仓库"
+                ],
+                "summary": "删除镜像仓库凭据",
+                "parameters": [
+                    {
+                        "description": "仓库地址",
+                        "name": "credential",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.RegistryCredentialDeleteRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "删除成功",
+                        "schema": {
+                            "$ref": "#/definitions/models.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "该仓库没有凭据",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/registry/credential/test": {
+            "post": {
+                "description": "由指定 Docker 主机使用已保存的凭据登录仓库，验证凭据是否有效",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "镜像仓库"
+                ],
+                "summary": "测试镜像仓库凭据",
+                "parameters": [
+                    {
+                        "description": "仓库地址",
+                        "name": "credential",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.RegistryCredentialDeleteRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Docker 主机ID，默认使用 default_host",
+                        "name": "host",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "登录成功",
+                        "schema": {
+                            "$ref": "#/definitions/models.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "凭据无效",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "该仓库没有凭据或 Docker 主机不存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/registry/credential/update": {
+            "post": {
+                "description": "修改已保存的仓库凭据，password、token 留空表示保持原值",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "镜像仓库"
+                ],
+                "summary": "更新镜像仓库凭据",
+                "parameters": [
+                    {
+                        "description": "仓库凭据",
+                        "name": "credential",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.RegistryCredentialRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "更新成功",
+                        "schema": {
+                            "$ref": "#/definitions/models.RegistryCredentialInfo"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "未配置加密口令",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "该仓库没有凭据",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/registry/credentials": {
+            "get": {
+                "description": "返回已保存凭据的仓库地址和用户名，不返回密码和 token",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "镜像仓库"
+                ],
+                "summary": "获取镜像仓库凭据列表",
+                "responses": {
+                    "200": {
+                        "description": "成功返回凭据列表",
+                        "schema": {
+                            "$ref": "#/definitions/models.RegistryCredentialListResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/snapshot/create": {
+            "post": {
+                "description": "把容器当前的文件系统 commit 为镜像 adp-snapshot/\u003c容器名\u003e:\u003c时间戳\u003e，并记录容器配置（HostConfig、网络）和备注。快照不包含数据卷和 bind 挂载的内容，需要时配合备份接口使用。pause 默认为 true，commit 期间暂停容器",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "容器快照"
+                ],
+                "summary": "创建容器快照",
+                "parameters": [
+                    {
+                        "description": "快照参数",
+                        "name": "snapshot",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.SnapshotCreateRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Docker 主机ID，默认使用 default_host",
+                        "name": "host",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "快照成功",
+                        "schema": {
+                            "$ref": "#/definitions/models.SnapshotCreateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "容器或 Docker 主机不存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "同一秒内已创建过该容器的快照",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/snapshot/delete": {
+            "post": {
+                "description": "删除快照记录，remove_image=true 时同时删除快照镜像；镜像仍被容器使用（例如回滚后的容器）时返回 409 且不删除记录",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "容器快照"
+                ],
+                "summary": "删除容器快照",
+                "parameters": [
+                    {
+                        "description": "要删除的快照",
+                        "name": "snapshot",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.SnapshotDeleteRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Docker 主机ID，默认使用 default_host",
+                        "name": "host",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "删除成功",
+                        "schema": {
+                            "$ref": "#/definitions/models.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "快照或 Docker 主机不存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "快照镜像正在被使用",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/snapshot/rollback": {
+            "post": {
+                "description": "使用快照镜像和快照时的配置重建同名容器：HostConfig、网络（别名、静态 IP）和容器名保持不变。原容器先停止并改名，新容器启动失败时自动删除新容器并恢复原容器；成功后删除原容器，keep_old=true 时保留。原容器已被删除时直接按快照创建",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "容器快照"
+                ],
+                "summary": "回滚容器到快照",
+                "parameters": [
+                    {
+                        "description": "回滚参数",
+                        "name": "rollback",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.SnapshotRollbackRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Docker 主机ID，默认使用 default_host",
+                        "name": "host",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "回滚成功",
+                        "schema": {
+                            "$ref": "#/definitions/models.SnapshotRollbackResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误或快照不属于该主机",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "快照、快照镜像或 Docker 主机不存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "重建容器失败，原容器已恢复",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/snapshots": {
+            "get": {
+                "description": "列出当前 Docker 主机上的容器快照，最新的在前。容器被删除后其快照仍会保留，可按容器名查询并回滚",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "容器快照"
+                ],
+                "summary": "获取容器快照列表",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "按容器名过滤",
+                        "name": "container",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Docker 主机ID，默认使用 default_host",
+                        "name": "host",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功返回快照列表",
+                        "schema": {
+                            "$ref": "#/definitions/models.SnapshotListResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "未知 Docker 主机",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/template/create": {
+            "post": {
+                "description": "把创建容器的请求体保存为模板，版本号为 1。请求体中的字符串（包括标签等对象的键）可使用 ${NAME} 占位符，实例化时替换为参数值，$$ 表示字面量 $；数字、布尔字段不能使用占位符。请求体字段会按 format 对应的创建容器接口校验",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "容器模板"
+                ],
+                "summary": "新建容器模板",
+                "parameters": [
+                    {
+                        "description": "模板内容",
+                        "name": "template",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ContainerTemplateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "保存成功",
+                        "schema": {
+                            "$ref": "#/definitions/models.ContainerTemplateSaveResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "模板已存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/template/delete": {
+            "post": {
+                "description": "删除模板及其全部历史版本，已创建的容器不受影响",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "容器模板"
+                ],
+                "summary": "删除容器模板",
+                "parameters": [
+                    {
+                        "description": "模板名",
+                        "name": "template",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ContainerTemplateDeleteRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "删除成功",
+                        "schema": {
+                            "$ref": "#/definitions/models.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "模板不存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/template/inspect": {
+            "get": {
+                "description": "返回模板某个版本的请求体、参数和全部历史版本号，未指定 version 时返回最新版本",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "容器模板"
+                ],
+                "summary": "获取模板详情",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "模板名",
+                        "name": "name",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "版本号，默认最新",
+                        "name": "version",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功返回模板",
+                        "schema": {
+                            "$ref": "#/definitions/models.ContainerTemplateDetail"
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "模板或版本不存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/template/instantiate": {
+            "post": {
+                "description": "用参数值替换模板中的占位符，然后与对应版本的创建容器接口一样校验、拉取镜像、创建并启动容器（支持 wait 等全部选项）。未提供的参数使用默认值，缺少必填参数或提供了模板中不存在的参数时返回 400。dry_run=true 时只返回替换后的请求体",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "容器模板"
+                ],
+                "summary": "用模板创建容器",
+                "parameters": [
+                    {
+                        "description": "模板名、版本和参数值",
+                        "name": "instantiate",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.TemplateInstantiateRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Docker 主机ID，默认使用 default_host",
+                        "name": "host",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "创建成功或 dry_run 结果",
+                        "schema": {
+                            "$ref": "#/definitions/models.TemplateInstantiateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "参数缺失或替换后的请求不合法",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "模板、版本或 Docker 主机不存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "容器名已被占用",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "容器启动失败或未就绪",
+                        "schema": {
+                            "$ref": "#/definitions/models.ContainerNotReadyResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/template/update": {
+            "post": {
+                "description": "以完整的模板内容保存为新版本，版本号加 1，旧版本保留，可按版本号查看和实例化",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "容器模板"
+                ],
+                "summary": "修改容器模板",
+                "parameters": [
+                    {
+                        "description": "模板内容",
+                        "name": "template",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ContainerTemplateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "保存成功",
+                        "schema": {
+                            "$ref": "#/definitions/models.ContainerTemplateSaveResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.ValidationErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "模板不存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/templates": {
+            "get": {
+                "description": "列出全部容器模板的最新版本及其参数",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "容器模板"
+                ],
+                "summary": "获取容器模板列表",
+                "responses": {
+                    "200": {
+                        "description": "成功返回模板列表",
+                        "schema": {
+                            "$ref": "#/definitions/models.ContainerTemplateListResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/volume/create": {
+            "post": {
+                "description": "创建命名数据卷，可指定驱动、驱动参数和标签",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "数据卷管理"
+                ],
+                "summary": "创建数据卷",
+                "parameters": [
+                    {
+                        "description": "创建数据卷参数",
+                        "name": "volume",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.VolumeCreateRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Docker 主机ID，默认使用 default_host",
+                        "name": "host",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "创建成功",
+                        "schema": {
+                            "$ref": "#/definitions/models.VolumeCreateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "未知 Docker 主机",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "同名数据卷已使用其他驱动创建",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/volume/inspect": {
+            "get": {
+                "description": "返回数据卷的配置、磁盘占用以及挂载它的容器和挂载路径",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "数据卷管理"
+                ],
+                "summary": "获取数据卷详情",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "数据卷名称",
+                        "name": "name",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Docker 主机ID，默认使用 default_host",
+                        "name": "host",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功返回数据卷详情",
+                        "schema": {
+                            "$ref": "#/definitions/models.VolumeInfo"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "数据卷或 Docker 主机不存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/volume/prune": {
+            "post": {
+                "description": "删除没有任何容器（包括已停止的容器）挂载的 local 数据卷。dry_run=true 只返回将被删除的数据卷及可回收空间；label 只清理带指定标签的数据卷",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "数据卷管理"
+                ],
+                "summary": "清理未使用的数据卷",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "只统计不删除",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "按标签过滤，key 或 key=value",
+                        "name": "label",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Docker 主机ID，默认使用 default_host",
+                        "name": "host",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "清理结果",
+                        "schema": {
+                            "$ref": "#/definitions/models.VolumePruneResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "未知 Docker 主机",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/volume/remove": {
+            "post": {
+                "description": "删除数据卷，仍有容器挂载时返回 409",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "数据卷管理"
+                ],
+                "summary": "删除数据卷",
+                "parameters": [
+                    {
+                        "description": "数据卷名称",
+                        "name": "volume",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.VolumeRemoveRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Docker 主机ID，默认使用 default_host",
+                        "name": "host",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "删除成功",
+                        "schema": {
+                            "$ref": "#/definitions/models.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "数据卷或 Docker 主机不存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "数据卷正在使用",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/volumes": {
+            "get": {
+                "description": "列出数据卷的驱动、磁盘占用、挂载它的容器以及所属 compose 项目；dangling=true 只列出没有容器挂载的孤立数据卷",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "数据卷管理"
+                ],
+                "summary": "获取数据卷列表",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "只列出未被任何容器使用的数据卷",
+                        "name": "dangling",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "按驱动过滤，如 local",
+                        "name": "driver",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "只列出属于该 compose 项目的数据卷",
+                        "name": "compose_project",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Docker 主机ID，默认使用 default_host",
+                        "name": "host",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功返回数据卷列表",
+                        "schema": {
+                            "$ref": "#/definitions/models.VolumeListResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "未知 Docker 主机",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/ws/container-exec/{id}": {
+            "get": {
+                "description": "通过 WebSocket 打开带 TTY 的 docker exec 会话。服务端以二进制消息推送终端输出；客户端发送二进制消息作为 stdin，或发送 JSON 文本消息 {\"type\":\"input\",\"data\":\"ls\\r\"} / {\"type\":\"resize\",\"cols\":120,\"rows\":40}。连接断开后自动结束 exec 会话。只接受同源页面或 docker.exec_allowed_origins 中列出的页面发起的连接",
+                "tags": [
+                    "容器管理"
+                ],
+                "summary": "容器交互式终端",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "容器ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "shell：sh / bash / ash / zsh，默认 sh",
+                        "name": "shell",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "初始终端列数",
+                        "name": "cols",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "初始终端行数",
+                        "name": "rows",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Docker 主机ID，默认使用 default_host",
+                        "name": "host",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "101": {
+                        "description": "WebSocket 连接已建立",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "shell 不支持",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "页面来源不在允许范围内",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "容器或 Docker 主机不存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "容器未运行",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "exec 创建失败",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/ws/container-logs/{id}": {
+            "get": {
+                "description": "通过 WebSocket 推送容器日志，每行一条 JSON 消息 {\"stream\":\"stdout|stderr\",\"timestamp\":\"...\",\"text\":\"...\"}，出错时 stream 为 error。follow=false 时推送完历史日志后正常关闭连接",
+                "tags": [
+                    "容器管理"
+                ],
+                "summary": "实时获取容器日志",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "容器ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "从末尾开始的行数，all 表示全部，默认 50",
+                        "name": "tail",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "起始时间：RFC3339、Unix 时间戳或相对时长 (10m)",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "截止时间，格式同 since",
+                        "name": "until",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "消息是否携带 timestamp，默认 true",
+                        "name": "timestamps",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "是否持续推送新日志，默认 true",
+                        "name": "follow",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Docker 主机ID，默认使用 default_host",
+                        "name": "host",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "101": {
+                        "description": "WebSocket 连接已建立，逐行推送日志",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.LogFrame"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "容器或 Docker 主机不存在",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/ws/container-stats": {
+            "get": {
+                "description": "按 interval 秒推送容器 CPU %、内存使用/上限、网络收发、块设备读写。ids 为空时推送所有运行中的容器，并随容器启停自动增减",
+                "tags": [
+                    "容器管理"
+                ],
+                "summary": "实时推送容器资源使用",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "容器ID或名称，逗号分隔",
+                        "name": "ids",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "推送间隔秒数，默认 3",
+                        "name": "interval",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Docker 主机ID，默认使用 default_host",
+                        "name": "host",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "101": {
+                        "description": "WebSocket 连接已建立，推送 []ContainerStatsInfo",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "未知 Docker 主机",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/ws/docker-events": {
+            "get": {
+                "description": "转发 Docker 守护进程的事件流（容器 start/die/oom/health_status、镜像 pull/delete、网络和数据卷事件），每个事件一条 JSON 消息，供容器和 Compose 页面实时刷新。type / event / container 可用逗号分隔多个值，label 可重复传入且需全部匹配；compose_project 按容器的 Compose 项目标签过滤，网络和数据卷事件不带标签会被排除。出错时推送 type=error 的消息后关闭连接",
+                "tags": [
+                    "容器管理"
+                ],
+                "summary": "实时推送 Docker 事件",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "事件类型：container,image,network,volume,daemon",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "事件动作，如 start,die,oom,health_status",
+                        "name": "event",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "容器ID或名称，逗号分隔",
+                        "name": "container",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "镜像名",
+                        "name": "image",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "标签过滤 key 或 key=value，可重复",
+                        "name": "label",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Compose 项目名",
+                        "name": "compose_project",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "先补发该时间之后的事件：RFC3339、Unix 时间戳或相对时长 (10m)",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Docker 主机ID，默认使用 default_host",
+                        "name": "host",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "101": {
+                        "description": "WebSocket 连接已建立，逐条推送事件",
+                        "schema": {
+                            "$ref": "#/definitions/controllers.DockerEventFrame"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "未知 Docker 主机",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/ws/image-pull": {
+            "get": {
+                "description": "通过 WebSocket 推送镜像拉取进度（每层的状态和字节数），最后一条消息 status 为 done 或 error。客户端断开连接即取消拉取",
+                "tags": [
+                    "镜像管理"
+                ],
+                "summary": "拉取镜像并推送进度",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "镜像名，如 nginx:1.25",
+                        "name": "image",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Docker 主机ID，默认使用 default_host",
+                        "name": "host",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "101": {
+                        "description": "WebSocket 连接已建立，逐条推送进度",
+                        "schema": {
+                            "$ref": "#/definitions/models.ImagePullProgress"
+                        }
+                    },
+                    "400": {
+                        "description": "参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "未知 Docker 主机",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "controllers.DockerEventFrame": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "description": "start / die / oom / health_status / pull / delete / connect ...",
+                    "type": "string"
+                },
+                "attributes": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "compose_project": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "health_status 的健康状态，exec_* 的命令",
+                    "type": "string"
+                },
+                "time": {
+                    "description": "RFC3339Nano，断线重连时作为 since 传回即可续传",
+                    "type": "string"
+                },
+                "type": {
+                    "description": "container / image / network / volume / daemon，出错时为 error",
+                    "type": "string"
+                }
+            }
+        },
+        "controllers.LogFrame": {
+            "type": "object",
+            "properties": {
+                "stream": {
+                    "description": "stdout / stderr / error",
+                    "type": "string"
+                },
+                "text": {
+                    "type": "string"
+                },
+                "timestamp": {
+                    "description": "RFC3339Nano，断线重连时作为 since 传回即可续传",
+                    "type": "string"
+                }
+            }
+        },
+        "models.AutoUpdateReport": {
+            "type": "object",
+            "properties": {
+                "available": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "checked": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "dry_run": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "failed": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "finished_at": {
+                    "type": "string",
+                    "example": "2025-03-23T02:01:12+08:00"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "20250323-020000"
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AutoUpdateResult"
+                    }
+                },
+                "skipped": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "started_at": {
+                    "type": "string",
+                    "example": "2025-03-23T02:00:00+08:00"
+                },
+                "trigger": {
+                    "description": "schedule / manual",
+                    "type": "string",
+                    "example": "schedule"
+                },
+                "updated": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "models.AutoUpdateReportSummary": {
+            "type": "object",
+            "properties": {
+                "available": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "checked": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "dry_run": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "failed": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "finished_at": {
+                    "type": "string",
+                    "example": "2025-03-23T02:01:12+08:00"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "20250323-020000"
+                },
+                "skipped": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "started_at": {
+                    "type": "string",
+                    "example": "2025-03-23T02:00:00+08:00"
+                },
+                "trigger": {
+                    "description": "schedule / manual",
+                    "type": "string",
+                    "example": "schedule"
+                },
+                "updated": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "models.AutoUpdateResult": {
+            "type": "object",
+            "properties": {
+                "container": {
+                    "type": "string",
+                    "example": "web"
+                },
+                "container_id": {
+                    "type": "string",
+                    "example": "a1b2c3d4e5f6"
+                },
+                "error": {
+                    "type": "string",
+                    "example": "container 9f86d081884c is unhealthy"
+                },
+                "host": {
+                    "type": "string",
+                    "example": "local"
+                },
+                "image": {
+                    "type": "string",
+                    "example": "nginx:1.27"
+                },
+                "local_digest": {
+                    "type": "string",
+                    "example": "sha256:1a2b3c4d"
+                },
+                "new_container_id": {
+                    "type": "string",
+                    "example": "b2c3d4e5f6a1"
+                },
+                "remote_digest": {
+                    "type": "string",
+                    "example": "sha256:5e6f7a8b"
+                },
+                "status": {
+                    "description": "up_to_date / update_available / updated / failed / skipped",
+                    "type": "string",
+                    "example": "updated"
+                }
+            }
+        },
+        "models.AutoUpdateRunRequest": {
+            "type": "object",
+            "properties": {
+                "dry_run": {
+                    "description": "只检查不重建",
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "models.AutoUpdateRunResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Auto update finished"
+                },
+                "report": {
+                    "$ref": "#/definitions/models.AutoUpdateReport"
+                }
+            }
+        },
+        "models.AutoUpdateStatusResponse": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "interval": {
+                    "type": "string",
+                    "example": "1h0m0s"
+                },
+                "label": {
+                    "type": "string",
+                    "example": "adp.autoupdate=true"
+                },
+                "next_run": {
+                    "type": "string",
+                    "example": "2025-03-23T02:00:00+08:00"
+                },
+                "reports": {
+                    "description": "最新的在前",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AutoUpdateReportSummary"
+                    }
+                },
+                "running": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "window": {
+                    "description": "维护窗口，为空不限",
+                    "type": "string",
+                    "example": "02:00-05:00"
+                }
+            }
+        },
+        "models.BackupCreateRequest": {
+            "type": "object",
+            "properties": {
+                "container": {
+                    "type": "string",
+                    "example": ""
+                },
+                "note": {
+                    "type": "string",
+                    "example": "before upgrade"
+                },
+                "stop_container": {
+                    "description": "备份期间停止使用这些数据的容器，完成后重新启动",
+                    "type": "boolean",
+                    "example": true
+                },
+                "volume": {
+                    "type": "string",
+                    "example": "web-data"
+                }
+            }
+        },
+        "models.BackupCreateResponse": {
+            "type": "object",
+            "properties": {
+                "backup": {
+                    "$ref": "#/definitions/models.BackupInfo"
+                },
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Backup created"
+                },
+                "stopped": {
+                    "description": "备份期间被停止并已重新启动的容器",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "/web"
+                    ]
+                }
+            }
+        },
+        "models.BackupDeleteRequest": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "example": "volume-web-data-20250322-123456"
+                }
+            }
+        },
+        "models.BackupInfo": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "string",
+                    "example": "2025-03-22T12:34:56Z"
+                },
+                "file": {
+                    "type": "string",
+                    "example": "volume-web-data-20250322-123456.tar.gz"
+                },
+                "host": {
+                    "type": "string",
+                    "example": "local"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "volume-web-data-20250322-123456"
+                },
+                "kind": {
+                    "description": "volume / container",
+                    "type": "string",
+                    "example": "volume"
+                },
+                "mounts": {
+                    "description": "kind=container 时包含的挂载",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.BackupMount"
+                    }
+                },
+                "note": {
+                    "type": "string",
+                    "example": "before upgrade"
+                },
+                "sha256": {
+                    "type": "string",
+                    "example": "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+                },
+                "size": {
+                    "type": "integer",
+                    "example": 1048576
+                },
+                "source": {
+                    "description": "数据卷名或容器名",
+                    "type": "string",
+                    "example": "web-data"
+                }
+            }
+        },
+        "models.BackupListResponse": {
+            "type": "object",
+            "properties": {
+                "backups": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.BackupInfo"
+                    }
+                }
+            }
+        },
+        "models.BackupMount": {
+            "type": "object",
+            "properties": {
+                "destination": {
+                    "type": "string",
+                    "example": "/usr/share/nginx/html"
+                },
+                "source": {
+                    "type": "string",
+                    "example": "web-data"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "volume"
+                }
+            }
+        },
+        "models.BackupRestoreRequest": {
+            "type": "object",
+            "properties": {
+                "clean": {
+                    "description": "恢复前清空目标，否则只覆盖同名文件",
+                    "type": "boolean",
+                    "example": true
+                },
+                "container": {
+                    "description": "目标容器，默认为备份来源",
+                    "type": "string",
+                    "example": ""
+                },
+                "id": {
+                    "type": "string",
+                    "example": "volume-web-data-20250322-123456"
+                },
+                "stop_container": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "volume": {
+                    "description": "目标数据卷，默认为备份来源，不存在时自动创建",
+                    "type": "string",
+                    "example": "web-data-restored"
+                }
+            }
+        },
+        "models.BackupRestoreResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Backup restored"
+                },
+                "stopped": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "/web"
+                    ]
+                },
+                "target": {
+                    "type": "string",
+                    "example": "web-data-restored"
+                }
+            }
+        },
+        "models.BatchChmodRequest": {
+            "type": "object",
+            "properties": {
+                "mode": {
+                    "type": "string",
+                    "example": "755"
+                },
+                "names": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "[\"file1\"",
+                        " \"folder1\"]"
+                    ]
+                },
+                "path": {
+                    "type": "string",
+                    "example": "/tmp/file-manager"
+                }
+            }
+        },
+        "models.BatchDeleteRequest": {
+            "type": "object",
+            "properties": {
+                "names": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "[\"file1.txt\"",
+                        " \"folder2\"]"
+                    ]
+                },
+                "path": {
+                    "type": "string",
+                    "example": "/data"
+                }
+            }
+        },
+        "models.BatchDownloadRequest": {
+            "type": "object",
+            "properties": {
+                "names": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "[\"a.txt\"",
+                        " \"b.txt\"]"
+                    ]
+                },
+                "path": {
+                    "type": "string",
+                    "example": "/tmp/file-manager"
+                }
+            }
+        },
+        "models.BlueGreenApp": {
+            "type": "object",
+            "properties": {
+                "app": {
+                    "type": "string",
+                    "example": "web"
+                },
+                "history": {
+                    "description": "最新的在前",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.BlueGreenEvent"
+                    }
+                },
+                "host": {
+                    "type": "string",
+                    "example": "local"
+                },
+                "live": {
+                    "description": "当前在线的颜色",
+                    "type": "string",
+                    "example": "green"
+                },
+                "slots": {
+                    "description": "blue、green 各一项，未部署过的颜色不返回",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.BlueGreenSlot"
+                    }
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2025-03-22T12:34:56Z"
+                }
+            }
+        },
+        "models.BlueGreenDeployRequest": {
+            "type": "object",
+            "properties": {
+                "app": {
+                    "description": "应用名，首次部署时为已有容器的名称",
+                    "type": "string",
+                    "example": "web"
+                },
+                "health_timeout": {
+                    "description": "等待新容器就绪的最长秒数，默认 60，最大 600",
+                    "type": "integer",
+                    "example": 60
+                },
+                "image": {
+                    "type": "string",
+                    "example": "nginx:1.27"
+                },
+                "min_uptime": {
+                    "description": "无健康检查时需持续运行的秒数，默认 5，须小于 health_timeout",
+                    "type": "integer",
+                    "example": 5
+                },
+                "pull": {
+                    "description": "是否先拉取镜像，默认 true",
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "models.BlueGreenEvent": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "description": "deploy / rollback",
+                    "type": "string",
+                    "example": "deploy"
+                },
+                "at": {
+                    "type": "string",
+                    "example": "2025-03-22T12:34:56Z"
+                },
+                "from": {
+                    "type": "string",
+                    "example": "blue"
+                },
+                "image": {
+                    "type": "string",
+                    "example": "nginx:1.27"
+                },
+                "to": {
+                    "type": "string",
+                    "example": "green"
+                }
+            }
+        },
+        "models.BlueGreenListResponse": {
+            "type": "object",
+            "properties": {
+                "apps": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.BlueGreenApp"
+                    }
+                }
+            }
+        },
+        "models.BlueGreenResponse": {
+            "type": "object",
+            "properties": {
+                "app": {
+                    "$ref": "#/definitions/models.BlueGreenApp"
+                },
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Switched to green"
+                },
+                "temporary_ports": {
+                    "description": "新版本健康检查期间使用的临时端口",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "0.0.0.0:49153-\u003e80/tcp"
+                    ]
+                }
+            }
+        },
+        "models.BlueGreenRollbackRequest": {
+            "type": "object",
+            "properties": {
+                "app": {
+                    "type": "string",
+                    "example": "web"
+                },
+                "health_timeout": {
+                    "type": "integer",
+                    "example": 60
+                },
+                "min_uptime": {
+                    "type": "integer",
+                    "example": 5
+                }
+            }
+        },
+        "models.BlueGreenSlot": {
+            "type": "object",
+            "properties": {
+                "color": {
+                    "type": "string",
+                    "example": "green"
+                },
+                "container": {
+                    "type": "string",
+                    "example": "web-green"
+                },
+                "container_id": {
+                    "type": "string",
+                    "example": "a1b2c3d4e5f6"
+                },
+                "deployed_at": {
+                    "type": "string",
+                    "example": "2025-03-22T12:34:56Z"
+                },
+                "image": {
+                    "type": "string",
+                    "example": "nginx:1.27"
+                },
+                "image_id": {
+                    "type": "string",
+                    "example": "sha256:3b25b682ea82"
+                },
+                "live": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "state": {
+                    "description": "容器当前状态，已被删除时为 missing",
+                    "type": "string",
+                    "example": "running"
+                }
+            }
+        },
+        "models.ChmodRequest": {
+            "type": "object",
+            "properties": {
+                "mode": {
+                    "type": "string",
+                    "example": "755"
+                },
+                "path": {
+                    "type": "string",
+                    "example": "/data/file.txt"
+                }
+            }
+        },
+        "models.ComposeActionRequest": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "my-app"
+                }
+            }
+        },
+        "models.ComposeContainerInfo": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "example": "a1b2c3d4e5f6"
+                },
+                "image": {
+                    "type": "string",
+                    "example": "nginx:latest"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "/app1-web"
+                },
+                "ports": {
+                    "type": "string",
+                    "example": "8080:80 443:443"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "Up 3 minutes"
+                }
+            }
+        },
+        "models.ComposeStatusResponse": {
+            "type": "object",
+            "properties": {
+                "apps": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "$ref": "#/definitions/models.ComposeContainerInfo"
+                        }
+                    }
+                }
+            }
+        },
+        "models.CompressRequest": {
+            "type": "object",
+            "properties": {
+                "names": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "[\"file1.txt\"",
+                        " \"folder\"]"
+                    ]
+                },
+                "path": {
+                    "type": "string",
+                    "example": "/data"
+                },
+                "type": {
+                    "description": "可选 zip/tar.gz",
+                    "type": "string",
+                    "example": "zip"
+                }
+            }
+        },
+        "models.ContainerDetail": {
+            "type": "object",
+            "properties": {
+                "command": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "nginx",
+                        "-g",
+                        "daemon off;"
+                    ]
+                },
+                "created": {
+                    "type": "string",
+                    "example": "2025-03-22T12:34:56.789Z"
+                },
+                "entrypoint": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "/docker-entrypoint.sh"
+                    ]
+                },
+                "env": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "DB_HOST=db",
+                        "DB_PASSWORD=******"
+                    ]
+                },
+                "env_masked": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "id": {
+                    "type": "string",
+                    "example": "a1b2c3d4e5f6"
+                },
+                "image": {
+                    "type": "string",
+                    "example": "nginx:latest"
+                },
+                "image_id": {
+                    "type": "string",
+                    "example": "sha256:3f8a4339aadd"
+                },
+                "labels": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "mounts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ContainerMount"
+                    }
+                },
+                "name": {
+                    "type": "string",
+                    "example": "/my-container"
+                },
+                "networks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ContainerNetwork"
+                    }
+                },
+                "ports": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ContainerPortBinding"
+                    }
+                },
+                "resources": {
+                    "$ref": "#/definitions/models.ContainerResources"
+                },
+                "restart_count": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "restart_policy": {
+                    "$ref": "#/definitions/models.ContainerRestartPolicy"
+                },
+                "state": {
+                    "$ref": "#/definitions/models.ContainerState"
+                },
+                "user": {
+                    "type": "string",
+                    "example": "1000:1000"
+                },
+                "working_dir": {
+                    "type": "string",
+                    "example": "/app"
+                }
+            }
+        },
+        "models.ContainerHealth": {
+            "type": "object",
+            "properties": {
+                "failing_streak": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "last_exit_code": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "last_output": {
+                    "type": "string",
+                    "example": "OK"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "healthy"
+                }
+            }
+        },
+        "models.ContainerInfo": {
+            "type": "object",
+            "properties": {
+                "command": {
+                    "type": "string",
+                    "example": "/docker-entrypoint.sh nginx -g 'daemon off;'"
+                },
+                "compose_project": {
+                    "type": "string",
+                    "example": "blog"
+                },
+                "created": {
+                    "type": "integer",
+                    "example": 1678901234
+                },
+                "health": {
+                    "description": "healthy / unhealthy / starting，未配置健康检查时为空",
+                    "type": "string",
+                    "example": "healthy"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "a1b2c3d4e5f6"
+                },
+                "image": {
+                    "type": "string",
+                    "example": "nginx:latest"
+                },
+                "image_id": {
+                    "type": "string",
+                    "example": "sha256:9f86d081884c"
+                },
+                "labels": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string",
+                    "example": "/my-container"
+                },
+                "networks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ContainerNetwork"
+                    }
+                },
+                "ports": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ContainerPortBinding"
+                    }
+                },
+                "size_root_fs": {
+                    "description": "size=true 时返回，含镜像的总大小",
+                    "type": "integer",
+                    "example": 1429960
+                },
+                "size_rw": {
+                    "description": "size=true 时返回，容器可写层大小",
+                    "type": "integer",
+                    "example": 12288
+                },
+                "state": {
+                    "description": "created / running / paused / restarting / exited / dead",
+                    "type": "string",
+                    "example": "running"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "Up 2 hours (healthy)"
+                }
+            }
+        },
+        "models.ContainerListResponse": {
+            "type": "object",
+            "properties": {
+                "containers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ContainerInfo"
+                    }
+                },
+                "page": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "page_size": {
+                    "description": "0 表示未分页",
+                    "type": "integer",
+                    "example": 20
+                },
+                "total": {
+                    "description": "过滤后、分页前的容器数",
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "models.ContainerMount": {
+            "type": "object",
+            "properties": {
+                "destination": {
+                    "type": "string",
+                    "example": "/container/path"
+                },
+                "mode": {
+                    "type": "string",
+                    "example": "ro"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "my-volume"
+                },
+                "rw": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "source": {
+                    "type": "string",
+                    "example": "/host/path"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "bind"
+                }
+            }
+        },
+        "models.ContainerNetwork": {
+            "type": "object",
+            "properties": {
+                "aliases": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "web"
+                    ]
+                },
+                "gateway": {
+                    "type": "string",
+                    "example": "172.17.0.1"
+                },
+                "ip_address": {
+                    "type": "string",
+                    "example": "172.17.0.2"
+                },
+                "mac_address": {
+                    "type": "string",
+                    "example": "02:42:ac:11:00:02"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "bridge"
+                }
+            }
+        },
+        "models.ContainerNotReadyResponse": {
+            "type": "object",
+            "properties": {
+                "detail": {
+                    "type": "string",
+                    "example": "container a1b2c3d4e5f6 exited with code 1"
+                },
+                "error": {
+                    "type": "string",
+                    "example": "Container is not ready"
+                },
+                "exit_code": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "health": {
+                    "type": "string",
+                    "example": "unhealthy"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "a1b2c3d4e5f6"
+                },
+                "logs": {
+                    "description": "最后若干行日志，stderr 行以 \"stderr: \" 开头",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "removed": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "status": {
+                    "type": "string",
+                    "example": "exited"
+                }
+            }
+        },
+        "models.ContainerPortBinding": {
+            "type": "object",
+            "properties": {
+                "container_port": {
+                    "type": "string",
+                    "example": "80"
+                },
+                "host_ip": {
+                    "type": "string",
+                    "example": "0.0.0.0"
+                },
+                "host_port": {
+                    "type": "string",
+                    "example": "8080"
+                },
+                "protocol": {
+                    "type": "string",
+                    "example": "tcp"
+                }
+            }
+        },
+        "models.ContainerResources": {
+            "type": "object",
+            "properties": {
+                "cpu_shares": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "memory": {
+                    "type": "integer",
+                    "example": 536870912
+                },
+                "memory_reservation": {
+                    "type": "integer",
+                    "example": 268435456
+                },
+                "memory_swap": {
+                    "type": "integer",
+                    "example": 1073741824
+                },
+                "nano_cpus": {
+                    "type": "integer",
+                    "example": 500000000
+                },
+                "pids_limit": {
+                    "type": "integer",
+                    "example": 100
+                }
+            }
+        },
+        "models.ContainerRestartPolicy": {
+            "type": "object",
+            "properties": {
+                "maximum_retry_count": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "name": {
+                    "type": "string",
+                    "example": "always"
+                }
+            }
+        },
+        "models.ContainerState": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": ""
+                },
+                "exit_code": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "finished_at": {
+                    "type": "string",
+                    "example": "0001-01-01T00:00:00Z"
+                },
+                "health": {
+                    "$ref": "#/definitions/models.ContainerHealth"
+                },
+                "oom_killed": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "paused": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "restarting": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "running": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "started_at": {
+                    "type": "string",
+                    "example": "2025-03-22T12:34:57.123Z"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "running"
+                }
+            }
+        },
+        "models.ContainerTemplateDeleteRequest": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "web"
+                }
+            }
+        },
+        "models.ContainerTemplateDetail": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "nginx 前端"
+                },
+                "format": {
+                    "type": "string",
+                    "example": "v2"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "web"
+                },
+                "parameters": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TemplateParameter"
+                    }
+                },
+                "payload": {
+                    "type": "object"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2025-03-22T12:34:56Z"
+                },
+                "version": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "versions": {
+                    "description": "全部历史版本",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        1,
+                        2,
+                        3
+                    ]
+                }
+            }
+        },
+        "models.ContainerTemplateListResponse": {
+            "type": "object",
+            "properties": {
+                "templates": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ContainerTemplateSummary"
+                    }
+                }
+            }
+        },
+        "models.ContainerTemplateRequest": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "nginx 前端"
+                },
+                "format": {
+                    "description": "v1 / v2，对应 /api/v1 或 /api/v2 的创建容器请求，默认 v2",
+                    "type": "string",
+                    "example": "v2"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "web"
+                },
+                "parameters": {
+                    "description": "可选，为占位符设置说明和默认值，未声明或未设置 default 的占位符视为必填参数",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TemplateParameter"
+                    }
+                },
+                "payload": {
+                    "type": "object"
+                }
+            }
+        },
+        "models.ContainerTemplateSaveResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Template saved"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "web"
+                },
+                "parameters": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TemplateParameter"
+                    }
+                },
+                "version": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "models.ContainerTemplateSummary": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "nginx 前端"
+                },
+                "format": {
+                    "type": "string",
+                    "example": "v2"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "web"
+                },
+                "parameters": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TemplateParameter"
+                    }
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2025-03-22T12:34:56Z"
+                },
+                "version": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "models.CreateContainerRequest": {
+            "type": "object",
+            "properties": {
+                "cpu": {
+                    "description": "单位核",
+                    "type": "string",
+                    "example": "0.5"
+                },
+                "envs": {
+                    "type": "string",
+                    "example": "ENV_VAR1=value1,ENV_VAR2=value2"
+                },
+                "health_timeout": {
+                    "description": "wait 时等待就绪的秒数",
+                    "type": "integer",
+                    "example": 60
+                },
+                "image": {
+                    "type": "string",
+                    "example": "nginx:latest"
+                },
+                "memory": {
+                    "description": "单位 m/g",
+                    "type": "string",
+                    "example": "512m"
+                },
+                "min_uptime": {
+                    "description": "wait 且无健康检查时需持续运行的秒数",
+                    "type": "integer",
+                    "example": 5
+                },
+                "name": {
+                    "type": "string",
+                    "example": "my-container"
+                },
+                "network": {
+                    "description": "host/bridge",
+                    "type": "string",
+                    "example": "bridge"
+                },
+                "networks": {
+                    "description": "接入多个网络并设置别名 / 静态 IP 时使用",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.NetworkAttachment"
+                    }
+                },
+                "ports": {
+                    "description": "hostPort:containerPort[/protocol]",
+                    "type": "string",
+                    "example": "8080:80,8443:443"
+                },
+                "remove_on_failure": {
+                    "description": "启动失败或未就绪时删除容器",
+                    "type": "boolean",
+                    "example": false
+                },
+                "restart": {
+                    "description": "Restart 策略",
+                    "type": "string",
+                    "example": "always"
+                },
+                "volumes": {
+                    "description": "source:target[:ro]，source 非绝对路径时为数据卷",
+                    "type": "string",
+                    "example": "/host/path:/container/path"
+                },
+                "wait": {
+                    "description": "等待容器就绪后再返回，见 CreateContainerV2Request",
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "models.CreateContainerResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "id": {
+                    "type": "string",
+                    "example": "a1b2c3d4e5f6"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Container created"
+                }
+            }
+        },
+        "models.CreateContainerV2Request": {
+            "type": "object",
+            "properties": {
+                "cap_add": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "NET_ADMIN"
+                    ]
+                },
+                "cap_drop": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "ALL"
+                    ]
+                },
+                "command": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "nginx",
+                        "-g",
+                        "daemon off;"
+                    ]
+                },
+                "entrypoint": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "/docker-entrypoint.sh"
+                    ]
+                },
+                "env": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.EnvVar"
+                    }
+                },
+                "health_timeout": {
+                    "description": "等待就绪的最长秒数，默认 60，最大 600",
+                    "type": "integer",
+                    "example": 60
+                },
+                "healthcheck": {
+                    "$ref": "#/definitions/models.HealthcheckSpec"
+                },
+                "hostname": {
+                    "type": "string",
+                    "example": "web"
+                },
+                "image": {
+                    "type": "string",
+                    "example": "nginx:1.25"
+                },
+                "labels": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "min_uptime": {
+                    "description": "无健康检查时需持续运行的秒数，默认 5，须小于 health_timeout",
+                    "type": "integer",
+                    "example": 5
+                },
+                "mounts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.MountSpec"
+                    }
+                },
+                "name": {
+                    "type": "string",
+                    "example": "web"
+                },
+                "network": {
+                    "description": "网络模式：bridge / host / none 或网络名，多个网络时使用 networks",
+                    "type": "string",
+                    "example": "bridge"
+                },
+                "networks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.NetworkAttachment"
+                    }
+                },
+                "ports": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PortSpec"
+                    }
+                },
+                "privileged": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "remove_on_failure": {
+                    "description": "启动失败或未就绪时删除容器",
+                    "type": "boolean",
+                    "example": true
+                },
+                "resources": {
+                    "$ref": "#/definitions/models.ResourceSpec"
+                },
+                "restart_policy": {
+                    "$ref": "#/definitions/models.RestartPolicySpec"
+                },
+                "user": {
+                    "type": "string",
+                    "example": "1000:1000"
+                },
+                "wait": {
+                    "description": "健康门控：wait=true 时启动后等待容器运行，配置了健康检查（含镜像自带的 HEALTHCHECK）时等到 healthy",
+                    "type": "boolean",
+                    "example": true
+                },
+                "working_dir": {
+                    "type": "string",
+                    "example": "/app"
+                }
+            }
+        },
+        "models.DockerHostInfo": {
+            "type": "object",
+            "properties": {
+                "default": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "endpoint": {
+                    "type": "string",
+                    "example": "unix:///var/run/docker.sock"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "local"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Localhost"
+                },
+                "tls": {
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "models.DockerHostListResponse": {
+            "type": "object",
+            "properties": {
+                "hosts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.DockerHostInfo"
+                    }
+                }
+            }
+        },
+        "models.DockerInfoResponse": {
+            "type": "object",
+            "properties": {
+                "api_version": {
+                    "type": "string",
+                    "example": "1.41"
+                },
+                "arch": {
+                    "type": "string",
+                    "example": "x86_64"
+                },
+                "client_api_version": {
+                    "type": "string",
+                    "example": "1.41"
+                },
+                "containers": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "containers_running": {
+                    "type": "integer",
+                    "example": 8
+                },
+                "images": {
+                    "type": "integer",
+                    "example": 30
+                },
+                "kernel_version": {
+                    "type": "string",
+                    "example": "5.15.0-105-generic"
+                },
+                "mem_total": {
+                    "type": "integer",
+                    "example": 8218431488
+                },
+                "name": {
+                    "type": "string",
+                    "example": "docker-host"
+                },
+                "ncpu": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "os": {
+                    "type": "string",
+                    "example": "Ubuntu 22.04.4 LTS"
+                },
+                "server_version": {
+                    "type": "string",
+                    "example": "20.10.23"
+                }
+            }
+        },
+        "models.EnvVar": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "DB_HOST"
+                },
+                "value": {
+                    "type": "string",
+                    "example": "db"
+                }
+            }
+        },
+        "models.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 500
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Internal Server Error"
+                }
+            }
+        },
+        "models.FieldError": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string",
+                    "example": "ports[0].container_port"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "invalid port \"8o\""
+                }
+            }
+        },
+        "models.FileConfigResponse": {
+            "type": "object",
+            "properties": {
+                "allowAll": {
+                    "type": "boolean"
+                },
+                "apiBaseUrl": {
+                    "type": "string"
+                },
+                "baseDir": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.FileContentResponse": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.FileDeleteRequest": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "file.txt"
+                },
+                "path": {
+                    "type": "string",
+                    "example": "/data"
+                }
+            }
+        },
+        "models.FileInfo": {
+            "type": "object",
+            "properties": {
+                "is_dir": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "mod_time": {
+                    "type": "string",
+                    "example": "2025-03-22 12:34:56"
+                },
+                "mode": {
+                    "type": "string",
+                    "example": "rw-r--r--"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "file.txt"
+                },
+                "size": {
+                    "type": "integer",
+                    "example": 1024
+                }
+            }
+        },
+        "models.HealthcheckSpec": {
+            "type": "object",
+            "properties": {
+                "interval": {
+                    "type": "string",
+                    "example": "30s"
+                },
+                "retries": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "start_period": {
+                    "type": "string",
+                    "example": "10s"
+                },
+                "test": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "CMD-SHELL",
+                        "curl -f http://localhost/ || exit 1"
+                    ]
+                },
+                "timeout": {
+                    "type": "string",
+                    "example": "5s"
+                }
+            }
+        },
+        "models.ImageDetail": {
+            "type": "object",
+            "properties": {
+                "architecture": {
+                    "type": "string",
+                    "example": "amd64"
+                },
+                "author": {
+                    "type": "string",
+                    "example": ""
+                },
+                "command": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "nginx",
+                        "-g",
+                        "daemon off;"
+                    ]
+                },
+                "containers": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "/web"
+                    ]
+                },
+                "created": {
+                    "type": "string",
+                    "example": "2025-03-22T12:34:56.789Z"
+                },
+                "digests": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "nginx@sha256:0d17b565c37b"
+                    ]
+                },
+                "entrypoint": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "/docker-entrypoint.sh"
+                    ]
+                },
+                "env": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "PATH=/usr/local/sbin:/usr/local/bin"
+                    ]
+                },
+                "exposed_ports": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "80/tcp"
+                    ]
+                },
+                "history": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ImageHistoryItem"
+                    }
+                },
+                "id": {
+                    "type": "string",
+                    "example": "sha256:3f8a4339aadd"
+                },
+                "labels": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "layers": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "sha256:8cbe4b54fa88"
+                    ]
+                },
+                "os": {
+                    "type": "string",
+                    "example": "linux"
+                },
+                "size": {
+                    "type": "integer",
+                    "example": 187654321
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "nginx:latest"
+                    ]
+                },
+                "user": {
+                    "type": "string",
+                    "example": ""
+                },
+                "working_dir": {
+                    "type": "string",
+                    "example": "/"
+                }
+            }
+        },
+        "models.ImageHistoryItem": {
+            "type": "object",
+            "properties": {
+                "comment": {
+                    "type": "string",
+                    "example": ""
+                },
+                "created": {
+                    "type": "integer",
+                    "example": 1678901234
+                },
+                "created_by": {
+                    "type": "string",
+                    "example": "/bin/sh -c #(nop)  CMD [\"nginx\"]"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "\u003cmissing\u003e"
+                },
+                "size": {
+                    "type": "integer",
+                    "example": 1024
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "nginx:latest"
+                    ]
+                }
+            }
+        },
+        "models.ImageInfo": {
+            "type": "object",
+            "properties": {
+                "containers": {
+                    "description": "使用该镜像的容器名",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "/web",
+                        "/web-2"
+                    ]
+                },
+                "created": {
+                    "type": "integer",
+                    "example": 1678901234
+                },
+                "dangling": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "digests": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "nginx@sha256:0d17b565c37b"
+                    ]
+                },
+                "id": {
+                    "type": "string",
+                    "example": "sha256:3f8a4339aadd"
+                },
+                "size": {
+                    "type": "integer",
+                    "example": 187654321
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "nginx:latest"
+                    ]
+                }
+            }
+        },
+        "models.ImageListResponse": {
+            "type": "object",
+            "properties": {
+                "images": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ImageInfo"
+                    }
+                }
+            }
+        },
+        "models.ImagePruneResponse": {
+            "type": "object",
+            "properties": {
+                "dry_run": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "images": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ImageInfo"
+                    }
+                },
+                "space_reclaimed": {
+                    "type": "integer",
+                    "example": 52428800
+                }
+            }
+        },
+        "models.ImagePullProgress": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "type": "integer",
+                    "example": 5242880
+                },
+                "error": {
+                    "type": "string",
+                    "example": ""
+                },
+                "id": {
+                    "type": "string",
+                    "example": "a2abf6c4d29d"
+                },
+                "progress": {
+                    "type": "string",
+                    "example": "[=====\u003e      ]  5.243MB/10.49MB"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "Downloading"
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 10485760
+                }
+            }
+        },
+        "models.ImageRemoveRequest": {
+            "type": "object",
+            "properties": {
+                "force": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "id": {
+                    "type": "string",
+                    "example": "nginx:latest"
+                }
+            }
+        },
+        "models.ImageRemoveResponse": {
+            "type": "object",
+            "properties": {
+                "deleted": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "sha256:3f8a4339aadd"
+                    ]
+                },
+                "untagged": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "nginx:latest"
+                    ]
+                }
+            }
+        },
+        "models.ImageTagRequest": {
+            "type": "object",
+            "properties": {
+                "source": {
+                    "type": "string",
+                    "example": "nginx:latest"
+                },
+                "target": {
+                    "type": "string",
+                    "example": "registry.example.com/nginx:1.25"
+                }
+            }
+        },
+        "models.ListComposeResponse": {
+            "type": "object",
+            "properties": {
+                "apps": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "[\"app1\"",
+                        " \"app2\"]"
+                    ]
+                }
+            }
+        },
+        "models.ListFilesResponse": {
+            "type": "object",
             "properties": {
-                "names": {
+                "current": {
+                    "type": "string",
+                    "example": "/data"
+                },
+                "files": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.FileInfo"
+                    }
+                }
+            }
+        },
+        "models.MkdirRequest": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "new-folder"
+                },
+                "path": {
+                    "type": "string",
+                    "example": "/data"
+                }
+            }
+        },
+        "models.MountSpec": {
+            "type": "object",
+            "properties": {
+                "read_only": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "source": {
+                    "type": "string",
+                    "example": "/data/web"
+                },
+                "target": {
+                    "type": "string",
+                    "example": "/usr/share/nginx/html"
+                },
+                "tmpfs_size": {
+                    "description": "仅 tmpfs，支持 k/m/g",
+                    "type": "string",
+                    "example": "64m"
+                },
+                "type": {
+                    "description": "bind / volume / tmpfs",
+                    "type": "string",
+                    "example": "bind"
+                }
+            }
+        },
+        "models.MoveFileRequest": {
+            "type": "object",
+            "properties": {
+                "source_path": {
+                    "type": "string",
+                    "example": "/tmp/file-manager/a.txt"
+                },
+                "target_dir": {
+                    "type": "string",
+                    "example": "/tmp/file-manager/subfolder"
+                }
+            }
+        },
+        "models.NetworkAttachment": {
+            "type": "object",
+            "properties": {
+                "aliases": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "api"
+                    ]
+                },
+                "ipv4_address": {
+                    "type": "string",
+                    "example": "172.20.0.10"
+                },
+                "ipv6_address": {
+                    "type": "string",
+                    "example": ""
+                },
+                "name": {
+                    "type": "string",
+                    "example": "backend"
+                }
+            }
+        },
+        "models.NetworkConnectRequest": {
+            "type": "object",
+            "properties": {
+                "aliases": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "api"
+                    ]
+                },
+                "container": {
+                    "type": "string",
+                    "example": "web"
+                },
+                "ipv4_address": {
+                    "type": "string",
+                    "example": "172.20.0.10"
+                },
+                "ipv6_address": {
+                    "type": "string",
+                    "example": ""
+                },
+                "network": {
+                    "type": "string",
+                    "example": "backend"
+                }
+            }
+        },
+        "models.NetworkContainer": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "example": "a1b2c3d4e5f6"
+                },
+                "ipv4_address": {
+                    "type": "string",
+                    "example": "172.20.0.2/16"
+                },
+                "ipv6_address": {
+                    "type": "string",
+                    "example": ""
+                },
+                "mac_address": {
+                    "type": "string",
+                    "example": "02:42:ac:14:00:02"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "web"
+                }
+            }
+        },
+        "models.NetworkCreateRequest": {
+            "type": "object",
+            "properties": {
+                "attachable": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "driver": {
+                    "description": "bridge / overlay / macvlan / ipvlan，默认 bridge",
+                    "type": "string",
+                    "example": "bridge"
+                },
+                "enable_ipv6": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "gateway": {
+                    "type": "string",
+                    "example": "172.20.0.1"
+                },
+                "internal": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "ip_range": {
+                    "type": "string",
+                    "example": "172.20.10.0/24"
+                },
+                "labels": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string",
+                    "example": "backend"
+                },
+                "options": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "subnet": {
+                    "type": "string",
+                    "example": "172.20.0.0/16"
+                }
+            }
+        },
+        "models.NetworkCreateResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "id": {
+                    "type": "string",
+                    "example": "7d86d31b1478"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Network created"
+                }
+            }
+        },
+        "models.NetworkDetail": {
+            "type": "object",
+            "properties": {
+                "attachable": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "containers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.NetworkContainer"
+                    }
+                },
+                "created": {
+                    "type": "string",
+                    "example": "2025-03-22T12:34:56Z"
+                },
+                "driver": {
+                    "type": "string",
+                    "example": "bridge"
+                },
+                "enable_ipv6": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "id": {
+                    "type": "string",
+                    "example": "7d86d31b1478"
+                },
+                "internal": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "ipam": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.NetworkIPAM"
+                    }
+                },
+                "labels": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string",
+                    "example": "backend"
+                },
+                "options": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "predefined": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "scope": {
+                    "type": "string",
+                    "example": "local"
+                }
+            }
+        },
+        "models.NetworkDisconnectRequest": {
+            "type": "object",
+            "properties": {
+                "container": {
+                    "type": "string",
+                    "example": "web"
+                },
+                "force": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "network": {
+                    "type": "string",
+                    "example": "backend"
+                }
+            }
+        },
+        "models.NetworkIPAM": {
+            "type": "object",
+            "properties": {
+                "gateway": {
+                    "type": "string",
+                    "example": "172.20.0.1"
+                },
+                "ip_range": {
+                    "type": "string",
+                    "example": "172.20.10.0/24"
+                },
+                "subnet": {
+                    "type": "string",
+                    "example": "172.20.0.0/16"
+                }
+            }
+        },
+        "models.NetworkInfo": {
+            "type": "object",
+            "properties": {
+                "attachable": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "containers": {
+                    "description": "接入的容器数",
+                    "type": "integer",
+                    "example": 2
+                },
+                "created": {
+                    "type": "string",
+                    "example": "2025-03-22T12:34:56Z"
+                },
+                "driver": {
+                    "type": "string",
+                    "example": "bridge"
+                },
+                "gateways": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "172.20.0.1"
+                    ]
+                },
+                "id": {
+                    "type": "string",
+                    "example": "7d86d31b1478"
+                },
+                "internal": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "labels": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string",
+                    "example": "backend"
+                },
+                "predefined": {
+                    "description": "bridge / host / none，不能删除",
+                    "type": "boolean",
+                    "example": false
+                },
+                "scope": {
+                    "type": "string",
+                    "example": "local"
+                },
+                "subnets": {
                     "type": "array",
                     "items": {
                         "type": "string"
                     },
                     "example": [
-                        "[\"a.txt\"",
-                        " \"b.txt\"]"
+                        "172.20.0.0/16"
                     ]
-                },
-                "path": {
+                }
+            }
+        },
+        "models.NetworkListResponse": {
+            "type": "object",
+            "properties": {
+                "networks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.NetworkInfo"
+                    }
+                }
+            }
+        },
+        "models.NetworkRemoveRequest": {
+            "type": "object",
+            "properties": {
+                "id": {
                     "type": "string",
-                    "example": "/tmp/file-manager"
+                    "example": "backend"
                 }
             }
         },
-        "models.ChmodRequest": {
+        "models.PortSpec": {
             "type": "object",
             "properties": {
-                "mode": {
+                "container_port": {
                     "type": "string",
-                    "example": "755"
+                    "example": "80"
                 },
-                "path": {
+                "host_ip": {
                     "type": "string",
-                    "example": "/data/file.txt"
+                    "example": "127.0.0.1"
+                },
+                "host_port": {
+                    "type": "string",
+                    "example": "8080"
+                },
+                "protocol": {
+                    "description": "tcp / udp / sctp，默认 tcp",
+                    "type": "string",
+                    "example": "tcp"
                 }
             }
         },
-        "models.ComposeActionRequest": {
+        "models.RedeployContainerRequest": {
             "type": "object",
             "properties": {
-                "name": {
+                "force": {
+                    "description": "镜像未变化时也重建，默认跳过",
+                    "type": "boolean",
+                    "example": false
+                },
+                "health_timeout": {
+                    "description": "等待新容器 healthy 的秒数，默认 60",
+                    "type": "integer",
+                    "example": 60
+                },
+                "image": {
+                    "description": "为空时重新拉取容器当前使用的镜像标签",
                     "type": "string",
-                    "example": "my-app"
+                    "example": "nginx:1.27"
+                },
+                "keep_old": {
+                    "description": "保留被替换的容器（已停止并改名），默认删除",
+                    "type": "boolean",
+                    "example": false
+                },
+                "min_uptime": {
+                    "description": "未配置健康检查时新容器需持续运行的秒数，默认 5，须小于 health_timeout",
+                    "type": "integer",
+                    "example": 5
+                },
+                "pull": {
+                    "description": "先拉取镜像，默认 true；false 时使用本地镜像",
+                    "type": "boolean",
+                    "example": true
                 }
             }
         },
-        "models.ComposeContainerInfo": {
+        "models.RedeployContainerResponse": {
             "type": "object",
             "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "container": {
+                    "type": "string",
+                    "example": "web"
+                },
                 "id": {
+                    "description": "当前运行的容器 ID",
                     "type": "string",
-                    "example": "a1b2c3d4e5f6"
+                    "example": "b2c3d4e5f6a1"
                 },
                 "image": {
                     "type": "string",
-                    "example": "nginx:latest"
+                    "example": "nginx:1.27"
                 },
-                "name": {
+                "image_id": {
                     "type": "string",
-                    "example": "/app1-web"
+                    "example": "sha256:9f86d081884c"
                 },
-                "ports": {
+                "message": {
                     "type": "string",
-                    "example": "8080:80 443:443"
+                    "example": "Container redeployed"
                 },
-                "status": {
+                "old_container": {
+                    "description": "keep_old=true 时被保留的原容器名",
                     "type": "string",
-                    "example": "Up 3 minutes"
+                    "example": "web-replaced-a1b2c3d4e5f6"
+                },
+                "previous_image": {
+                    "type": "string",
+                    "example": "nginx:1.25"
+                },
+                "previous_image_id": {
+                    "type": "string",
+                    "example": "sha256:1a2b3c4d5e6f"
+                },
+                "updated": {
+                    "description": "false 表示镜像未变化，未重建",
+                    "type": "boolean",
+                    "example": true
                 }
             }
         },
-        "models.ComposeStatusResponse": {
+        "models.RegistryCredentialDeleteRequest": {
             "type": "object",
             "properties": {
-                "apps": {
-                    "type": "object",
-                    "additionalProperties": {
-                        "type": "array",
-                        "items": {
-                            "$ref": "#/definitions/models.ComposeContainerInfo"
-                        }
-                    }
+                "registry": {
+                    "type": "string",
+                    "example": "registry.example.com:5000"
                 }
             }
         },
-        "models.CompressRequest": {
+        "models.RegistryCredentialInfo": {
             "type": "object",
             "properties": {
-                "names": {
+                "has_password": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "has_token": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "registry": {
+                    "type": "string",
+                    "example": "registry.example.com:5000"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2025-03-22T12:34:56Z"
+                },
+                "username": {
+                    "type": "string",
+                    "example": "deploy"
+                }
+            }
+        },
+        "models.RegistryCredentialListResponse": {
+            "type": "object",
+            "properties": {
+                "credentials": {
                     "type": "array",
                     "items": {
-                        "type": "string"
-                    },
-                    "example": [
-                        "[\"file1.txt\"",
-                        " \"folder\"]"
-                    ]
+                        "$ref": "#/definitions/models.RegistryCredentialInfo"
+                    }
+                }
+            }
+        },
+        "models.RegistryCredentialRequest": {
+            "type": "object",
+            "properties": {
+                "password": {
+                    "type": "string",
+                    "example": "s3cret"
+                },
+                "registry": {
+                    "type": "string",
+                    "example": "registry.example.com:5000"
+                },
+                "token": {
+                    "type": "string",
+                    "example": ""
+                },
+                "username": {
+                    "type": "string",
+                    "example": "deploy"
+                }
+            }
+        },
+        "models.RenameContainerRequest": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "my-container-v2"
+                }
+            }
+        },
+        "models.RenameRequest": {
+            "type": "object",
+            "properties": {
+                "new_name": {
+                    "type": "string",
+                    "example": "file-renamed.txt"
+                },
+                "old_name": {
+                    "type": "string",
+                    "example": "file.txt"
                 },
                 "path": {
                     "type": "string",
                     "example": "/data"
+                }
+            }
+        },
+        "models.ResourceSpec": {
+            "type": "object",
+            "properties": {
+                "cpus": {
+                    "type": "string",
+                    "example": "0.5"
                 },
-                "type": {
-                    "description": "可选 zip/tar.gz",
+                "memory": {
                     "type": "string",
-                    "example": "zip"
+                    "example": "512m"
+                },
+                "memory_reservation": {
+                    "type": "string",
+                    "example": "256m"
+                },
+                "pids_limit": {
+                    "type": "integer",
+                    "example": 200
                 }
             }
         },
-        "models.ContainerInfo": {
+        "models.RestartPolicySpec": {
             "type": "object",
             "properties": {
-                "created": {
+                "max_retries": {
+                    "description": "仅 on-failure",
                     "type": "integer",
-                    "example": 1678901234
+                    "example": 3
+                },
+                "name": {
+                    "description": "no / always / unless-stopped / on-failure",
+                    "type": "string",
+                    "example": "on-failure"
+                }
+            }
+        },
+        "models.RunAnsibleRequest": {
+            "type": "object",
+            "properties": {
+                "inventory": {
+                    "type": "string",
+                    "example": "/etc/ansible/hosts"
+                },
+                "playbook": {
+                    "type": "string",
+                    "example": "site.yml"
+                }
+            }
+        },
+        "models.SaveFileRequest": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "type": "string",
+                    "example": "新的文件内容"
+                },
+                "path": {
+                    "type": "string",
+                    "example": "/data/file.txt"
+                }
+            }
+        },
+        "models.SnapshotCreateRequest": {
+            "type": "object",
+            "properties": {
+                "container": {
+                    "type": "string",
+                    "example": "web"
+                },
+                "note": {
+                    "type": "string",
+                    "example": "before editing nginx.conf"
+                },
+                "pause": {
+                    "description": "commit 期间暂停容器以保证文件一致，默认 true",
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "models.SnapshotCreateResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Snapshot created"
                 },
+                "snapshot": {
+                    "$ref": "#/definitions/models.SnapshotInfo"
+                }
+            }
+        },
+        "models.SnapshotDeleteRequest": {
+            "type": "object",
+            "properties": {
                 "id": {
+                    "type": "string",
+                    "example": "web-20250322-123456"
+                },
+                "remove_image": {
+                    "description": "同时删除快照镜像",
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "models.SnapshotInfo": {
+            "type": "object",
+            "properties": {
+                "container": {
+                    "type": "string",
+                    "example": "web"
+                },
+                "container_id": {
                     "type": "string",
                     "example": "a1b2c3d4e5f6"
                 },
+                "created": {
+                    "type": "string",
+                    "example": "2025-03-22T12:34:56Z"
+                },
+                "host": {
+                    "type": "string",
+                    "example": "local"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "web-20250322-123456"
+                },
                 "image": {
                     "type": "string",
-                    "example": "nginx:latest"
+                    "example": "adp-snapshot/web:20250322-123456"
                 },
-                "name": {
+                "image_exists": {
+                    "description": "快照镜像被删除后无法回滚",
+                    "type": "boolean",
+                    "example": true
+                },
+                "image_id": {
                     "type": "string",
-                    "example": "/my-container"
+                    "example": "sha256:9f86d081884c"
                 },
-                "status": {
+                "networks": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "bridge"
+                    ]
+                },
+                "note": {
                     "type": "string",
-                    "example": "Up 2 hours"
+                    "example": "before editing nginx.conf"
+                },
+                "source_image": {
+                    "description": "快照时容器使用的镜像",
+                    "type": "string",
+                    "example": "nginx:latest"
                 }
             }
         },
-        "models.ContainerListResponse": {
+        "models.SnapshotListResponse": {
             "type": "object",
             "properties": {
-                "containers": {
+                "snapshots": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.ContainerInfo"
+                        "$ref": "#/definitions/models.SnapshotInfo"
                     }
                 }
             }
         },
-        "models.CreateContainerRequest": {
+        "models.SnapshotRollbackRequest": {
             "type": "object",
             "properties": {
-                "cpu": {
-                    "description": "单位核",
-                    "type": "string",
-                    "example": "0.5"
-                },
-                "envs": {
-                    "type": "string",
-                    "example": "ENV_VAR1=value1,ENV_VAR2=value2"
-                },
-                "image": {
-                    "type": "string",
-                    "example": "nginx:latest"
-                },
-                "memory": {
-                    "description": "单位 m/g",
-                    "type": "string",
-                    "example": "512m"
-                },
-                "name": {
-                    "type": "string",
-                    "example": "my-container"
-                },
-                "network": {
-                    "description": "host/bridge",
-                    "type": "string",
-                    "example": "bridge"
-                },
-                "ports": {
-                    "description": "hostPort:containerPort",
-                    "type": "string",
-                    "example": "8080:80,8443:443"
-                },
-                "restart": {
-                    "description": "Restart 策略",
+                "id": {
                     "type": "string",
-                    "example": "always"
+                    "example": "web-20250322-123456"
                 },
-                "volumes": {
-                    "type": "string",
-                    "example": "/host/path:/container/path"
+                "keep_old": {
+                    "description": "保留被替换的容器（已停止并改名），默认删除",
+                    "type": "boolean",
+                    "example": false
                 }
             }
         },
-        "models.CreateContainerResponse": {
+        "models.SnapshotRollbackResponse": {
             "type": "object",
             "properties": {
                 "code": {
                     "type": "integer",
                     "example": 200
                 },
+                "container": {
+                    "type": "string",
+                    "example": "web"
+                },
                 "id": {
+                    "description": "重建后的容器 ID",
                     "type": "string",
-                    "example": "a1b2c3d4e5f6"
+                    "example": "b2c3d4e5f6a1"
                 },
                 "message": {
                     "type": "string",
-                    "example": "Container created"
+                    "example": "Container rolled back"
+                },
+                "old_container": {
+                    "description": "keep_old=true 时被保留的原容器名",
+                    "type": "string",
+                    "example": "web-replaced-a1b2c3d4e5f6"
                 }
             }
         },
-        "models.ErrorResponse": {
+        "models.SuccessResponse": {
             "type": "object",
             "properties": {
                 "code": {
                     "type": "integer",
-                    "example": 500
+                    "example": 200
                 },
                 "message": {
                     "type": "string",
-                    "example": "Internal Server Error"
+                    "example": "Container stopped successfully"
                 }
             }
         },
-        "models.FileConfigResponse": {
+        "models.TemplateInstantiateRequest": {
             "type": "object",
             "properties": {
-                "allowAll": {
-                    "type": "boolean"
+                "dry_run": {
+                    "type": "boolean",
+                    "example": false
                 },
-                "apiBaseUrl": {
-                    "type": "string"
+                "name": {
+                    "type": "string",
+                    "example": "web"
                 },
-                "baseDir": {
-                    "type": "string"
+                "params": {
+                    "description": "参数值，未提供时使用默认值",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "version": {
+                    "description": "0 表示最新版本",
+                    "type": "integer",
+                    "example": 0
                 }
             }
         },
-        "models.FileContentResponse": {
+        "models.TemplateInstantiateResponse": {
             "type": "object",
             "properties": {
-                "content": {
-                    "type": "string"
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "id": {
+                    "type": "string",
+                    "example": "a1b2c3d4e5f6"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Container created"
+                },
+                "payload": {
+                    "type": "object"
+                },
+                "template": {
+                    "type": "string",
+                    "example": "web"
+                },
+                "version": {
+                    "type": "integer",
+                    "example": 3
                 }
             }
         },
-        "models.FileDeleteRequest": {
+        "models.TemplateParameter": {
             "type": "object",
             "properties": {
-                "name": {
+                "default": {
+                    "description": "未设置或为 null 时参数必填",
                     "type": "string",
-                    "example": "file.txt"
+                    "example": "latest"
                 },
-                "path": {
+                "description": {
                     "type": "string",
-                    "example": "/data"
+                    "example": "镜像标签"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "TAG"
+                },
+                "required": {
+                    "description": "只读，由是否设置了 default 决定",
+                    "type": "boolean",
+                    "example": false
                 }
             }
         },
-        "models.FileInfo": {
+        "models.UpdateContainerRequest": {
             "type": "object",
             "properties": {
-                "is_dir": {
-                    "type": "boolean",
-                    "example": false
+                "cpus": {
+                    "type": "string",
+                    "example": "1.5"
                 },
-                "mod_time": {
+                "memory": {
                     "type": "string",
-                    "example": "2025-03-22 12:34:56"
+                    "example": "1g"
                 },
-                "mode": {
+                "memory_reservation": {
                     "type": "string",
-                    "example": "rw-r--r--"
+                    "example": "512m"
                 },
-                "name": {
+                "memory_swap": {
+                    "description": "内存 + swap 总量，-1 表示不限制 swap",
                     "type": "string",
-                    "example": "file.txt"
+                    "example": "2g"
                 },
-                "size": {
+                "pids_limit": {
+                    "description": "0 或 -1 表示不限制",
                     "type": "integer",
-                    "example": 1024
+                    "example": 200
+                },
+                "restart_policy": {
+                    "$ref": "#/definitions/models.RestartPolicySpec"
                 }
             }
         },
-        "models.ListComposeResponse": {
+        "models.UpdateContainerResponse": {
             "type": "object",
             "properties": {
-                "apps": {
+                "code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Container updated"
+                },
+                "resources": {
+                    "$ref": "#/definitions/models.ContainerResources"
+                },
+                "restart_policy": {
+                    "$ref": "#/definitions/models.ContainerRestartPolicy"
+                },
+                "warnings": {
                     "type": "array",
                     "items": {
                         "type": "string"
-                    },
-                    "example": [
-                        "[\"app1\"",
-                        " \"app2\"]"
-                    ]
+                    }
                 }
             }
         },
-        "models.ListFilesResponse": {
+        "models.ValidationErrorResponse": {
             "type": "object",
             "properties": {
-                "current": {
+                "error": {
                     "type": "string",
-                    "example": "/data"
+                    "example": "Invalid request"
                 },
-                "files": {
+                "fields": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.FileInfo"
+                        "$ref": "#/definitions/models.FieldError"
                     }
                 }
             }
         },
-        "models.MkdirRequest": {
+        "models.VolumeContainer": {
             "type": "object",
             "properties": {
+                "destination": {
+                    "type": "string",
+                    "example": "/usr/share/nginx/html"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "a1b2c3d4e5f6"
+                },
                 "name": {
                     "type": "string",
-                    "example": "new-folder"
+                    "example": "/web"
                 },
-                "path": {
+                "read_only": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "state": {
                     "type": "string",
-                    "example": "/data"
+                    "example": "running"
                 }
             }
         },
-        "models.MoveFileRequest": {
+        "models.VolumeCreateRequest": {
             "type": "object",
             "properties": {
-                "source_path": {
+                "driver": {
+                    "description": "默认 local",
                     "type": "string",
-                    "example": "/tmp/file-manager/a.txt"
+                    "example": "local"
                 },
-                "target_dir": {
+                "driver_opts": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "labels": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "name": {
                     "type": "string",
-                    "example": "/tmp/file-manager/subfolder"
+                    "example": "web-data"
                 }
             }
         },
-        "models.RenameRequest": {
+        "models.VolumeCreateResponse": {
             "type": "object",
             "properties": {
-                "new_name": {
-                    "type": "string",
-                    "example": "file-renamed.txt"
+                "code": {
+                    "type": "integer",
+                    "example": 200
                 },
-                "old_name": {
+                "message": {
                     "type": "string",
-                    "example": "file.txt"
+                    "example": "Volume created"
                 },
-                "path": {
+                "name": {
                     "type": "string",
-                    "example": "/data"
+                    "example": "web-data"
                 }
             }
         },
-        "models.RunAnsibleRequest": {
+        "models.VolumeInfo": {
             "type": "object",
             "properties": {
-                "inventory": {
+                "compose_projects": {
+                    "description": "来自数据卷及容器的 com.docker.compose.project 标签",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "blog"
+                    ]
+                },
+                "containers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.VolumeContainer"
+                    }
+                },
+                "created": {
                     "type": "string",
-                    "example": "/etc/ansible/hosts"
+                    "example": "2025-03-22T12:34:56Z"
                 },
-                "playbook": {
+                "driver": {
                     "type": "string",
-                    "example": "site.yml"
+                    "example": "local"
+                },
+                "in_use": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "labels": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "mountpoint": {
+                    "type": "string",
+                    "example": "/var/lib/docker/volumes/web-data/_data"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "web-data"
+                },
+                "options": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "scope": {
+                    "type": "string",
+                    "example": "local"
+                },
+                "size": {
+                    "description": "占用磁盘字节数，-1 表示驱动不支持统计",
+                    "type": "integer",
+                    "example": 52428800
                 }
             }
         },
-        "models.SaveFileRequest": {
+        "models.VolumeListResponse": {
             "type": "object",
             "properties": {
-                "content": {
-                    "type": "string",
-                    "example": "新的文件内容"
+                "total_size": {
+                    "type": "integer",
+                    "example": 104857600
                 },
-                "path": {
-                    "type": "string",
-                    "example": "/data/file.txt"
+                "volumes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.VolumeInfo"
+                    }
                 }
             }
         },
-        "models.SuccessResponse": {
+        "models.VolumePruneResponse": {
             "type": "object",
             "properties": {
-                "code": {
+                "dry_run": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "space_reclaimed": {
                     "type": "integer",
-                    "example": 200
+                    "example": 52428800
                 },
-                "message": {
+                "volumes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.VolumeInfo"
+                    }
+                }
+            }
+        },
+        "models.VolumeRemoveRequest": {
+            "type": "object",
+            "properties": {
+                "force": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "name": {
                     "type": "string",
-                    "example": "Container stopped successfully"
+                    "example": "web-data"
                 }
             }
         }
@@ -0,0 +1,57 @@
+package models
+
+// AutoUpdateStatusResponse 自动更新配置和最近的检查报告
+type AutoUpdateStatusResponse struct {
+	Enabled  bool                      `json:"enabled" example:"true"`
+	Interval string                    `json:"interval" example:"1h0m0s"`
+	Window   string                    `json:"window" example:"02:00-05:00"` // 维护窗口，为空不限
+	Label    string                    `json:"label" example:"adp.autoupdate=true"`
+	Running  bool                      `json:"running" example:"false"`
+	NextRun  string                    `json:"next_run,omitempty" example:"2025-03-23T02:00:00+08:00"`
+	Reports  []AutoUpdateReportSummary `json:"reports"` // 最新的在前
+}
+
+// AutoUpdateReportSummary 一次检查的概要
+type AutoUpdateReportSummary struct {
+	ID         string `json:"id" example:"20250323-020000"`
+	Trigger    string `json:"trigger" example:"schedule"` // schedule / manual
+	DryRun     bool   `json:"dry_run" example:"false"`
+	StartedAt  string `json:"started_at" example:"2025-03-23T02:00:00+08:00"`
+	FinishedAt string `json:"finished_at" example:"2025-03-23T02:01:12+08:00"`
+	Checked    int    `json:"checked" example:"5"`
+	Available  int    `json:"available" example:"0"`
+	Updated    int    `json:"updated" example:"2"`
+	Failed     int    `json:"failed" example:"1"`
+	Skipped    int    `json:"skipped" example:"0"`
+}
+
+// AutoUpdateReport 一次检查的完整报告
+type AutoUpdateReport struct {
+	AutoUpdateReportSummary
+	Results []AutoUpdateResult `json:"results"`
+}
+
+// AutoUpdateResult 单个容器的检查结果
+type AutoUpdateResult struct {
+	Host           string `json:"host" example:"local"`
+	Container      string `json:"container" example:"web"`
+	ContainerID    string `json:"container_id" example:"a1b2c3d4e5f6"`
+	Image          string `json:"image" example:"nginx:1.27"`
+	LocalDigest    string `json:"local_digest,omitempty" example:"sha256:1a2b3c4d"`
+	RemoteDigest   string `json:"remote_digest,omitempty" example:"sha256:5e6f7a8b"`
+	Status         string `json:"status" example:"updated"` // up_to_date / update_available / updated / failed / skipped
+	Error          string `json:"error,omitempty" example:"container 9f86d081884c is unhealthy"`
+	NewContainerID string `json:"new_container_id,omitempty" example:"b2c3d4e5f6a1"`
+}
+
+// AutoUpdateRunRequest 立即执行一次检查
+type AutoUpdateRunRequest struct {
+	DryRun bool `json:"dry_run" example:"true"` // 只检查不重建
+}
+
+// AutoUpdateRunResponse 立即检查的结果
+type AutoUpdateRunResponse struct {
+	Code    int              `json:"code" example:"200"`
+	Message string           `json:"message" example:"Auto update finished"`
+	Report  AutoUpdateReport `json:"report"`
+}
@@ -0,0 +1,65 @@
+package models
+
+// BackupInfo 数据卷备份信息
+type BackupInfo struct {
+	ID      string        `json:"id" example:"volume-web-data-20250322-123456"`
+	Kind    string        `json:"kind" example:"volume"`     // volume / container
+	Source  string        `json:"source" example:"web-data"` // 数据卷名或容器名
+	Host    string        `json:"host" example:"local"`
+	Mounts  []BackupMount `json:"mounts"` // kind=container 时包含的挂载
+	File    string        `json:"file" example:"volume-web-data-20250322-123456.tar.gz"`
+	Size    int64         `json:"size" example:"1048576"`
+	SHA256  string        `json:"sha256" example:"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"`
+	Note    string        `json:"note" example:"before upgrade"`
+	Created string        `json:"created" example:"2025-03-22T12:34:56Z"`
+}
+
+// BackupMount 容器备份中的一个挂载
+type BackupMount struct {
+	Type        string `json:"type" example:"volume"`
+	Source      string `json:"source" example:"web-data"`
+	Destination string `json:"destination" example:"/usr/share/nginx/html"`
+}
+
+// BackupListResponse 备份列表响应
+type BackupListResponse struct {
+	Backups []BackupInfo `json:"backups"`
+}
+
+// BackupCreateRequest 创建备份请求，volume 与 container 二选一
+type BackupCreateRequest struct {
+	Volume        string `json:"volume" example:"web-data"`
+	Container     string `json:"container" example:""`
+	StopContainer bool   `json:"stop_container" example:"true"` // 备份期间停止使用这些数据的容器，完成后重新启动
+	Note          string `json:"note" example:"before upgrade"`
+}
+
+// BackupCreateResponse 创建备份响应
+type BackupCreateResponse struct {
+	Code    int        `json:"code" example:"200"`
+	Message string     `json:"message" example:"Backup created"`
+	Backup  BackupInfo `json:"backup"`
+	Stopped []string   `json:"stopped" example:"/web"` // 备份期间被停止并已重新启动的容器
+}
+
+// BackupRestoreRequest 恢复备份请求；数据卷备份可恢复到同名或新数据卷，容器备份恢复到原容器或同样挂载的其他容器
+type BackupRestoreRequest struct {
+	ID            string `json:"id" example:"volume-web-data-20250322-123456"`
+	Volume        string `json:"volume" example:"web-data-restored"` // 目标数据卷，默认为备份来源，不存在时自动创建
+	Container     string `json:"container" example:""`               // 目标容器，默认为备份来源
+	StopContainer bool   `json:"stop_container" example:"true"`
+	Clean         bool   `json:"clean" example:"true"` // 恢复前清空目标，否则只覆盖同名文件
+}
+
+// BackupRestoreResponse 恢复备份响应
+type BackupRestoreResponse struct {
+	Code    int      `json:"code" example:"200"`
+	Message string   `json:"message" example:"Backup restored"`
+	Target  string   `json:"target" example:"web-data-restored"`
+	Stopped []string `json:"stopped" example:"/web"`
+}
+
+// BackupDeleteRequest 删除备份请求
+type BackupDeleteRequest struct {
+	ID string `json:"id" example:"volume-web-data-20250322-123456"`
+}
@@ -0,0 +1,61 @@
+package models
+
+// BlueGreenDeployRequest 蓝绿部署新版本
+type BlueGreenDeployRequest struct {
+	App           string `json:"app" example:"web"` // 应用名，首次部署时为已有容器的名称
+	Image         string `json:"image" example:"nginx:1.27"`
+	Pull          *bool  `json:"pull" example:"true"`         // 是否先拉取镜像，默认 true
+	HealthTimeout int    `json:"health_timeout" example:"60"` // 等待新容器就绪的最长秒数，默认 60，最大 600
+	MinUptime     int    `json:"min_uptime" example:"5"`      // 无健康检查时需持续运行的秒数，默认 5，须小于 health_timeout
+}
+
+// BlueGreenRollbackRequest 切回另一个颜色
+type BlueGreenRollbackRequest struct {
+	App           string `json:"app" example:"web"`
+	HealthTimeout int    `json:"health_timeout" example:"60"`
+	MinUptime     int    `json:"min_uptime" example:"5"`
+}
+
+// BlueGreenApp 应用的蓝绿部署状态
+type BlueGreenApp struct {
+	App       string           `json:"app" example:"web"`
+	Host      string           `json:"host" example:"local"`
+	Live      string           `json:"live" example:"green"` // 当前在线的颜色
+	Slots     []BlueGreenSlot  `json:"slots"`                // blue、green 各一项，未部署过的颜色不返回
+	History   []BlueGreenEvent `json:"history"`              // 最新的在前
+	UpdatedAt string           `json:"updated_at" example:"2025-03-22T12:34:56Z"`
+}
+
+// BlueGreenSlot 一个颜色对应的容器
+type BlueGreenSlot struct {
+	Color       string `json:"color" example:"green"`
+	Live        bool   `json:"live" example:"true"`
+	Container   string `json:"container" example:"web-green"`
+	ContainerID string `json:"container_id" example:"a1b2c3d4e5f6"`
+	Image       string `json:"image" example:"nginx:1.27"`
+	ImageID     string `json:"image_id" example:"sha256:3b25b682ea82"`
+	State       string `json:"state" example:"running"` // 容器当前状态，已被删除时为 missing
+	DeployedAt  string `json:"deployed_at" example:"2025-03-22T12:34:56Z"`
+}
+
+// BlueGreenEvent 一次部署或回滚
+type BlueGreenEvent struct {
+	Action string `json:"action" example:"deploy"` // deploy / rollback
+	From   string `json:"from" example:"blue"`
+	To     string `json:"to" example:"green"`
+	Image  string `json:"image" example:"nginx:1.27"`
+	At     string `json:"at" example:"2025-03-22T12:34:56Z"`
+}
+
+// BlueGreenListResponse 蓝绿部署应用列表
+type BlueGreenListResponse struct {
+	Apps []BlueGreenApp `json:"apps"`
+}
+
+// BlueGreenResponse 部署或回滚成功
+type BlueGreenResponse struct {
+	Code           int          `json:"code" example:"200"`
+	Message        string       `json:"message" example:"Switched to green"`
+	App            BlueGreenApp `json:"app"`
+	TemporaryPorts []string     `json:"temporary_ports,omitempty" example:"0.0.0.0:49153->80/tcp"` // 新版本健康检查期间使用的临时端口
+}
@@ -2,22 +2,300 @@ package models
 
 // ContainerInfo 容器信息
 type ContainerInfo struct {
-	ID      string `json:"id" example:"a1b2c3d4e5f6"`
-	Name    string `json:"name" example:"/my-container"`
-	Status  string `json:"status" example:"Up 2 hours"`
-	Image   string `json:"image" example:"nginx:latest"`
-	Created int64  `json:"created" example:"1678901234"`
+	ID             string                 `json:"id" example:"a1b2c3d4e5f6"`
+	Name           string                 `json:"name" example:"/my-container"`
+	Status         string                 `json:"status" example:"Up 2 hours (healthy)"`
+	State          string                 `json:"state" example:"running"`  // created / running / paused / restarting / exited / dead
+	Health         string                 `json:"health" example:"healthy"` // healthy / unhealthy / starting，未配置健康检查时为空
+	Image          string                 `json:"image" example:"nginx:latest"`
+	ImageID        string                 `json:"image_id" example:"sha256:9f86d081884c"`
+	Command        string                 `json:"command" example:"/docker-entrypoint.sh nginx -g 'daemon off;'"`
+	Created        int64                  `json:"created" example:"1678901234"`
+	Ports          []ContainerPortBinding `json:"ports"`
+	Labels         map[string]string      `json:"labels"`
+	Networks       []ContainerNetwork     `json:"networks"`
+	ComposeProject string                 `json:"compose_project" example:"blog"`
+	SizeRw         *int64                 `json:"size_rw,omitempty" example:"12288"`        // size=true 时返回，容器可写层大小
+	SizeRootFs     *int64                 `json:"size_root_fs,omitempty" example:"1429960"` // size=true 时返回，含镜像的总大小
 }
 
-// CreateContainerRequest 创建容器请求
+// CreateContainerRequest 创建容器请求（v1，逗号分隔字符串），新接口请使用 CreateContainerV2Request
 type CreateContainerRequest struct {
 	Name    string `json:"name" example:"my-container"`
 	Image   string `json:"image" example:"nginx:latest"`
-	Ports   string `json:"ports" example:"8080:80,8443:443"` // hostPort:containerPort
-	Volumes string `json:"volumes" example:"/host/path:/container/path"`
+	Ports   string `json:"ports" example:"8080:80,8443:443"`             // hostPort:containerPort[/protocol]
+	Volumes string `json:"volumes" example:"/host/path:/container/path"` // source:target[:ro]，source 非绝对路径时为数据卷
 	Envs    string `json:"envs" example:"ENV_VAR1=value1,ENV_VAR2=value2"`
 	CPU     string `json:"cpu" example:"0.5"`        // 单位核
 	Memory  string `json:"memory" example:"512m"`    // 单位 m/g
 	Restart string `json:"restart" example:"always"` // Restart 策略
 	Network string `json:"network" example:"bridge"` // host/bridge
+
+	Networks []NetworkAttachment `json:"networks"` // 接入多个网络并设置别名 / 静态 IP 时使用
+
+	Wait            bool `json:"wait" example:"false"`              // 等待容器就绪后再返回，见 CreateContainerV2Request
+	HealthTimeout   int  `json:"health_timeout" example:"60"`       // wait 时等待就绪的秒数
+	MinUptime       int  `json:"min_uptime" example:"5"`            // wait 且无健康检查时需持续运行的秒数
+	RemoveOnFailure bool `json:"remove_on_failure" example:"false"` // 启动失败或未就绪时删除容器
+}
+
+// CreateContainerV2Request 结构化的创建容器请求 (/api/v2/container/create)
+type CreateContainerV2Request struct {
+	Name          string              `json:"name" example:"web"`
+	Image         string              `json:"image" example:"nginx:1.25"`
+	Command       []string            `json:"command" example:"nginx,-g,daemon off;"`
+	Entrypoint    []string            `json:"entrypoint" example:"/docker-entrypoint.sh"`
+	WorkingDir    string              `json:"working_dir" example:"/app"`
+	User          string              `json:"user" example:"1000:1000"`
+	Hostname      string              `json:"hostname" example:"web"`
+	Env           []EnvVar            `json:"env"`
+	Labels        map[string]string   `json:"labels"`
+	Ports         []PortSpec          `json:"ports"`
+	Mounts        []MountSpec         `json:"mounts"`
+	Resources     ResourceSpec        `json:"resources"`
+	RestartPolicy RestartPolicySpec   `json:"restart_policy"`
+	Network       string              `json:"network" example:"bridge"` // 网络模式：bridge / host / none 或网络名，多个网络时使用 networks
+	Networks      []NetworkAttachment `json:"networks"`
+	CapAdd        []string            `json:"cap_add" example:"NET_ADMIN"`
+	CapDrop       []string            `json:"cap_drop" example:"ALL"`
+	Privileged    bool                `json:"privileged" example:"false"`
+	Healthcheck   *HealthcheckSpec    `json:"healthcheck"`
+
+	// 健康门控：wait=true 时启动后等待容器运行，配置了健康检查（含镜像自带的 HEALTHCHECK）时等到 healthy
+	Wait            bool `json:"wait" example:"true"`
+	HealthTimeout   int  `json:"health_timeout" example:"60"`      // 等待就绪的最长秒数，默认 60，最大 600
+	MinUptime       int  `json:"min_uptime" example:"5"`           // 无健康检查时需持续运行的秒数，默认 5，须小于 health_timeout
+	RemoveOnFailure bool `json:"remove_on_failure" example:"true"` // 启动失败或未就绪时删除容器
+}
+
+// NetworkAttachment 容器接入的网络，别名和静态 IP 只能用于自定义网络
+type NetworkAttachment struct {
+	Name        string   `json:"name" example:"backend"`
+	Aliases     []string `json:"aliases" example:"api"`
+	IPv4Address string   `json:"ipv4_address" example:"172.20.0.10"`
+	IPv6Address string   `json:"ipv6_address" example:""`
+}
+
+// EnvVar 环境变量
+type EnvVar struct {
+	Name  string `json:"name" example:"DB_HOST"`
+	Value string `json:"value" example:"db"`
+}
+
+// PortSpec 端口映射，端口可写为范围 8000-8010；host_port 为空时由 Docker 随机分配
+type PortSpec struct {
+	HostIP        string `json:"host_ip" example:"127.0.0.1"`
+	HostPort      string `json:"host_port" example:"8080"`
+	ContainerPort string `json:"container_port" example:"80"`
+	Protocol      string `json:"protocol" example:"tcp"` // tcp / udp / sctp，默认 tcp
+}
+
+// MountSpec 挂载：bind 挂载宿主机路径，volume 挂载数据卷（source 为空时为匿名卷），tmpfs 挂载内存文件系统
+type MountSpec struct {
+	Type      string `json:"type" example:"bind"` // bind / volume / tmpfs
+	Source    string `json:"source" example:"/data/web"`
+	Target    string `json:"target" example:"/usr/share/nginx/html"`
+	ReadOnly  bool   `json:"read_only" example:"true"`
+	TmpfsSize string `json:"tmpfs_size" example:"64m"` // 仅 tmpfs，支持 k/m/g
+}
+
+// ResourceSpec 资源限制，内存支持 b/k/m/g 后缀，无后缀按 MB 计
+type ResourceSpec struct {
+	CPUs              string `json:"cpus" example:"0.5"`
+	Memory            string `json:"memory" example:"512m"`
+	MemoryReservation string `json:"memory_reservation" example:"256m"`
+	PidsLimit         int64  `json:"pids_limit" example:"200"`
+}
+
+// RestartPolicySpec 重启策略
+type RestartPolicySpec struct {
+	Name              string `json:"name" example:"on-failure"` // no / always / unless-stopped / on-failure
+	MaximumRetryCount int    `json:"max_retries" example:"3"`   // 仅 on-failure
+}
+
+// HealthcheckSpec 健康检查，test 形如 ["CMD-SHELL", "curl -f http://localhost/"]，["NONE"] 表示禁用镜像自带的检查
+type HealthcheckSpec struct {
+	Test        []string `json:"test" example:"CMD-SHELL,curl -f http://localhost/ || exit 1"`
+	Interval    string   `json:"interval" example:"30s"`
+	Timeout     string   `json:"timeout" example:"5s"`
+	Retries     int      `json:"retries" example:"3"`
+	StartPeriod string   `json:"start_period" example:"10s"`
+}
+
+// FieldError 单个字段的校验错误
+type FieldError struct {
+	Field   string `json:"field" example:"ports[0].container_port"`
+	Message string `json:"message" example:"invalid port \"8o\""`
+}
+
+// ValidationErrorResponse 请求校验失败响应，列出所有不合法的字段
+type ValidationErrorResponse struct {
+	Error  string       `json:"error" example:"Invalid request"`
+	Fields []FieldError `json:"fields"`
+}
+
+// ContainerDetail 容器详情
+type ContainerDetail struct {
+	ID            string                 `json:"id" example:"a1b2c3d4e5f6"`
+	Name          string                 `json:"name" example:"/my-container"`
+	Image         string                 `json:"image" example:"nginx:latest"`
+	ImageID       string                 `json:"image_id" example:"sha256:3f8a4339aadd"`
+	Created       string                 `json:"created" example:"2025-03-22T12:34:56.789Z"`
+	State         ContainerState         `json:"state"`
+	RestartCount  int                    `json:"restart_count" example:"0"`
+	Entrypoint    []string               `json:"entrypoint" example:"/docker-entrypoint.sh"`
+	Command       []string               `json:"command" example:"nginx,-g,daemon off;"`
+	WorkingDir    string                 `json:"working_dir" example:"/app"`
+	User          string                 `json:"user" example:"1000:1000"`
+	Env           []string               `json:"env" example:"DB_HOST=db,DB_PASSWORD=******"`
+	EnvMasked     bool                   `json:"env_masked" example:"true"`
+	Labels        map[string]string      `json:"labels"`
+	Mounts        []ContainerMount       `json:"mounts"`
+	Networks      []ContainerNetwork     `json:"networks"`
+	Ports         []ContainerPortBinding `json:"ports"`
+	RestartPolicy ContainerRestartPolicy `json:"restart_policy"`
+	Resources     ContainerResources     `json:"resources"`
+}
+
+// ContainerState 容器运行状态
+type ContainerState struct {
+	Status     string           `json:"status" example:"running"`
+	Running    bool             `json:"running" example:"true"`
+	Paused     bool             `json:"paused" example:"false"`
+	Restarting bool             `json:"restarting" example:"false"`
+	OOMKilled  bool             `json:"oom_killed" example:"false"`
+	ExitCode   int              `json:"exit_code" example:"0"`
+	Error      string           `json:"error" example:""`
+	StartedAt  string           `json:"started_at" example:"2025-03-22T12:34:57.123Z"`
+	FinishedAt string           `json:"finished_at" example:"0001-01-01T00:00:00Z"`
+	Health     *ContainerHealth `json:"health,omitempty"`
+}
+
+// ContainerHealth 健康检查状态
+type ContainerHealth struct {
+	Status        string `json:"status" example:"healthy"`
+	FailingStreak int    `json:"failing_streak" example:"0"`
+	LastOutput    string `json:"last_output" example:"OK"`
+	LastExitCode  int    `json:"last_exit_code" example:"0"`
+}
+
+// ContainerMount 挂载信息
+type ContainerMount struct {
+	Type        string `json:"type" example:"bind"`
+	Name        string `json:"name,omitempty" example:"my-volume"`
+	Source      string `json:"source" example:"/host/path"`
+	Destination string `json:"destination" example:"/container/path"`
+	Mode        string `json:"mode" example:"ro"`
+	RW          bool   `json:"rw" example:"false"`
+}
+
+// ContainerNetwork 容器所在网络及地址
+type ContainerNetwork struct {
+	Name       string   `json:"name" example:"bridge"`
+	IPAddress  string   `json:"ip_address" example:"172.17.0.2"`
+	Gateway    string   `json:"gateway" example:"172.17.0.1"`
+	MacAddress string   `json:"mac_address" example:"02:42:ac:11:00:02"`
+	Aliases    []string `json:"aliases" example:"web"`
+}
+
+// ContainerPortBinding 端口映射
+type ContainerPortBinding struct {
+	ContainerPort string `json:"container_port" example:"80"`
+	Protocol      string `json:"protocol" example:"tcp"`
+	HostIP        string `json:"host_ip" example:"0.0.0.0"`
+	HostPort      string `json:"host_port" example:"8080"`
+}
+
+// ContainerRestartPolicy 重启策略
+type ContainerRestartPolicy struct {
+	Name              string `json:"name" example:"always"`
+	MaximumRetryCount int    `json:"maximum_retry_count" example:"0"`
+}
+
+// ContainerResources 资源限制
+type ContainerResources struct {
+	NanoCPUs          int64  `json:"nano_cpus" example:"500000000"`
+	CPUShares         int64  `json:"cpu_shares" example:"0"`
+	Memory            int64  `json:"memory" example:"536870912"`
+	MemorySwap        int64  `json:"memory_swap" example:"1073741824"`
+	MemoryReservation int64  `json:"memory_reservation" example:"268435456"`
+	PidsLimit         *int64 `json:"pids_limit,omitempty" example:"100"`
+}
+
+// UpdateContainerRequest 在线修改容器资源限制和重启策略，未填写的字段保持不变；格式与创建容器相同
+type UpdateContainerRequest struct {
+	CPUs              string             `json:"cpus" example:"1.5"`
+	Memory            string             `json:"memory" example:"1g"`
+	MemorySwap        string             `json:"memory_swap" example:"2g"` // 内存 + swap 总量，-1 表示不限制 swap
+	MemoryReservation string             `json:"memory_reservation" example:"512m"`
+	PidsLimit         *int64             `json:"pids_limit" example:"200"` // 0 或 -1 表示不限制
+	RestartPolicy     *RestartPolicySpec `json:"restart_policy"`
+}
+
+// UpdateContainerResponse 修改容器资源响应，返回修改后的实际配置
+type UpdateContainerResponse struct {
+	Code          int                    `json:"code" example:"200"`
+	Message       string                 `json:"message" example:"Container updated"`
+	Warnings      []string               `json:"warnings"`
+	Resources     ContainerResources     `json:"resources"`
+	RestartPolicy ContainerRestartPolicy `json:"restart_policy"`
+}
+
+// RedeployContainerRequest 用新镜像重建容器的请求，其余配置沿用原容器
+type RedeployContainerRequest struct {
+	Image         string `json:"image" example:"nginx:1.27"`  // 为空时重新拉取容器当前使用的镜像标签
+	Pull          *bool  `json:"pull" example:"true"`         // 先拉取镜像，默认 true；false 时使用本地镜像
+	Force         bool   `json:"force" example:"false"`       // 镜像未变化时也重建，默认跳过
+	KeepOld       bool   `json:"keep_old" example:"false"`    // 保留被替换的容器（已停止并改名），默认删除
+	HealthTimeout int    `json:"health_timeout" example:"60"` // 等待新容器 healthy 的秒数，默认 60
+	MinUptime     int    `json:"min_uptime" example:"5"`      // 未配置健康检查时新容器需持续运行的秒数，默认 5，须小于 health_timeout
+}
+
+// RedeployContainerResponse 重建容器响应
+type RedeployContainerResponse struct {
+	Code            int    `json:"code" example:"200"`
+	Message         string `json:"message" example:"Container redeployed"`
+	Updated         bool   `json:"updated" example:"true"`    // false 表示镜像未变化，未重建
+	ID              string `json:"id" example:"b2c3d4e5f6a1"` // 当前运行的容器 ID
+	Container       string `json:"container" example:"web"`
+	Image           string `json:"image" example:"nginx:1.27"`
+	ImageID         string `json:"image_id" example:"sha256:9f86d081884c"`
+	PreviousImage   string `json:"previous_image" example:"nginx:1.25"`
+	PreviousImageID string `json:"previous_image_id" example:"sha256:1a2b3c4d5e6f"`
+	OldContainer    string `json:"old_container,omitempty" example:"web-replaced-a1b2c3d4e5f6"` // keep_old=true 时被保留的原容器名
+}
+
+// RenameContainerRequest 容器重命名请求
+type RenameContainerRequest struct {
+	Name string `json:"name" example:"my-container-v2"`
+}
+
+// DockerInfoResponse Docker 守护进程信息
+type DockerInfoResponse struct {
+	Name              string `json:"name" example:"docker-host"`
+	ServerVersion     string `json:"server_version" example:"20.10.23"`
+	APIVersion        string `json:"api_version" example:"1.41"`
+	ClientAPIVersion  string `json:"client_api_version" example:"1.41"`
+	OS                string `json:"os" example:"Ubuntu 22.04.4 LTS"`
+	Arch              string `json:"arch" example:"x86_64"`
+	KernelVersion     string `json:"kernel_version" example:"5.15.0-105-generic"`
+	NCPU              int    `json:"ncpu" example:"4"`
+	MemTotal          int64  `json:"mem_total" example:"8218431488"`
+	Containers        int    `json:"containers" example:"12"`
+	ContainersRunning int    `json:"containers_running" example:"8"`
+	Images            int    `json:"images" example:"30"`
+}
+
+// DockerHostInfo 可管理的 Docker 主机
+type DockerHostInfo struct {
+	ID       string `json:"id" example:"local"`
+	Name     string `json:"name" example:"Localhost"`
+	Endpoint string `json:"endpoint" example:"unix:///var/run/docker.sock"`
+	TLS      bool   `json:"tls" example:"false"`
+	Default  bool   `json:"default" example:"true"`
+}
+
+// DockerHostListResponse Docker 主机列表响应
+type DockerHostListResponse struct {
+	Hosts []DockerHostInfo `json:"hosts"`
 }
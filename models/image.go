@@ -0,0 +1,84 @@
+package models
+
+// ImageInfo 本地镜像信息
+type ImageInfo struct {
+	ID         string   `json:"id" example:"sha256:3f8a4339aadd"`
+	Tags       []string `json:"tags" example:"nginx:latest"`
+	Digests    []string `json:"digests" example:"nginx@sha256:0d17b565c37b"`
+	Size       int64    `json:"size" example:"187654321"`
+	Created    int64    `json:"created" example:"1678901234"`
+	Dangling   bool     `json:"dangling" example:"false"`
+	Containers []string `json:"containers" example:"/web,/web-2"` // 使用该镜像的容器名
+}
+
+// ImageListResponse 镜像列表响应
+type ImageListResponse struct {
+	Images []ImageInfo `json:"images"`
+}
+
+// ImageDetail 镜像详情
+type ImageDetail struct {
+	ID           string             `json:"id" example:"sha256:3f8a4339aadd"`
+	Tags         []string           `json:"tags" example:"nginx:latest"`
+	Digests      []string           `json:"digests" example:"nginx@sha256:0d17b565c37b"`
+	Created      string             `json:"created" example:"2025-03-22T12:34:56.789Z"`
+	Author       string             `json:"author" example:""`
+	Architecture string             `json:"architecture" example:"amd64"`
+	OS           string             `json:"os" example:"linux"`
+	Size         int64              `json:"size" example:"187654321"`
+	Entrypoint   []string           `json:"entrypoint" example:"/docker-entrypoint.sh"`
+	Command      []string           `json:"command" example:"nginx,-g,daemon off;"`
+	WorkingDir   string             `json:"working_dir" example:"/"`
+	User         string             `json:"user" example:""`
+	Env          []string           `json:"env" example:"PATH=/usr/local/sbin:/usr/local/bin"`
+	ExposedPorts []string           `json:"exposed_ports" example:"80/tcp"`
+	Labels       map[string]string  `json:"labels"`
+	Layers       []string           `json:"layers" example:"sha256:8cbe4b54fa88"`
+	History      []ImageHistoryItem `json:"history"`
+	Containers   []string           `json:"containers" example:"/web"`
+}
+
+// ImageHistoryItem 镜像构建历史
+type ImageHistoryItem struct {
+	ID        string   `json:"id" example:"<missing>"`
+	Created   int64    `json:"created" example:"1678901234"`
+	CreatedBy string   `json:"created_by" example:"/bin/sh -c #(nop)  CMD [\"nginx\"]"`
+	Size      int64    `json:"size" example:"1024"`
+	Comment   string   `json:"comment" example:""`
+	Tags      []string `json:"tags" example:"nginx:latest"`
+}
+
+// ImageRemoveRequest 删除镜像请求
+type ImageRemoveRequest struct {
+	ID    string `json:"id" example:"nginx:latest"`
+	Force bool   `json:"force" example:"false"`
+}
+
+// ImageRemoveResponse 删除镜像响应
+type ImageRemoveResponse struct {
+	Untagged []string `json:"untagged" example:"nginx:latest"`
+	Deleted  []string `json:"deleted" example:"sha256:3f8a4339aadd"`
+}
+
+// ImageTagRequest 镜像打标签请求
+type ImageTagRequest struct {
+	Source string `json:"source" example:"nginx:latest"`
+	Target string `json:"target" example:"registry.example.com/nginx:1.25"`
+}
+
+// ImagePruneResponse 清理镜像响应，dry_run 时只统计不删除
+type ImagePruneResponse struct {
+	DryRun         bool        `json:"dry_run" example:"true"`
+	Images         []ImageInfo `json:"images"`
+	SpaceReclaimed uint64      `json:"space_reclaimed" example:"52428800"`
+}
+
+// ImagePullProgress 镜像拉取进度消息，按层 ID 区分；最后一条 status 为 done 或 error
+type ImagePullProgress struct {
+	ID       string `json:"id,omitempty" example:"a2abf6c4d29d"`
+	Status   string `json:"status" example:"Downloading"`
+	Progress string `json:"progress,omitempty" example:"[=====>      ]  5.243MB/10.49MB"`
+	Current  int64  `json:"current,omitempty" example:"5242880"`
+	Total    int64  `json:"total,omitempty" example:"10485760"`
+	Error    string `json:"error,omitempty" example:""`
+}
@@ -0,0 +1,97 @@
+package models
+
+// NetworkInfo Docker 网络信息
+type NetworkInfo struct {
+	ID         string            `json:"id" example:"7d86d31b1478"`
+	Name       string            `json:"name" example:"backend"`
+	Driver     string            `json:"driver" example:"bridge"`
+	Scope      string            `json:"scope" example:"local"`
+	Subnets    []string          `json:"subnets" example:"172.20.0.0/16"`
+	Gateways   []string          `json:"gateways" example:"172.20.0.1"`
+	Internal   bool              `json:"internal" example:"false"`
+	Attachable bool              `json:"attachable" example:"false"`
+	Predefined bool              `json:"predefined" example:"false"` // bridge / host / none，不能删除
+	Labels     map[string]string `json:"labels"`
+	Containers int               `json:"containers" example:"2"` // 接入的容器数
+	Created    string            `json:"created" example:"2025-03-22T12:34:56Z"`
+}
+
+// NetworkListResponse 网络列表响应
+type NetworkListResponse struct {
+	Networks []NetworkInfo `json:"networks"`
+}
+
+// NetworkDetail 网络详情
+type NetworkDetail struct {
+	ID         string             `json:"id" example:"7d86d31b1478"`
+	Name       string             `json:"name" example:"backend"`
+	Driver     string             `json:"driver" example:"bridge"`
+	Scope      string             `json:"scope" example:"local"`
+	Internal   bool               `json:"internal" example:"false"`
+	Attachable bool               `json:"attachable" example:"false"`
+	EnableIPv6 bool               `json:"enable_ipv6" example:"false"`
+	Predefined bool               `json:"predefined" example:"false"`
+	IPAM       []NetworkIPAM      `json:"ipam"`
+	Options    map[string]string  `json:"options"`
+	Labels     map[string]string  `json:"labels"`
+	Containers []NetworkContainer `json:"containers"`
+	Created    string             `json:"created" example:"2025-03-22T12:34:56Z"`
+}
+
+// NetworkIPAM 网络地址段
+type NetworkIPAM struct {
+	Subnet  string `json:"subnet" example:"172.20.0.0/16"`
+	Gateway string `json:"gateway" example:"172.20.0.1"`
+	IPRange string `json:"ip_range" example:"172.20.10.0/24"`
+}
+
+// NetworkContainer 接入网络的容器
+type NetworkContainer struct {
+	ID          string `json:"id" example:"a1b2c3d4e5f6"`
+	Name        string `json:"name" example:"web"`
+	IPv4Address string `json:"ipv4_address" example:"172.20.0.2/16"`
+	IPv6Address string `json:"ipv6_address" example:""`
+	MacAddress  string `json:"mac_address" example:"02:42:ac:14:00:02"`
+}
+
+// NetworkCreateRequest 创建网络请求，subnet 为空时由 Docker 自动分配
+type NetworkCreateRequest struct {
+	Name       string            `json:"name" example:"backend"`
+	Driver     string            `json:"driver" example:"bridge"` // bridge / overlay / macvlan / ipvlan，默认 bridge
+	Subnet     string            `json:"subnet" example:"172.20.0.0/16"`
+	Gateway    string            `json:"gateway" example:"172.20.0.1"`
+	IPRange    string            `json:"ip_range" example:"172.20.10.0/24"`
+	Internal   bool              `json:"internal" example:"false"`
+	Attachable bool              `json:"attachable" example:"false"`
+	EnableIPv6 bool              `json:"enable_ipv6" example:"false"`
+	Labels     map[string]string `json:"labels"`
+	Options    map[string]string `json:"options"`
+}
+
+// NetworkCreateResponse 创建网络响应
+type NetworkCreateResponse struct {
+	Code    int    `json:"code" example:"200"`
+	Message string `json:"message" example:"Network created"`
+	ID      string `json:"id" example:"7d86d31b1478"`
+}
+
+// NetworkRemoveRequest 删除网络请求
+type NetworkRemoveRequest struct {
+	ID string `json:"id" example:"backend"`
+}
+
+// NetworkConnectRequest 容器接入网络请求
+type NetworkConnectRequest struct {
+	Network     string   `json:"network" example:"backend"`
+	Container   string   `json:"container" example:"web"`
+	Aliases     []string `json:"aliases" example:"api"`
+	IPv4Address string   `json:"ipv4_address" example:"172.20.0.10"`
+	IPv6Address string   `json:"ipv6_address" example:""`
+}
+
+// NetworkDisconnectRequest 容器断开网络请求
+type NetworkDisconnectRequest struct {
+	Network   string `json:"network" example:"backend"`
+	Container string `json:"container" example:"web"`
+	Force     bool   `json:"force" example:"false"`
+}
@@ -0,0 +1,28 @@
+package models
+
+// RegistryCredentialInfo 镜像仓库凭据，不返回密码和 token 明文
+type RegistryCredentialInfo struct {
+	Registry    string `json:"registry" example:"registry.example.com:5000"`
+	Username    string `json:"username" example:"deploy"`
+	HasPassword bool   `json:"has_password" example:"true"`
+	HasToken    bool   `json:"has_token" example:"false"`
+	UpdatedAt   string `json:"updated_at" example:"2025-03-22T12:34:56Z"`
+}
+
+// RegistryCredentialListResponse 镜像仓库凭据列表响应
+type RegistryCredentialListResponse struct {
+	Credentials []RegistryCredentialInfo `json:"credentials"`
+}
+
+// RegistryCredentialRequest 新增/更新镜像仓库凭据请求，更新时 password、token 留空表示不修改
+type RegistryCredentialRequest struct {
+	Registry string `json:"registry" example:"registry.example.com:5000"`
+	Username string `json:"username" example:"deploy"`
+	Password string `json:"password" example:"s3cret"`
+	Token    string `json:"token" example:""`
+}
+
+// RegistryCredentialDeleteRequest 删除/测试镜像仓库凭据请求
+type RegistryCredentialDeleteRequest struct {
+	Registry string `json:"registry" example:"registry.example.com:5000"`
+}
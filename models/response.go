@@ -9,6 +9,9 @@ type ErrorResponse struct {
 // ContainerListResponse 成功返回的容器列表
 type ContainerListResponse struct {
 	Containers []ContainerInfo `json:"containers"`
+	Total      int             `json:"total" example:"42"` // 过滤后、分页前的容器数
+	Page       int             `json:"page" example:"1"`
+	PageSize   int             `json:"page_size" example:"20"` // 0 表示未分页
 }
 
 // SuccessResponse 通用成功响应
@@ -23,3 +26,15 @@ type CreateContainerResponse struct {
 	Message string `json:"message" example:"Container created"`
 	ID      string `json:"id" example:"a1b2c3d4e5f6"`
 }
+
+// ContainerNotReadyResponse 容器启动失败或 wait 模式下未就绪
+type ContainerNotReadyResponse struct {
+	Error    string   `json:"error" example:"Container is not ready"`
+	Detail   string   `json:"detail" example:"container a1b2c3d4e5f6 exited with code 1"`
+	ID       string   `json:"id" example:"a1b2c3d4e5f6"`
+	Status   string   `json:"status,omitempty" example:"exited"`
+	ExitCode int      `json:"exit_code" example:"1"`
+	Health   string   `json:"health,omitempty" example:"unhealthy"`
+	Logs     []string `json:"logs"` // 最后若干行日志，stderr 行以 "stderr: " 开头
+	Removed  bool     `json:"removed" example:"true"`
+}
@@ -0,0 +1,56 @@
+package models
+
+// SnapshotInfo 容器快照信息
+type SnapshotInfo struct {
+	ID          string   `json:"id" example:"web-20250322-123456"`
+	Host        string   `json:"host" example:"local"`
+	Container   string   `json:"container" example:"web"`
+	ContainerID string   `json:"container_id" example:"a1b2c3d4e5f6"`
+	Image       string   `json:"image" example:"adp-snapshot/web:20250322-123456"`
+	ImageID     string   `json:"image_id" example:"sha256:9f86d081884c"`
+	SourceImage string   `json:"source_image" example:"nginx:latest"` // 快照时容器使用的镜像
+	Networks    []string `json:"networks" example:"bridge"`
+	Note        string   `json:"note" example:"before editing nginx.conf"`
+	ImageExists bool     `json:"image_exists" example:"true"` // 快照镜像被删除后无法回滚
+	Created     string   `json:"created" example:"2025-03-22T12:34:56Z"`
+}
+
+// SnapshotListResponse 快照列表响应
+type SnapshotListResponse struct {
+	Snapshots []SnapshotInfo `json:"snapshots"`
+}
+
+// SnapshotCreateRequest 创建容器快照请求
+type SnapshotCreateRequest struct {
+	Container string `json:"container" example:"web"`
+	Note      string `json:"note" example:"before editing nginx.conf"`
+	Pause     *bool  `json:"pause" example:"true"` // commit 期间暂停容器以保证文件一致，默认 true
+}
+
+// SnapshotCreateResponse 创建容器快照响应
+type SnapshotCreateResponse struct {
+	Code     int          `json:"code" example:"200"`
+	Message  string       `json:"message" example:"Snapshot created"`
+	Snapshot SnapshotInfo `json:"snapshot"`
+}
+
+// SnapshotRollbackRequest 回滚请求
+type SnapshotRollbackRequest struct {
+	ID      string `json:"id" example:"web-20250322-123456"`
+	KeepOld bool   `json:"keep_old" example:"false"` // 保留被替换的容器（已停止并改名），默认删除
+}
+
+// SnapshotRollbackResponse 回滚响应
+type SnapshotRollbackResponse struct {
+	Code         int    `json:"code" example:"200"`
+	Message      string `json:"message" example:"Container rolled back"`
+	ID           string `json:"id" example:"b2c3d4e5f6a1"` // 重建后的容器 ID
+	Container    string `json:"container" example:"web"`
+	OldContainer string `json:"old_container" example:"web-replaced-a1b2c3d4e5f6"` // keep_old=true 时被保留的原容器名
+}
+
+// SnapshotDeleteRequest 删除快照请求
+type SnapshotDeleteRequest struct {
+	ID          string `json:"id" example:"web-20250322-123456"`
+	RemoveImage bool   `json:"remove_image" example:"true"` // 同时删除快照镜像
+}
@@ -0,0 +1,74 @@
+package models
+
+import "encoding/json"
+
+// TemplateParameter 模板参数，没有默认值的参数实例化时必须提供；default 为 "" 表示可选、默认为空
+type TemplateParameter struct {
+	Name        string  `json:"name" example:"TAG"`
+	Description string  `json:"description" example:"镜像标签"`
+	Default     *string `json:"default" example:"latest"` // 未设置或为 null 时参数必填
+	Required    bool    `json:"required" example:"false"` // 只读，由是否设置了 default 决定
+}
+
+// ContainerTemplateRequest 新建 / 修改容器模板。payload 为创建容器的请求体，字符串中可使用 ${NAME} 占位符，$$ 表示字面量 $
+type ContainerTemplateRequest struct {
+	Name        string              `json:"name" example:"web"`
+	Description string              `json:"description" example:"nginx 前端"`
+	Format      string              `json:"format" example:"v2"` // v1 / v2，对应 /api/v1 或 /api/v2 的创建容器请求，默认 v2
+	Payload     json.RawMessage     `json:"payload" swaggertype:"object"`
+	Parameters  []TemplateParameter `json:"parameters"` // 可选，为占位符设置说明和默认值，未声明或未设置 default 的占位符视为必填参数
+}
+
+// ContainerTemplateSummary 模板概要（最新版本）
+type ContainerTemplateSummary struct {
+	Name        string              `json:"name" example:"web"`
+	Description string              `json:"description" example:"nginx 前端"`
+	Format      string              `json:"format" example:"v2"`
+	Version     int                 `json:"version" example:"3"`
+	Parameters  []TemplateParameter `json:"parameters"`
+	UpdatedAt   string              `json:"updated_at" example:"2025-03-22T12:34:56Z"`
+}
+
+// ContainerTemplateListResponse 模板列表
+type ContainerTemplateListResponse struct {
+	Templates []ContainerTemplateSummary `json:"templates"`
+}
+
+// ContainerTemplateDetail 模板某个版本的完整内容
+type ContainerTemplateDetail struct {
+	ContainerTemplateSummary
+	Payload  json.RawMessage `json:"payload" swaggertype:"object"`
+	Versions []int           `json:"versions" example:"1,2,3"` // 全部历史版本
+}
+
+// ContainerTemplateSaveResponse 保存模板成功
+type ContainerTemplateSaveResponse struct {
+	Code       int                 `json:"code" example:"200"`
+	Message    string              `json:"message" example:"Template saved"`
+	Name       string              `json:"name" example:"web"`
+	Version    int                 `json:"version" example:"2"`
+	Parameters []TemplateParameter `json:"parameters"`
+}
+
+// ContainerTemplateDeleteRequest 删除模板
+type ContainerTemplateDeleteRequest struct {
+	Name string `json:"name" example:"web"`
+}
+
+// TemplateInstantiateRequest 用模板创建容器
+type TemplateInstantiateRequest struct {
+	Name    string            `json:"name" example:"web"`
+	Version int               `json:"version" example:"0"` // 0 表示最新版本
+	Params  map[string]string `json:"params"`              // 参数值，未提供时使用默认值
+	DryRun  bool              `json:"dry_run" example:"false"`
+}
+
+// TemplateInstantiateResponse 用模板创建容器的结果，dry_run 时只返回替换参数后的请求体
+type TemplateInstantiateResponse struct {
+	Code     int             `json:"code" example:"200"`
+	Message  string          `json:"message" example:"Container created"`
+	ID       string          `json:"id,omitempty" example:"a1b2c3d4e5f6"`
+	Template string          `json:"template" example:"web"`
+	Version  int             `json:"version" example:"3"`
+	Payload  json.RawMessage `json:"payload" swaggertype:"object"`
+}
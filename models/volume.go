@@ -0,0 +1,59 @@
+package models
+
+// VolumeInfo 数据卷信息及使用情况
+type VolumeInfo struct {
+	Name            string            `json:"name" example:"web-data"`
+	Driver          string            `json:"driver" example:"local"`
+	Mountpoint      string            `json:"mountpoint" example:"/var/lib/docker/volumes/web-data/_data"`
+	Scope           string            `json:"scope" example:"local"`
+	Created         string            `json:"created" example:"2025-03-22T12:34:56Z"`
+	Labels          map[string]string `json:"labels"`
+	Options         map[string]string `json:"options"`
+	Size            int64             `json:"size" example:"52428800"` // 占用磁盘字节数，-1 表示驱动不支持统计
+	InUse           bool              `json:"in_use" example:"true"`
+	Containers      []VolumeContainer `json:"containers"`
+	ComposeProjects []string          `json:"compose_projects" example:"blog"` // 来自数据卷及容器的 com.docker.compose.project 标签
+}
+
+// VolumeContainer 挂载数据卷的容器
+type VolumeContainer struct {
+	ID          string `json:"id" example:"a1b2c3d4e5f6"`
+	Name        string `json:"name" example:"/web"`
+	State       string `json:"state" example:"running"`
+	Destination string `json:"destination" example:"/usr/share/nginx/html"`
+	ReadOnly    bool   `json:"read_only" example:"false"`
+}
+
+// VolumeListResponse 数据卷列表响应
+type VolumeListResponse struct {
+	Volumes   []VolumeInfo `json:"volumes"`
+	TotalSize int64        `json:"total_size" example:"104857600"`
+}
+
+// VolumeCreateRequest 创建数据卷请求
+type VolumeCreateRequest struct {
+	Name       string            `json:"name" example:"web-data"`
+	Driver     string            `json:"driver" example:"local"` // 默认 local
+	DriverOpts map[string]string `json:"driver_opts"`
+	Labels     map[string]string `json:"labels"`
+}
+
+// VolumeCreateResponse 创建数据卷响应
+type VolumeCreateResponse struct {
+	Code    int    `json:"code" example:"200"`
+	Message string `json:"message" example:"Volume created"`
+	Name    string `json:"name" example:"web-data"`
+}
+
+// VolumeRemoveRequest 删除数据卷请求
+type VolumeRemoveRequest struct {
+	Name  string `json:"name" example:"web-data"`
+	Force bool   `json:"force" example:"false"`
+}
+
+// VolumePruneResponse 清理数据卷响应，dry_run 时只统计不删除
+type VolumePruneResponse struct {
+	DryRun         bool         `json:"dry_run" example:"true"`
+	Volumes        []VolumeInfo `json:"volumes"`
+	SpaceReclaimed uint64       `json:"space_reclaimed" example:"52428800"`
+}
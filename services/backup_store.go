@@ -0,0 +1,245 @@
+package services
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/errdefs"
+)
+
+// 备份类型
+const (
+	BackupKindVolume    = "volume"    // 单个命名数据卷，归档内为数据卷根目录下的相对路径
+	BackupKindContainer = "container" // 容器的全部挂载，归档内为以挂载点为前缀的相对路径
+)
+
+var backupIDPattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]*$`)
+
+// Backup 一份备份的元数据，与 tar.gz 归档同名保存为 .json
+type Backup struct {
+	ID        string        `json:"id"`
+	Kind      string        `json:"kind"`
+	Source    string        `json:"source"` // 数据卷名或容器名
+	Host      string        `json:"host"`   // 备份来源的 Docker 主机
+	Mounts    []BackupMount `json:"mounts,omitempty"`
+	File      string        `json:"file"`
+	Size      int64         `json:"size"`
+	SHA256    string        `json:"sha256"`
+	Note      string        `json:"note"`
+	CreatedAt time.Time     `json:"created_at"`
+}
+
+// BackupMount 容器备份中包含的挂载
+type BackupMount struct {
+	Type        string `json:"type"` // volume / bind
+	Source      string `json:"source"`
+	Destination string `json:"destination"`
+}
+
+// BackupStore 备份目录，每份备份由 <id>.tar.gz 和 <id>.json 两个文件组成
+type BackupStore struct {
+	mu  sync.Mutex
+	dir string
+}
+
+// NewBackupStore 打开备份目录，不存在时创建
+func NewBackupStore(dir string) (*BackupStore, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, err
+	}
+	return &BackupStore{dir: dir}, nil
+}
+
+// List 返回全部备份，最新的在前
+func (s *BackupStore) List() ([]Backup, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, err
+	}
+	list := []Backup{}
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".json") {
+			continue
+		}
+		b, err := s.Get(strings.TrimSuffix(e.Name(), ".json"))
+		if err != nil {
+			continue
+		}
+		list = append(list, b)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].CreatedAt.After(list[j].CreatedAt) })
+	return list, nil
+}
+
+// Get 读取备份元数据，不存在时返回 NotFound
+func (s *BackupStore) Get(id string) (Backup, error) {
+	if !backupIDPattern.MatchString(id) {
+		return Backup{}, errdefs.InvalidParameter(fmt.Errorf("invalid backup id %q", id))
+	}
+	data, err := os.ReadFile(filepath.Join(s.dir, id+".json"))
+	if errors.Is(err, os.ErrNotExist) {
+		return Backup{}, errdefs.NotFound(fmt.Errorf("backup %s not found", id))
+	}
+	if err != nil {
+		return Backup{}, err
+	}
+	var b Backup
+	if err := json.Unmarshal(data, &b); err != nil {
+		return Backup{}, fmt.Errorf("parse backup %s: %w", id, err)
+	}
+	return b, nil
+}
+
+// Create 生成带时间戳的备份：write 向 tar 写入条目，归档经 gzip 压缩后落盘并计算 sha256。
+// 写入失败时删除不完整的文件，不会留下半份备份
+func (s *BackupStore) Create(b Backup, write func(tw *tar.Writer) error) (Backup, error) {
+	b.CreatedAt = time.Now()
+	s.mu.Lock()
+	b.ID = s.newID(b.Kind, b.Source, b.CreatedAt)
+	b.File = b.ID + ".tar.gz"
+	// 先占住文件名，避免同一秒内的并发备份互相覆盖
+	tmp := filepath.Join(s.dir, b.File+".tmp")
+	out, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o640)
+	s.mu.Unlock()
+	if err != nil {
+		return Backup{}, err
+	}
+
+	hash := sha256.New()
+	counter := &countingWriter{w: io.MultiWriter(out, hash)}
+	gz := gzip.NewWriter(counter)
+	tw := tar.NewWriter(gz)
+	err = write(tw)
+	if err == nil {
+		err = tw.Close()
+	}
+	if err == nil {
+		err = gz.Close()
+	}
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return Backup{}, err
+	}
+
+	b.Size = counter.n
+	b.SHA256 = hex.EncodeToString(hash.Sum(nil))
+	meta, err := json.MarshalIndent(b, "", "  ")
+	if err == nil {
+		err = os.WriteFile(filepath.Join(s.dir, b.ID+".json"), meta, 0o640)
+	}
+	if err == nil {
+		err = os.Rename(tmp, filepath.Join(s.dir, b.File))
+	}
+	if err != nil {
+		os.Remove(tmp)
+		os.Remove(filepath.Join(s.dir, b.ID+".json"))
+		return Backup{}, err
+	}
+	return b, nil
+}
+
+// Open 校验 sha256 后打开备份，返回解压后的 tar 流
+func (s *BackupStore) Open(id string) (io.ReadCloser, Backup, error) {
+	b, err := s.Get(id)
+	if err != nil {
+		return nil, Backup{}, err
+	}
+	f, err := os.Open(s.Path(b))
+	if err != nil {
+		return nil, Backup{}, err
+	}
+	hash := sha256.New()
+	if _, err := io.Copy(hash, f); err != nil {
+		f.Close()
+		return nil, Backup{}, err
+	}
+	if sum := hex.EncodeToString(hash.Sum(nil)); sum != b.SHA256 {
+		f.Close()
+		return nil, Backup{}, fmt.Errorf("backup %s is corrupted: sha256 %s does not match %s", id, sum, b.SHA256)
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		f.Close()
+		return nil, Backup{}, err
+	}
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		f.Close()
+		return nil, Backup{}, fmt.Errorf("backup %s is corrupted: %w", id, err)
+	}
+	return &gzipFile{Reader: gz, file: f}, b, nil
+}
+
+// Path 返回备份归档的路径
+func (s *BackupStore) Path(b Backup) string {
+	return filepath.Join(s.dir, b.File)
+}
+
+// Delete 删除备份归档及元数据
+func (s *BackupStore) Delete(id string) error {
+	b, err := s.Get(id)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(s.Path(b)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return os.Remove(filepath.Join(s.dir, b.ID+".json"))
+}
+
+// newID 生成 <kind>-<source>-<时间戳>，同一秒内重复时追加序号；调用方需持有锁
+func (s *BackupStore) newID(kind, source string, t time.Time) string {
+	source = strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' || r == '.' || r == '-' {
+			return r
+		}
+		return '_'
+	}, strings.TrimPrefix(source, "/"))
+	base := fmt.Sprintf("%s-%s-%s", kind, source, t.Format("20060102-150405"))
+	id := base
+	for i := 2; ; i++ {
+		_, errJSON := os.Stat(filepath.Join(s.dir, id+".json"))
+		_, errTmp := os.Stat(filepath.Join(s.dir, id+".tar.gz.tmp"))
+		if errors.Is(errJSON, os.ErrNotExist) && errors.Is(errTmp, os.ErrNotExist) {
+			return id
+		}
+		id = fmt.Sprintf("%s-%d", base, i)
+	}
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// gzipFile 关闭时同时关闭 gzip 流和底层文件
+type gzipFile struct {
+	*gzip.Reader
+	file *os.File
+}
+
+func (g *gzipFile) Close() error {
+	g.Reader.Close()
+	return g.file.Close()
+}
@@ -0,0 +1,185 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/errdefs"
+)
+
+// 蓝绿部署的两个颜色
+const (
+	ColorBlue  = "blue"
+	ColorGreen = "green"
+)
+
+// blueGreenHistory 每个应用保留的切换记录条数
+const blueGreenHistory = 20
+
+// OtherColor 返回另一个颜色
+func OtherColor(color string) string {
+	if color == ColorBlue {
+		return ColorGreen
+	}
+	return ColorBlue
+}
+
+// BlueGreenApp 一个应用的蓝绿部署记录。两个颜色的容器名为 <app>-blue / <app>-green，
+// 在线的容器占用公开端口，另一个保持停止，用于立即回滚
+type BlueGreenApp struct {
+	Host      string           `json:"host"`
+	App       string           `json:"app"`
+	Live      string           `json:"live"` // blue / green
+	Blue      *BlueGreenSlot   `json:"blue,omitempty"`
+	Green     *BlueGreenSlot   `json:"green,omitempty"`
+	History   []BlueGreenEvent `json:"history"` // 按时间先后
+	UpdatedAt time.Time        `json:"updated_at"`
+}
+
+// BlueGreenSlot 一个颜色当前对应的容器
+type BlueGreenSlot struct {
+	Container   string    `json:"container"`
+	ContainerID string    `json:"container_id"`
+	Image       string    `json:"image"`
+	ImageID     string    `json:"image_id"`
+	DeployedAt  time.Time `json:"deployed_at"`
+}
+
+// BlueGreenEvent 一次部署或回滚
+type BlueGreenEvent struct {
+	Action string    `json:"action"` // deploy / rollback
+	From   string    `json:"from"`
+	To     string    `json:"to"`
+	Image  string    `json:"image"`
+	At     time.Time `json:"at"`
+}
+
+// Slot 返回颜色对应的容器，未部署过时为 nil
+func (a *BlueGreenApp) Slot(color string) *BlueGreenSlot {
+	if color == ColorGreen {
+		return a.Green
+	}
+	return a.Blue
+}
+
+// SetSlot 设置颜色对应的容器
+func (a *BlueGreenApp) SetSlot(color string, slot *BlueGreenSlot) {
+	if color == ColorGreen {
+		a.Green = slot
+	} else {
+		a.Blue = slot
+	}
+}
+
+// Record 切换在线颜色并追加一条记录，只保留最近的若干条
+func (a *BlueGreenApp) Record(action, to, image string, at time.Time) {
+	a.History = append(a.History, BlueGreenEvent{Action: action, From: a.Live, To: to, Image: image, At: at})
+	if len(a.History) > blueGreenHistory {
+		a.History = a.History[len(a.History)-blueGreenHistory:]
+	}
+	a.Live = to
+	a.UpdatedAt = at
+}
+
+// BlueGreenStore 蓝绿部署记录，按主机和应用名区分，整体保存在一个 JSON 文件中
+type BlueGreenStore struct {
+	mu   sync.RWMutex
+	path string
+	apps map[string]BlueGreenApp
+}
+
+func blueGreenKey(host, app string) string {
+	return host + "/" + app
+}
+
+// NewBlueGreenStore 打开蓝绿部署记录文件，文件不存在时视为空
+func NewBlueGreenStore(path string) (*BlueGreenStore, error) {
+	s := &BlueGreenStore{path: path, apps: make(map[string]BlueGreenApp)}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var list []BlueGreenApp
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, fmt.Errorf("parse blue/green records %s: %w", path, err)
+	}
+	for _, app := range list {
+		s.apps[blueGreenKey(app.Host, app.App)] = app
+	}
+	return s, nil
+}
+
+// List 返回指定主机上的应用，按应用名排序
+func (s *BlueGreenStore) List(host string) []BlueGreenApp {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	list := []BlueGreenApp{}
+	for _, app := range s.apps {
+		if app.Host == host {
+			list = append(list, app)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].App < list[j].App })
+	return list
+}
+
+// Get 查找应用的部署记录
+func (s *BlueGreenStore) Get(host, app string) (BlueGreenApp, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	rec, ok := s.apps[blueGreenKey(host, app)]
+	if !ok {
+		return BlueGreenApp{}, errdefs.NotFound(fmt.Errorf("blue/green app %s not found", app))
+	}
+	return rec, nil
+}
+
+// Put 保存应用的部署记录
+func (s *BlueGreenStore) Put(app BlueGreenApp) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := blueGreenKey(app.Host, app.App)
+	old, existed := s.apps[key]
+	s.apps[key] = app
+	if err := s.flush(); err != nil {
+		if existed {
+			s.apps[key] = old
+		} else {
+			delete(s.apps, key)
+		}
+		return err
+	}
+	return nil
+}
+
+// flush 先写临时文件再改名；调用方需持有写锁
+func (s *BlueGreenStore) flush() error {
+	list := make([]BlueGreenApp, 0, len(s.apps))
+	for _, app := range s.apps {
+		list = append(list, app)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return blueGreenKey(list[i].Host, list[i].App) < blueGreenKey(list[j].Host, list[j].App)
+	})
+	data, err := json.MarshalIndent(list, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o750); err != nil {
+		return err
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o640); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path)
+}
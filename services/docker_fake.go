@@ -0,0 +1,1250 @@
+package services
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/events"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/api/types/registry"
+	timetypes "github.com/docker/docker/api/types/time"
+	"github.com/docker/docker/api/types/volume"
+	"github.com/docker/docker/errdefs"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/docker/go-connections/nat"
+	specs "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// FakeContainer 内存中的容器记录
+type FakeContainer struct {
+	Summary          types.Container
+	Config           *container.Config
+	HostConfig       *container.HostConfig
+	NetworkingConfig *network.NetworkingConfig
+	Health           *types.Health
+	ExitCode         int
+	Logs             []FakeLogLine
+
+	ports nat.PortMap // 启动时实际发布的端口，HostPort 为空的绑定会分配随机端口
+
+	files fakeFS // 容器自身的文件，数据卷挂载点下的文件存放在对应数据卷中
+}
+
+// FakeLogLine 容器输出的一行日志
+type FakeLogLine struct {
+	Time   time.Time
+	Stream string // stdout / stderr
+	Text   string // 含结尾换行
+}
+
+// FakeExec 内存中的 exec 会话，附着后把输入原样回显，模拟一个 TTY shell
+type FakeExec struct {
+	ContainerID string
+	Config      types.ExecConfig
+	Running     bool
+	Started     bool
+	Size        types.ResizeOptions
+}
+
+var _ DockerService = (*FakeDockerService)(nil)
+
+// FakeDockerService 内存版 DockerService，无需 Docker 守护进程即可对控制器做单元测试
+type FakeDockerService struct {
+	mu         sync.Mutex
+	seq        int
+	containers map[string]*FakeContainer
+	images     map[string]*types.ImageInspect
+	execs      map[string]*FakeExec
+	networks   map[string]*types.NetworkResource
+	volumes    map[string]*types.Volume
+	// volumeFiles 数据卷内的文件，key 为数据卷名
+	volumeFiles map[string]fakeFS
+	// imageFiles commit 时保存的容器文件，由该镜像创建的容器以此为初始文件
+	imageFiles map[string]fakeFS
+	// events 已发布的事件，Events 指定 since 时回放
+	events      []events.Message
+	subscribers []*fakeSubscriber
+
+	// Errors 按方法名注入错误，例如 Errors["ContainerList"] = errors.New("boom")
+	Errors map[string]error
+	// Pulled / PulledAuth 记录 ImagePull 拉取过的镜像及使用的认证信息
+	Pulled     []string
+	PulledAuth []string
+	// Registries 模拟需要登录的私有仓库，key 为仓库地址，拉取时校验 RegistryAuth 是否匹配
+	Registries map[string]types.AuthConfig
+	// remote 模拟的镜像仓库：镜像标签 → 仓库中当前的清单摘要
+	remote map[string]string
+	// lastPort 最近分配的随机宿主机端口
+	lastPort int
+}
+
+// NewFakeDockerService 创建空的内存 Docker 服务
+func NewFakeDockerService() *FakeDockerService {
+	f := &FakeDockerService{
+		containers:  make(map[string]*FakeContainer),
+		images:      make(map[string]*types.ImageInspect),
+		execs:       make(map[string]*FakeExec),
+		networks:    make(map[string]*types.NetworkResource),
+		volumes:     make(map[string]*types.Volume),
+		volumeFiles: make(map[string]fakeFS),
+		imageFiles:  make(map[string]fakeFS),
+		Errors:      make(map[string]error),
+		Registries:  make(map[string]types.AuthConfig),
+		remote:      make(map[string]string),
+	}
+	// 与 Docker 一样预置 bridge / host / none 三个网络
+	f.addNetwork("bridge", types.NetworkCreate{Driver: "bridge", IPAM: &network.IPAM{Driver: "default", Config: []network.IPAMConfig{{Subnet: "172.17.0.0/16", Gateway: "172.17.0.1"}}}})
+	f.addNetwork("host", types.NetworkCreate{Driver: "host"})
+	f.addNetwork("none", types.NetworkCreate{Driver: "null"})
+	return f
+}
+
+// AddContainer 预置一个容器，ID 为空时自动生成
+func (f *FakeDockerService) AddContainer(c types.Container) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if c.ID == "" {
+		c.ID = f.nextID()
+	}
+	f.containers[c.ID] = &FakeContainer{
+		Summary:          c,
+		Config:           &container.Config{Image: c.Image, Labels: c.Labels},
+		HostConfig:       &container.HostConfig{},
+		NetworkingConfig: &network.NetworkingConfig{},
+		files:            make(fakeFS),
+	}
+	return c.ID
+}
+
+// AddImage 预置一个本地镜像，返回镜像 ID
+func (f *FakeDockerService) AddImage(ref string) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.addImage(ref)
+}
+
+func (f *FakeDockerService) addImage(ref string) string {
+	if img, err := f.lookupImage(ref); err == nil {
+		return img.ID
+	}
+	id := "sha256:" + f.nextID()
+	layer := "sha256:" + f.nextID()
+	img := &types.ImageInspect{
+		ID:           id,
+		Created:      time.Now().UTC().Format(time.RFC3339Nano),
+		Architecture: "amd64",
+		Os:           "linux",
+		Size:         10 << 20,
+		VirtualSize:  10 << 20,
+		Config:       &container.Config{Cmd: []string{"/bin/sh"}},
+		RootFS:       types.RootFS{Type: "layers", Layers: []string{layer}},
+	}
+	if ref != "" {
+		img.RepoTags = []string{NormalizeImageRef(ref)}
+		img.RepoDigests = []string{repoDigest(ref, f.registryDigest(ref))}
+	}
+	f.images[id] = img
+	return id
+}
+
+func (f *FakeDockerService) lookupImage(ref string) (*types.ImageInspect, error) {
+	if ref != "" {
+		tag := NormalizeImageRef(ref)
+		for id, img := range f.images {
+			if id == ref || id == "sha256:"+ref || (len(ref) >= 4 && strings.HasPrefix(strings.TrimPrefix(id, "sha256:"), ref)) {
+				return img, nil
+			}
+			for _, t := range img.RepoTags {
+				if t == tag {
+					return img, nil
+				}
+			}
+		}
+	}
+	return nil, errdefs.NotFound(fmt.Errorf("No such image: %s", ref))
+}
+
+// imageInUse 返回使用该镜像的容器数
+func (f *FakeDockerService) imageInUse(id string) int {
+	n := 0
+	for _, c := range f.containers {
+		if c.Summary.ImageID == id {
+			n++
+		}
+	}
+	return n
+}
+
+// Exec 按 ID 取出 exec 会话记录
+func (f *FakeDockerService) Exec(execID string) (FakeExec, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	e, ok := f.execs[execID]
+	if !ok {
+		return FakeExec{}, false
+	}
+	return *e, true
+}
+
+// Container 按 ID 或名称取出容器记录，便于断言创建参数
+func (f *FakeDockerService) Container(idOrName string) (*FakeContainer, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	c, err := f.lookup(idOrName)
+	return c, err == nil
+}
+
+func (f *FakeDockerService) nextID() string {
+	f.seq++
+	sum := sha256.Sum256([]byte(fmt.Sprintf("fake-%d", f.seq)))
+	return hex.EncodeToString(sum[:])
+}
+
+// SetError 注入（err 为 nil 时清除）某个方法的错误，可与其他调用并发
+func (f *FakeDockerService) SetError(method string, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err == nil {
+		delete(f.Errors, method)
+		return
+	}
+	f.Errors[method] = err
+}
+
+func (f *FakeDockerService) fail(method string) error {
+	return f.Errors[method]
+}
+
+// lookup 与守护进程一致：先按完整 ID、再按名称查找，最后按 ID 前缀匹配，前缀对应多个容器时报错
+func (f *FakeDockerService) lookup(idOrName string) (*FakeContainer, error) {
+	if idOrName == "" {
+		return nil, errdefs.InvalidParameter(fmt.Errorf("invalid name or ID supplied: %q", idOrName))
+	}
+	if c, ok := f.containers[idOrName]; ok {
+		return c, nil
+	}
+	if c := f.byName(idOrName); c != nil {
+		return c, nil
+	}
+	var found *FakeContainer
+	for id, c := range f.containers {
+		if !strings.HasPrefix(id, idOrName) {
+			continue
+		}
+		if found != nil {
+			return nil, errdefs.System(fmt.Errorf("multiple IDs found with provided prefix: %s", idOrName))
+		}
+		found = c
+	}
+	if found == nil {
+		return nil, errdefs.NotFound(fmt.Errorf("No such container: %s", idOrName))
+	}
+	return found, nil
+}
+
+// byName 按名称查找容器，用于名称冲突检查
+func (f *FakeDockerService) byName(name string) *FakeContainer {
+	name = strings.TrimPrefix(name, "/")
+	for _, c := range f.containers {
+		for _, n := range c.Summary.Names {
+			if strings.TrimPrefix(n, "/") == name {
+				return c
+			}
+		}
+	}
+	return nil
+}
+
+func (f *FakeDockerService) ContainerList(ctx context.Context, options types.ContainerListOptions) ([]types.Container, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("ContainerList"); err != nil {
+		return nil, err
+	}
+	var list []types.Container
+	for _, c := range f.containers {
+		if !options.All && c.Summary.State != "running" {
+			continue
+		}
+		if !c.matches(options.Filters) {
+			continue
+		}
+		list = append(list, f.summary(c, options.Size))
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Created > list[j].Created })
+	return list, nil
+}
+
+// healthStatus 返回健康状态，未配置健康检查时为 none
+func (c *FakeContainer) healthStatus() string {
+	if c.Health == nil || c.Health.Status == "" {
+		return types.NoHealthcheck
+	}
+	return c.Health.Status
+}
+
+// matches 按 docker ps 的 status / label / name / health 过滤条件匹配容器
+func (c *FakeContainer) matches(filter filters.Args) bool {
+	if filter.Contains("status") && !filter.ExactMatch("status", c.Summary.State) {
+		return false
+	}
+	if filter.Contains("health") && !filter.ExactMatch("health", c.healthStatus()) {
+		return false
+	}
+	if filter.Contains("name") {
+		matched := false
+		for _, n := range c.Summary.Names {
+			matched = matched || filter.Match("name", strings.TrimPrefix(n, "/"))
+		}
+		if !matched {
+			return false
+		}
+	}
+	return filter.MatchKVList("label", c.Summary.Labels)
+}
+
+// summary 由内存记录拼出 docker ps 的一项，运行中的容器在状态后附加健康状态
+func (f *FakeDockerService) summary(c *FakeContainer, withSize bool) types.Container {
+	summary := c.Summary
+	info := c.inspect()
+	summary.Mounts = info.Mounts
+	if health := c.healthStatus(); health != types.NoHealthcheck && summary.State == "running" {
+		if health == types.Starting {
+			health = "health: starting"
+		}
+		summary.Status += " (" + health + ")"
+	}
+	summary.Ports = nil
+	for port, bindings := range c.HostConfig.PortBindings {
+		for _, b := range bindings {
+			public, _ := strconv.Atoi(b.HostPort)
+			summary.Ports = append(summary.Ports, types.Port{IP: b.HostIP, PrivatePort: uint16(port.Int()), PublicPort: uint16(public), Type: port.Proto()})
+		}
+	}
+	summary.HostConfig.NetworkMode = string(c.HostConfig.NetworkMode)
+	summary.NetworkSettings = &types.SummaryNetworkSettings{Networks: info.NetworkSettings.Networks}
+	if withSize {
+		for _, file := range c.files {
+			summary.SizeRw += int64(len(file.data))
+		}
+		summary.SizeRootFs = summary.SizeRw
+		if img, ok := f.images[c.Summary.ImageID]; ok {
+			summary.SizeRootFs += img.Size
+		}
+	}
+	return summary
+}
+
+func (f *FakeDockerService) ContainerInspect(ctx context.Context, containerID string) (types.ContainerJSON, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("ContainerInspect"); err != nil {
+		return types.ContainerJSON{}, err
+	}
+	c, err := f.lookup(containerID)
+	if err != nil {
+		return types.ContainerJSON{}, err
+	}
+	return c.inspect(), nil
+}
+
+// endpoints 返回容器接入的网络；未显式接入任何网络时按 NetworkMode 接入默认网络
+func (c *FakeContainer) endpoints() map[string]*network.EndpointSettings {
+	if len(c.NetworkingConfig.EndpointsConfig) > 0 {
+		return c.NetworkingConfig.EndpointsConfig
+	}
+	mode := string(c.HostConfig.NetworkMode)
+	if mode == "" || mode == "default" {
+		mode = "bridge"
+	}
+	return map[string]*network.EndpointSettings{mode: {}}
+}
+
+// inspect 由内存记录拼出与 docker inspect 结构一致的结果
+func (c *FakeContainer) inspect() types.ContainerJSON {
+	state := c.Summary.State
+	var mounts []types.MountPoint
+	for _, b := range c.HostConfig.Binds {
+		parts := strings.Split(b, ":")
+		if len(parts) < 2 {
+			continue
+		}
+		mp := types.MountPoint{Type: "bind", Source: parts[0], Destination: parts[1], RW: true}
+		if !strings.HasPrefix(parts[0], "/") {
+			mp.Type, mp.Name, mp.Source = "volume", parts[0], "/var/lib/docker/volumes/"+parts[0]+"/_data"
+		}
+		if len(parts) > 2 {
+			mp.Mode = parts[2]
+			mp.RW = !strings.Contains(parts[2], "ro")
+		}
+		mounts = append(mounts, mp)
+	}
+	for _, m := range c.HostConfig.Mounts {
+		mp := types.MountPoint{Type: m.Type, Source: m.Source, Destination: m.Target, RW: !m.ReadOnly}
+		if m.Type == "volume" {
+			mp.Name = m.Source
+			if mp.Name == "" {
+				mp.Name = c.Summary.ID // 匿名卷
+			}
+			mp.Source = "/var/lib/docker/volumes/" + mp.Name + "/_data"
+		}
+		mounts = append(mounts, mp)
+	}
+	networks := make(map[string]*network.EndpointSettings)
+	for name, ep := range c.endpoints() {
+		networks[name] = ep
+	}
+	name := ""
+	if len(c.Summary.Names) > 0 {
+		name = c.Summary.Names[0]
+	}
+	// 返回健康状态的副本，SetHealth 修改时不影响调用方已取得的结果
+	var health *types.Health
+	if c.Health != nil {
+		h := *c.Health
+		health = &h
+	}
+	return types.ContainerJSON{
+		ContainerJSONBase: &types.ContainerJSONBase{
+			ID:      c.Summary.ID,
+			Created: time.Unix(c.Summary.Created, 0).UTC().Format(time.RFC3339Nano),
+			Name:    name,
+			Image:   c.Summary.ImageID,
+			State: &types.ContainerState{
+				Status:   state,
+				Running:  state == "running" || state == "paused",
+				Paused:   state == "paused",
+				ExitCode: c.ExitCode,
+				Health:   health,
+			},
+			HostConfig: c.HostConfig,
+		},
+		Mounts:          mounts,
+		Config:          c.Config,
+		NetworkSettings: &types.NetworkSettings{Networks: networks, NetworkSettingsBase: types.NetworkSettingsBase{Ports: c.publishedPorts()}},
+	}
+}
+
+func (f *FakeDockerService) ContainerStart(ctx context.Context, containerID string, options types.ContainerStartOptions) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("ContainerStart"); err != nil {
+		return err
+	}
+	c, err := f.lookup(containerID)
+	if err != nil {
+		return err
+	}
+	if err := f.publishPorts(c); err != nil {
+		return err
+	}
+	c.Summary.State = "running"
+	c.Summary.Status = "Up Less than a second"
+	// 配置了健康检查的容器启动后处于 starting，之后由 SetHealth 推进
+	if hc := c.Config.Healthcheck; hc != nil && len(hc.Test) > 0 && hc.Test[0] != "NONE" {
+		c.Health = &types.Health{Status: types.Starting}
+	}
+	f.emitContainer(c, "start", nil)
+	return nil
+}
+
+func (f *FakeDockerService) ContainerStop(ctx context.Context, containerID string, timeout *time.Duration) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("ContainerStop"); err != nil {
+		return err
+	}
+	c, err := f.lookup(containerID)
+	if err != nil {
+		return err
+	}
+	if c.Summary.State == "running" || c.Summary.State == "paused" {
+		f.emitContainer(c, "die", map[string]string{"exitCode": "0"})
+	}
+	c.Summary.State = "exited"
+	c.Summary.Status = "Exited (0) Less than a second ago"
+	f.emitContainer(c, "stop", nil)
+	return nil
+}
+
+func (f *FakeDockerService) ContainerRestart(ctx context.Context, containerID string, timeout *time.Duration) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("ContainerRestart"); err != nil {
+		return err
+	}
+	c, err := f.lookup(containerID)
+	if err != nil {
+		return err
+	}
+	if err := f.publishPorts(c); err != nil {
+		return err
+	}
+	c.Summary.State = "running"
+	c.Summary.Status = "Up Less than a second"
+	f.emitContainer(c, "restart", nil)
+	return nil
+}
+
+func (f *FakeDockerService) ContainerPause(ctx context.Context, containerID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("ContainerPause"); err != nil {
+		return err
+	}
+	c, err := f.lookup(containerID)
+	if err != nil {
+		return err
+	}
+	if c.Summary.State != "running" {
+		return errdefs.Conflict(fmt.Errorf("Container %s is not running", containerID))
+	}
+	c.Summary.State = "paused"
+	c.Summary.Status = "Up Less than a second (Paused)"
+	f.emitContainer(c, "pause", nil)
+	return nil
+}
+
+func (f *FakeDockerService) ContainerUnpause(ctx context.Context, containerID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("ContainerUnpause"); err != nil {
+		return err
+	}
+	c, err := f.lookup(containerID)
+	if err != nil {
+		return err
+	}
+	if c.Summary.State != "paused" {
+		return errdefs.Conflict(fmt.Errorf("Container %s is not paused", containerID))
+	}
+	c.Summary.State = "running"
+	c.Summary.Status = "Up Less than a second"
+	f.emitContainer(c, "unpause", nil)
+	return nil
+}
+
+func (f *FakeDockerService) ContainerKill(ctx context.Context, containerID, signal string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("ContainerKill"); err != nil {
+		return err
+	}
+	c, err := f.lookup(containerID)
+	if err != nil {
+		return err
+	}
+	if c.Summary.State != "running" {
+		return errdefs.Conflict(fmt.Errorf("Container %s is not running", containerID))
+	}
+	c.Summary.State = "exited"
+	c.Summary.Status = "Exited (137) Less than a second ago"
+	f.emitContainer(c, "kill", map[string]string{"signal": signal})
+	f.emitContainer(c, "die", map[string]string{"exitCode": "137"})
+	return nil
+}
+
+func (f *FakeDockerService) ContainerRename(ctx context.Context, containerID, newContainerName string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("ContainerRename"); err != nil {
+		return err
+	}
+	c, err := f.lookup(containerID)
+	if err != nil {
+		return err
+	}
+	if f.byName(newContainerName) != nil {
+		return errdefs.Conflict(fmt.Errorf("Conflict. The container name %q is already in use", "/"+newContainerName))
+	}
+	oldName := c.Summary.Names[0]
+	c.Summary.Names = []string{"/" + strings.TrimPrefix(newContainerName, "/")}
+	f.emitContainer(c, "rename", map[string]string{"oldName": oldName})
+	return nil
+}
+
+// ContainerUpdate 与 Docker 一致，资源字段为 0、重启策略名为空时保持不变
+func (f *FakeDockerService) ContainerUpdate(ctx context.Context, containerID string, updateConfig container.UpdateConfig) (container.ContainerUpdateOKBody, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("ContainerUpdate"); err != nil {
+		return container.ContainerUpdateOKBody{}, err
+	}
+	c, err := f.lookup(containerID)
+	if err != nil {
+		return container.ContainerUpdateOKBody{}, err
+	}
+	hc := *c.HostConfig
+	r := updateConfig.Resources
+	if r.NanoCPUs != 0 {
+		hc.NanoCPUs = r.NanoCPUs
+	}
+	if r.Memory != 0 {
+		hc.Memory = r.Memory
+	}
+	if r.MemorySwap != 0 {
+		hc.MemorySwap = r.MemorySwap
+	}
+	if r.MemoryReservation != 0 {
+		hc.MemoryReservation = r.MemoryReservation
+	}
+	if r.PidsLimit != nil {
+		pids := *r.PidsLimit
+		hc.PidsLimit = &pids
+	}
+	if hc.MemorySwap > 0 && hc.Memory > hc.MemorySwap {
+		return container.ContainerUpdateOKBody{}, errdefs.InvalidParameter(errors.New("Memory limit should be smaller than already set memoryswap limit, update the memoryswap at the same time"))
+	}
+	if updateConfig.RestartPolicy.Name != "" {
+		if hc.AutoRemove && updateConfig.RestartPolicy.Name != "no" {
+			return container.ContainerUpdateOKBody{}, errdefs.InvalidParameter(errors.New("Restart policy cannot be updated because AutoRemove is enabled for the container"))
+		}
+		hc.RestartPolicy = updateConfig.RestartPolicy
+	}
+	c.HostConfig = &hc
+	f.emitContainer(c, "update", nil)
+	return container.ContainerUpdateOKBody{Warnings: []string{}}, nil
+}
+
+func (f *FakeDockerService) ContainerRemove(ctx context.Context, containerID string, options types.ContainerRemoveOptions) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("ContainerRemove"); err != nil {
+		return err
+	}
+	c, err := f.lookup(containerID)
+	if err != nil {
+		return err
+	}
+	if (c.Summary.State == "running" || c.Summary.State == "paused") && !options.Force {
+		return errdefs.Conflict(fmt.Errorf("You cannot remove a running container %s. Stop the container before attempting removal or force remove", c.Summary.ID))
+	}
+	delete(f.containers, c.Summary.ID)
+	f.emitContainer(c, "destroy", nil)
+	// RemoveVolumes 同时删除容器的匿名卷
+	if options.RemoveVolumes {
+		if v, ok := f.volumes[c.Summary.ID]; ok && f.volumeRefs(v.Name) == 0 {
+			delete(f.volumes, v.Name)
+			delete(f.volumeFiles, v.Name)
+		}
+	}
+	return nil
+}
+
+func (f *FakeDockerService) ContainerCreate(ctx context.Context, config *container.Config, hostConfig *container.HostConfig, networkingConfig *network.NetworkingConfig, platform *specs.Platform, containerName string) (container.ContainerCreateCreatedBody, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("ContainerCreate"); err != nil {
+		return container.ContainerCreateCreatedBody{}, err
+	}
+	img, err := f.lookupImage(config.Image)
+	if err != nil {
+		return container.ContainerCreateCreatedBody{}, err
+	}
+	if containerName != "" {
+		if f.byName(containerName) != nil {
+			return container.ContainerCreateCreatedBody{}, errdefs.Conflict(fmt.Errorf("Conflict. The container name %q is already in use", "/"+containerName))
+		}
+	}
+	id := f.nextID()
+	if containerName == "" {
+		containerName = "fake_" + id[len(id)-6:]
+	}
+	if hostConfig == nil {
+		hostConfig = &container.HostConfig{}
+	}
+	if networkingConfig == nil {
+		networkingConfig = &network.NetworkingConfig{}
+	}
+	mode := string(hostConfig.NetworkMode)
+	if mode == "" || mode == "default" {
+		mode = "bridge"
+	}
+	endpoints := map[string]*network.EndpointSettings{}
+	if !strings.HasPrefix(mode, "container:") {
+		if len(networkingConfig.EndpointsConfig) > 1 {
+			return container.ContainerCreateCreatedBody{}, errdefs.InvalidParameter(errors.New("Container cannot be connected to network endpoints: only one network can be specified on create"))
+		}
+		ep := networkingConfig.EndpointsConfig[mode]
+		if ep == nil {
+			ep = &network.EndpointSettings{}
+		}
+		n, err := f.lookupNetwork(mode)
+		if err != nil {
+			return container.ContainerCreateCreatedBody{}, err
+		}
+		if err := f.attach(n, ep); err != nil {
+			return container.ContainerCreateCreatedBody{}, err
+		}
+		endpoints[n.Name] = ep
+	}
+	networkingConfig = &network.NetworkingConfig{EndpointsConfig: endpoints}
+	fc := &FakeContainer{
+		Summary: types.Container{
+			ID:      id,
+			Names:   []string{"/" + containerName},
+			Image:   config.Image,
+			ImageID: img.ID,
+			Labels:  config.Labels,
+			Created: time.Now().Unix(),
+			State:   "created",
+			Status:  "Created",
+		},
+		Config:           config,
+		HostConfig:       hostConfig,
+		NetworkingConfig: networkingConfig,
+		files:            f.imageFiles[img.ID].clone(),
+	}
+	// 与 Docker 一致，挂载的数据卷不存在时自动创建
+	for _, m := range fc.inspect().Mounts {
+		if m.Type == "volume" {
+			f.addVolume(volume.VolumeCreateBody{Name: m.Name})
+		}
+	}
+	f.containers[id] = fc
+	f.emitContainer(fc, "create", nil)
+	return container.ContainerCreateCreatedBody{ID: id}, nil
+}
+
+func (f *FakeDockerService) ContainerLogs(ctx context.Context, containerID string, options types.ContainerLogsOptions) (io.ReadCloser, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("ContainerLogs"); err != nil {
+		return nil, err
+	}
+	c, err := f.lookup(containerID)
+	if err != nil {
+		return nil, err
+	}
+	since, err := fakeLogTime(options.Since)
+	if err != nil {
+		return nil, errdefs.InvalidParameter(err)
+	}
+	until, err := fakeLogTime(options.Until)
+	if err != nil {
+		return nil, errdefs.InvalidParameter(err)
+	}
+	tail := -1
+	if options.Tail != "" && options.Tail != "all" {
+		if tail, err = strconv.Atoi(options.Tail); err != nil {
+			return nil, errdefs.InvalidParameter(fmt.Errorf("invalid tail %q", options.Tail))
+		}
+	}
+
+	// 与 Docker 一致：非 TTY 容器按 stdcopy 格式多路复用 stdout / stderr，TTY 容器直接输出原始内容
+	pr, pw := io.Pipe()
+	stdout, stderr := io.Writer(pw), io.Writer(pw)
+	if !c.Config.Tty {
+		stdout, stderr = stdcopy.NewStdWriter(pw, stdcopy.Stdout), stdcopy.NewStdWriter(pw, stdcopy.Stderr)
+	}
+	write := func(l FakeLogLine) error {
+		if !since.IsZero() && l.Time.Before(since) || !until.IsZero() && l.Time.After(until) {
+			return nil
+		}
+		if l.Stream == "stderr" && !options.ShowStderr || l.Stream != "stderr" && !options.ShowStdout {
+			return nil
+		}
+		text := l.Text
+		if options.Timestamps {
+			text = l.Time.UTC().Format(time.RFC3339Nano) + " " + text
+		}
+		w := stdout
+		if l.Stream == "stderr" {
+			w = stderr
+		}
+		_, err := io.WriteString(w, text)
+		return err
+	}
+
+	lines := append([]FakeLogLine(nil), c.Logs...)
+	if tail >= 0 && tail < len(lines) {
+		lines = lines[len(lines)-tail:]
+	}
+	sent := len(c.Logs)
+	go func() {
+		for _, l := range lines {
+			if err := write(l); err != nil {
+				return
+			}
+		}
+		// follow 时每 100ms 检查新追加的日志，直到 ctx 取消
+		for options.Follow {
+			select {
+			case <-ctx.Done():
+				pw.CloseWithError(ctx.Err())
+				return
+			case <-time.After(100 * time.Millisecond):
+			}
+			f.mu.Lock()
+			fresh := append([]FakeLogLine(nil), c.Logs[sent:]...)
+			sent = len(c.Logs)
+			f.mu.Unlock()
+			for _, l := range fresh {
+				if err := write(l); err != nil {
+					return
+				}
+			}
+		}
+		pw.Close()
+	}()
+	return pr, nil
+}
+
+// AppendLogs 向容器追加日志，正在 follow 的 ContainerLogs 会收到新行
+func (f *FakeDockerService) AppendLogs(idOrName string, lines ...FakeLogLine) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	c, err := f.lookup(idOrName)
+	if err != nil {
+		return err
+	}
+	for _, l := range lines {
+		if l.Time.IsZero() {
+			l.Time = time.Now()
+		}
+		if l.Stream == "" {
+			l.Stream = "stdout"
+		}
+		c.Logs = append(c.Logs, l)
+	}
+	return nil
+}
+
+// fakeLogTime 解析 since / until，支持 RFC3339、Unix 时间戳和相对时长 (10m)
+func fakeLogTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	ts, err := timetypes.GetTimestamp(value, time.Now())
+	if err != nil {
+		return time.Time{}, err
+	}
+	sec, nsec, err := timetypes.ParseTimestamps(ts, 0)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(sec, nsec), nil
+}
+
+func (f *FakeDockerService) ContainerStats(ctx context.Context, containerID string, stream bool) (types.ContainerStats, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("ContainerStats"); err != nil {
+		return types.ContainerStats{}, err
+	}
+	c, err := f.lookup(containerID)
+	if err != nil {
+		return types.ContainerStats{}, err
+	}
+	id, name := c.Summary.ID, c.Summary.Names[0]
+
+	// 每 500ms 生成一条累计值递增的样本，stream=false 时只输出一条
+	pr, pw := io.Pipe()
+	go func() {
+		enc := json.NewEncoder(pw)
+		var prev types.CPUStats
+		for i := uint64(1); ; i++ {
+			cur := types.CPUStats{
+				CPUUsage:    types.CPUUsage{TotalUsage: i * 5e7},
+				SystemUsage: i * 1e9,
+				OnlineCPUs:  2,
+			}
+			sample := types.StatsJSON{
+				Name: name,
+				ID:   id,
+				Stats: types.Stats{
+					Read:        time.Now(),
+					CPUStats:    cur,
+					PreCPUStats: prev,
+					MemoryStats: types.MemoryStats{Usage: 64 << 20, Limit: 512 << 20, Stats: map[string]uint64{"inactive_file": 4 << 20}},
+					PidsStats:   types.PidsStats{Current: 3},
+					BlkioStats: types.BlkioStats{IoServiceBytesRecursive: []types.BlkioStatEntry{
+						{Op: "read", Value: i * 4096},
+						{Op: "write", Value: i * 8192},
+					}},
+				},
+				Networks: map[string]types.NetworkStats{"eth0": {RxBytes: i * 1500, TxBytes: i * 800}},
+			}
+			prev = cur
+			if err := enc.Encode(sample); err != nil || !stream {
+				pw.Close()
+				return
+			}
+			select {
+			case <-ctx.Done():
+				pw.CloseWithError(ctx.Err())
+				return
+			case <-time.After(500 * time.Millisecond):
+			}
+		}
+	}()
+	return types.ContainerStats{Body: pr, OSType: "linux"}, nil
+}
+
+func (f *FakeDockerService) ContainerExecCreate(ctx context.Context, containerID string, config types.ExecConfig) (types.IDResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("ContainerExecCreate"); err != nil {
+		return types.IDResponse{}, err
+	}
+	c, err := f.lookup(containerID)
+	if err != nil {
+		return types.IDResponse{}, err
+	}
+	if c.Summary.State != "running" {
+		return types.IDResponse{}, errdefs.Conflict(fmt.Errorf("Container %s is not running", containerID))
+	}
+	id := f.nextID()
+	f.execs[id] = &FakeExec{ContainerID: c.Summary.ID, Config: config}
+	return types.IDResponse{ID: id}, nil
+}
+
+func (f *FakeDockerService) ContainerExecAttach(ctx context.Context, execID string, config types.ExecStartCheck) (types.HijackedResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("ContainerExecAttach"); err != nil {
+		return types.HijackedResponse{}, err
+	}
+	e, ok := f.execs[execID]
+	if !ok {
+		return types.HijackedResponse{}, errdefs.NotFound(fmt.Errorf("No such exec instance: %s", execID))
+	}
+	e.Running, e.Started = true, true
+
+	client, server := net.Pipe()
+	go func() {
+		io.Copy(server, server)
+		server.Close()
+		f.mu.Lock()
+		e.Running = false
+		f.mu.Unlock()
+	}()
+	return types.HijackedResponse{Conn: client, Reader: bufio.NewReader(client)}, nil
+}
+
+func (f *FakeDockerService) ContainerExecStart(ctx context.Context, execID string, config types.ExecStartCheck) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("ContainerExecStart"); err != nil {
+		return err
+	}
+	e, ok := f.execs[execID]
+	if !ok {
+		return errdefs.NotFound(fmt.Errorf("No such exec instance: %s", execID))
+	}
+	e.Started = true
+	return nil
+}
+
+func (f *FakeDockerService) ContainerExecResize(ctx context.Context, execID string, options types.ResizeOptions) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("ContainerExecResize"); err != nil {
+		return err
+	}
+	e, ok := f.execs[execID]
+	if !ok {
+		return errdefs.NotFound(fmt.Errorf("No such exec instance: %s", execID))
+	}
+	e.Size = options
+	return nil
+}
+
+func (f *FakeDockerService) ContainerExecInspect(ctx context.Context, execID string) (types.ContainerExecInspect, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("ContainerExecInspect"); err != nil {
+		return types.ContainerExecInspect{}, err
+	}
+	e, ok := f.execs[execID]
+	if !ok {
+		return types.ContainerExecInspect{}, errdefs.NotFound(fmt.Errorf("No such exec instance: %s", execID))
+	}
+	return types.ContainerExecInspect{ExecID: execID, ContainerID: e.ContainerID, Running: e.Running}, nil
+}
+
+func (f *FakeDockerService) ImageInspectWithRaw(ctx context.Context, imageID string) (types.ImageInspect, []byte, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("ImageInspectWithRaw"); err != nil {
+		return types.ImageInspect{}, nil, err
+	}
+	img, err := f.lookupImage(imageID)
+	if err != nil {
+		return types.ImageInspect{}, nil, err
+	}
+	return *img, nil, nil
+}
+
+func (f *FakeDockerService) ImagePull(ctx context.Context, ref string, options types.ImagePullOptions) (io.ReadCloser, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("ImagePull"); err != nil {
+		return nil, err
+	}
+	f.Pulled = append(f.Pulled, ref)
+	f.PulledAuth = append(f.PulledAuth, options.RegistryAuth)
+	var auth types.AuthConfig
+	if data, err := base64.URLEncoding.DecodeString(options.RegistryAuth); err == nil {
+		json.Unmarshal(data, &auth)
+	}
+	if err := f.checkRegistryAuth(RegistryHost(ref), auth); err != nil {
+		return nil, err
+	}
+	id := f.pullFromRegistry(ref)
+	layer := f.images[id].RootFS.Layers[0][7:19]
+	f.emit(events.ImageEventType, "pull", NormalizeImageRef(ref), map[string]string{"name": NormalizeImageRef(ref)})
+
+	// 模拟 Docker 的拉取进度流，每条消息间隔 20ms，ctx 取消时中断
+	messages := []string{
+		fmt.Sprintf(`{"status":"Pulling from %s","id":"%s"}`, strings.Split(ref, ":")[0], strings.Split(NormalizeImageRef(ref), ":")[1]),
+		fmt.Sprintf(`{"status":"Pulling fs layer","progressDetail":{},"id":"%s"}`, layer),
+		fmt.Sprintf(`{"status":"Downloading","progressDetail":{"current":5242880,"total":10485760},"progress":"[=========================>                         ]  5.243MB/10.49MB","id":"%s"}`, layer),
+		fmt.Sprintf(`{"status":"Downloading","progressDetail":{"current":10485760,"total":10485760},"progress":"[==================================================>]  10.49MB/10.49MB","id":"%s"}`, layer),
+		fmt.Sprintf(`{"status":"Pull complete","progressDetail":{},"id":"%s"}`, layer),
+		fmt.Sprintf(`{"status":"Digest: sha256:%s"}`, id[7:]),
+		fmt.Sprintf(`{"status":"Status: Downloaded newer image for %s"}`, NormalizeImageRef(ref)),
+	}
+	pr, pw := io.Pipe()
+	go func() {
+		for _, m := range messages {
+			select {
+			case <-ctx.Done():
+				pw.CloseWithError(ctx.Err())
+				return
+			case <-time.After(20 * time.Millisecond):
+			}
+			if _, err := io.WriteString(pw, m+"\n"); err != nil {
+				return
+			}
+		}
+		pw.Close()
+	}()
+	return pr, nil
+}
+
+// checkRegistryAuth 私有仓库要求用户名密码或 identity token 与登记的一致
+func (f *FakeDockerService) checkRegistryAuth(registry string, auth types.AuthConfig) error {
+	want, private := f.Registries[registry]
+	if !private {
+		return nil
+	}
+	if auth.Username == want.Username && (auth.Password == want.Password && want.Password != "" ||
+		auth.IdentityToken == want.IdentityToken && want.IdentityToken != "") {
+		return nil
+	}
+	return errdefs.Unauthorized(fmt.Errorf("Get \"https://%s/v2/\": unauthorized: authentication required", registry))
+}
+
+func (f *FakeDockerService) ImageList(ctx context.Context, options types.ImageListOptions) ([]types.ImageSummary, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("ImageList"); err != nil {
+		return nil, err
+	}
+	var list []types.ImageSummary
+	for id, img := range f.images {
+		dangling := len(img.RepoTags) == 0
+		if options.Filters.Contains("dangling") && options.Filters.ExactMatch("dangling", "true") != dangling {
+			continue
+		}
+		created, _ := time.Parse(time.RFC3339Nano, img.Created)
+		list = append(list, types.ImageSummary{
+			ID:          id,
+			RepoTags:    img.RepoTags,
+			RepoDigests: img.RepoDigests,
+			Created:     created.Unix(),
+			Size:        img.Size,
+			VirtualSize: img.VirtualSize,
+			Containers:  int64(f.imageInUse(id)),
+		})
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+	return list, nil
+}
+
+func (f *FakeDockerService) ImageHistory(ctx context.Context, imageID string) ([]image.HistoryResponseItem, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("ImageHistory"); err != nil {
+		return nil, err
+	}
+	img, err := f.lookupImage(imageID)
+	if err != nil {
+		return nil, err
+	}
+	created, _ := time.Parse(time.RFC3339Nano, img.Created)
+	history := []image.HistoryResponseItem{{ID: img.ID, Created: created.Unix(), CreatedBy: "/bin/sh -c #(nop) CMD [\"/bin/sh\"]", Tags: img.RepoTags}}
+	for range img.RootFS.Layers {
+		history = append(history, image.HistoryResponseItem{ID: "<missing>", Created: created.Unix(), CreatedBy: "/bin/sh -c #(nop) ADD file:rootfs in / ", Size: img.Size / int64(len(img.RootFS.Layers))})
+	}
+	return history, nil
+}
+
+func (f *FakeDockerService) ImageRemove(ctx context.Context, imageID string, options types.ImageRemoveOptions) ([]types.ImageDeleteResponseItem, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("ImageRemove"); err != nil {
+		return nil, err
+	}
+	img, err := f.lookupImage(imageID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 按标签删除且镜像还有其他标签时只取消该标签
+	tag := NormalizeImageRef(imageID)
+	if len(img.RepoTags) > 1 && !options.Force {
+		for i, t := range img.RepoTags {
+			if t == tag {
+				img.RepoTags = append(img.RepoTags[:i:i], img.RepoTags[i+1:]...)
+				f.emit(events.ImageEventType, "untag", img.ID, map[string]string{"name": t})
+				return []types.ImageDeleteResponseItem{{Untagged: t}}, nil
+			}
+		}
+	}
+	if f.imageInUse(img.ID) > 0 && !options.Force {
+		return nil, errdefs.Conflict(fmt.Errorf("conflict: unable to remove repository reference %q - container is using its referenced image %s", imageID, img.ID[7:19]))
+	}
+
+	var items []types.ImageDeleteResponseItem
+	for _, t := range img.RepoTags {
+		items = append(items, types.ImageDeleteResponseItem{Untagged: t})
+	}
+	items = append(items, types.ImageDeleteResponseItem{Deleted: img.ID})
+	for _, l := range img.RootFS.Layers {
+		items = append(items, types.ImageDeleteResponseItem{Deleted: l})
+	}
+	delete(f.images, img.ID)
+	delete(f.imageFiles, img.ID)
+	f.emit(events.ImageEventType, "delete", img.ID, map[string]string{"name": img.ID})
+	return items, nil
+}
+
+func (f *FakeDockerService) ImageTag(ctx context.Context, source, target string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("ImageTag"); err != nil {
+		return err
+	}
+	img, err := f.lookupImage(source)
+	if err != nil {
+		return err
+	}
+	f.tagImage(img, target)
+	f.emit(events.ImageEventType, "tag", img.ID, map[string]string{"name": NormalizeImageRef(target)})
+	return nil
+}
+
+// tagImage 给镜像打标签，同名标签从原镜像上移走
+func (f *FakeDockerService) tagImage(img *types.ImageInspect, ref string) {
+	ref = NormalizeImageRef(ref)
+	for _, other := range f.images {
+		for i, t := range other.RepoTags {
+			if t == ref {
+				other.RepoTags = append(other.RepoTags[:i:i], other.RepoTags[i+1:]...)
+				break
+			}
+		}
+	}
+	img.RepoTags = append(img.RepoTags, ref)
+}
+
+func (f *FakeDockerService) ImagesPrune(ctx context.Context, pruneFilter filters.Args) (types.ImagesPruneReport, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("ImagesPrune"); err != nil {
+		return types.ImagesPruneReport{}, err
+	}
+	danglingOnly := !pruneFilter.Contains("dangling") || pruneFilter.ExactMatch("dangling", "true")
+	var report types.ImagesPruneReport
+	for id, img := range f.images {
+		if f.imageInUse(id) > 0 || (danglingOnly && len(img.RepoTags) > 0) {
+			continue
+		}
+		for _, t := range img.RepoTags {
+			report.ImagesDeleted = append(report.ImagesDeleted, types.ImageDeleteResponseItem{Untagged: t})
+		}
+		report.ImagesDeleted = append(report.ImagesDeleted, types.ImageDeleteResponseItem{Deleted: id})
+		report.SpaceReclaimed += uint64(img.Size)
+		delete(f.images, id)
+		delete(f.imageFiles, id)
+		f.emit(events.ImageEventType, "delete", id, map[string]string{"name": id})
+	}
+	return report, nil
+}
+
+func (f *FakeDockerService) RegistryLogin(ctx context.Context, auth types.AuthConfig) (registry.AuthenticateOKBody, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("RegistryLogin"); err != nil {
+		return registry.AuthenticateOKBody{}, err
+	}
+	if err := f.checkRegistryAuth(NormalizeRegistry(auth.ServerAddress), auth); err != nil {
+		return registry.AuthenticateOKBody{}, err
+	}
+	return registry.AuthenticateOKBody{Status: "Login Succeeded"}, nil
+}
+
+func (f *FakeDockerService) Info(ctx context.Context) (types.Info, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("Info"); err != nil {
+		return types.Info{}, err
+	}
+	info := types.Info{
+		ID:              "FAKE",
+		Name:            "fake-docker",
+		ServerVersion:   "20.10.23",
+		OperatingSystem: "FakeOS",
+		OSType:          "linux",
+		Architecture:    "x86_64",
+		Containers:      len(f.containers),
+		Images:          len(f.images),
+	}
+	for _, c := range f.containers {
+		switch c.Summary.State {
+		case "running":
+			info.ContainersRunning++
+		case "paused":
+			info.ContainersPaused++
+		default:
+			info.ContainersStopped++
+		}
+	}
+	return info, nil
+}
+
+func (f *FakeDockerService) ServerVersion(ctx context.Context) (types.Version, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("ServerVersion"); err != nil {
+		return types.Version{}, err
+	}
+	return types.Version{Version: "20.10.23", APIVersion: "1.41", MinAPIVersion: "1.12", Os: "linux", Arch: "amd64"}, nil
+}
+
+func (f *FakeDockerService) ClientVersion() string {
+	return "1.41"
+}
+
+func (f *FakeDockerService) Close() error {
+	return nil
+}
@@ -0,0 +1,180 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/events"
+	"github.com/docker/docker/api/types/filters"
+	timetypes "github.com/docker/docker/api/types/time"
+)
+
+// fakeSubscriber 一个 Events 订阅
+type fakeSubscriber struct {
+	filter filters.Args
+	ch     chan events.Message
+}
+
+// EmitEvent 发布一条事件，可用于模拟 oom 等内存实现不会主动产生的事件
+func (f *FakeDockerService) EmitEvent(typ, action, actorID string, attributes map[string]string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.emit(typ, action, actorID, attributes)
+}
+
+// SetHealth 设置容器健康状态并发布 health_status 事件
+func (f *FakeDockerService) SetHealth(idOrName, status string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	c, err := f.lookup(idOrName)
+	if err != nil {
+		return err
+	}
+	if c.Health == nil {
+		c.Health = &types.Health{}
+	}
+	c.Health.Status = status
+	f.emitContainer(c, "health_status: "+status, nil)
+	return nil
+}
+
+// Exit 模拟容器进程以 code 退出，并发布 die 事件
+func (f *FakeDockerService) Exit(idOrName string, code int) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	c, err := f.lookup(idOrName)
+	if err != nil {
+		return err
+	}
+	c.ExitCode = code
+	c.Summary.State = "exited"
+	c.Summary.Status = fmt.Sprintf("Exited (%d) Less than a second ago", code)
+	f.emitContainer(c, "die", map[string]string{"exitCode": fmt.Sprint(code)})
+	return nil
+}
+
+// emitContainer 发布容器事件，属性与 Docker 一致包含容器名、镜像和全部标签，extra 为动作相关的属性；调用方需持有锁
+func (f *FakeDockerService) emitContainer(c *FakeContainer, action string, extra map[string]string) {
+	attributes := map[string]string{"image": c.Summary.Image}
+	for k, v := range c.Summary.Labels {
+		attributes[k] = v
+	}
+	for k, v := range extra {
+		attributes[k] = v
+	}
+	if len(c.Summary.Names) > 0 {
+		attributes["name"] = strings.TrimPrefix(c.Summary.Names[0], "/")
+	}
+	f.emit(events.ContainerEventType, action, c.Summary.ID, attributes)
+}
+
+// emit 记录事件并推送给匹配的订阅；订阅方处理不过来时丢弃。调用方需持有锁
+func (f *FakeDockerService) emit(typ, action, actorID string, attributes map[string]string) {
+	now := time.Now()
+	msg := events.Message{
+		Type:     typ,
+		Action:   action,
+		Actor:    events.Actor{ID: actorID, Attributes: attributes},
+		Scope:    "local",
+		Time:     now.Unix(),
+		TimeNano: now.UnixNano(),
+	}
+	f.events = append(f.events, msg)
+	for _, sub := range f.subscribers {
+		if matchEvent(sub.filter, msg) {
+			select {
+			case sub.ch <- msg:
+			default:
+			}
+		}
+	}
+}
+
+// matchEvent 按 Docker 的规则过滤事件：type / event / container / image / network / volume / label
+func matchEvent(filter filters.Args, msg events.Message) bool {
+	action := msg.Action
+	// health_status: healthy、exec_start: sh 之类的动作按冒号前的部分匹配
+	if i := strings.Index(action, ":"); i >= 0 {
+		action = strings.TrimSpace(action[:i])
+	}
+	if filter.Contains("event") && !filter.ExactMatch("event", action) && !filter.ExactMatch("event", msg.Action) {
+		return false
+	}
+	if filter.Contains("type") && !filter.ExactMatch("type", msg.Type) {
+		return false
+	}
+	actor := func(key, typ string) bool {
+		if !filter.Contains(key) {
+			return true
+		}
+		return msg.Type == typ && (filter.ExactMatch(key, msg.Actor.ID) || filter.ExactMatch(key, msg.Actor.Attributes["name"]) ||
+			filter.FuzzyMatch(key, msg.Actor.ID))
+	}
+	if !actor("container", events.ContainerEventType) || !actor("network", events.NetworkEventType) || !actor("volume", events.VolumeEventType) {
+		return false
+	}
+	if filter.Contains("image") {
+		matched := false
+		for _, img := range []string{msg.Actor.ID, msg.Actor.Attributes["image"], msg.Actor.Attributes["name"]} {
+			if img != "" && (filter.ExactMatch("image", img) || filter.ExactMatch("image", NormalizeImageRef(img))) {
+				matched = true
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return filter.MatchKVList("label", msg.Actor.Attributes)
+}
+
+func (f *FakeDockerService) Events(ctx context.Context, options types.EventsOptions) (<-chan events.Message, <-chan error) {
+	errC := make(chan error, 1)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("Events"); err != nil {
+		errC <- err
+		return make(chan events.Message), errC
+	}
+
+	sub := &fakeSubscriber{filter: options.Filters, ch: make(chan events.Message, 256)}
+	// since 之后的历史事件先回放
+	if options.Since != "" {
+		ts, err := timetypes.GetTimestamp(options.Since, time.Now())
+		if err != nil {
+			errC <- err
+			return make(chan events.Message), errC
+		}
+		sec, nsec, err := timetypes.ParseTimestamps(ts, 0)
+		if err != nil {
+			errC <- err
+			return make(chan events.Message), errC
+		}
+		since := time.Unix(sec, nsec).UnixNano()
+		for _, msg := range f.events {
+			if msg.TimeNano >= since && matchEvent(sub.filter, msg) {
+				select {
+				case sub.ch <- msg:
+				default:
+				}
+			}
+		}
+	}
+	f.subscribers = append(f.subscribers, sub)
+
+	go func() {
+		<-ctx.Done()
+		f.mu.Lock()
+		for i, s := range f.subscribers {
+			if s == sub {
+				f.subscribers = append(f.subscribers[:i:i], f.subscribers[i+1:]...)
+				break
+			}
+		}
+		f.mu.Unlock()
+		errC <- ctx.Err()
+	}()
+	return sub.ch, errC
+}
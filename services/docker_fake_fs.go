@@ -0,0 +1,276 @@
+package services
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/errdefs"
+)
+
+// fakeFile 内存文件系统中的一个文件或目录
+type fakeFile struct {
+	data    []byte
+	mode    os.FileMode
+	modTime time.Time
+}
+
+// fakeFS 以相对路径 (不带前导 /) 为键的内存文件系统，根目录隐式存在
+type fakeFS map[string]*fakeFile
+
+func (fs fakeFS) stat(rel string) (*fakeFile, bool) {
+	if rel == "" {
+		return &fakeFile{mode: os.ModeDir | 0o755}, true
+	}
+	file, ok := fs[rel]
+	return file, ok
+}
+
+// clone 复制文件系统，nil 时返回空文件系统
+func (fs fakeFS) clone() fakeFS {
+	cp := make(fakeFS, len(fs))
+	for k, file := range fs {
+		data := append([]byte(nil), file.data...)
+		cp[k] = &fakeFile{data: data, mode: file.mode, modTime: file.modTime}
+	}
+	return cp
+}
+
+// write 写入文件或目录，并补齐缺失的父目录
+func (fs fakeFS) write(rel string, file *fakeFile) {
+	for dir := path.Dir(rel); dir != "." && dir != "/"; dir = path.Dir(dir) {
+		if _, ok := fs[dir]; !ok {
+			fs[dir] = &fakeFile{mode: os.ModeDir | 0o755, modTime: file.modTime}
+		}
+	}
+	fs[rel] = file
+}
+
+// walk 按路径顺序遍历 rel 及其下的所有条目
+func (fs fakeFS) walk(rel string, fn func(rel string, file *fakeFile)) {
+	if file, ok := fs.stat(rel); ok && rel != "" {
+		fn(rel, file)
+	}
+	var keys []string
+	for k := range fs {
+		if rel == "" || strings.HasPrefix(k, rel+"/") {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fn(k, fs[k])
+	}
+}
+
+// WriteFile 在容器内写入文件，路径位于数据卷挂载点下时写入对应数据卷
+func (f *FakeDockerService) WriteFile(idOrName, p string, data []byte) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	c, err := f.lookup(idOrName)
+	if err != nil {
+		return err
+	}
+	fs, rel := f.resolvePath(c, p)
+	if rel == "" {
+		return errdefs.InvalidParameter(fmt.Errorf("%s is a directory", p))
+	}
+	fs.write(rel, &fakeFile{data: data, mode: 0o644, modTime: time.Now().UTC()})
+	return nil
+}
+
+// ReadFile 读取容器内的文件
+func (f *FakeDockerService) ReadFile(idOrName, p string) ([]byte, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	c, err := f.lookup(idOrName)
+	if err != nil {
+		return nil, err
+	}
+	fs, rel := f.resolvePath(c, p)
+	file, ok := fs.stat(rel)
+	if !ok || file.mode.IsDir() {
+		return nil, errdefs.NotFound(fmt.Errorf("no such file: %s", p))
+	}
+	return file.data, nil
+}
+
+// resolvePath 把容器内的绝对路径解析到容器自身或挂载的数据卷，取最长匹配的挂载点
+func (f *FakeDockerService) resolvePath(c *FakeContainer, p string) (fakeFS, string) {
+	p = path.Clean("/" + p)
+	fs, rel, longest := c.files, strings.TrimPrefix(p, "/"), -1
+	for _, m := range c.inspect().Mounts {
+		if m.Type != "volume" || len(m.Destination) <= longest {
+			continue
+		}
+		if p == m.Destination || strings.HasPrefix(p, m.Destination+"/") {
+			fs, rel, longest = f.volumeFS(m.Name), strings.TrimPrefix(strings.TrimPrefix(p, m.Destination), "/"), len(m.Destination)
+		}
+	}
+	return fs, rel
+}
+
+func (f *FakeDockerService) volumeFS(name string) fakeFS {
+	fs, ok := f.volumeFiles[name]
+	if !ok {
+		fs = make(fakeFS)
+		f.volumeFiles[name] = fs
+	}
+	return fs
+}
+
+func (f *FakeDockerService) CopyFromContainer(ctx context.Context, containerID, srcPath string) (io.ReadCloser, types.ContainerPathStat, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("CopyFromContainer"); err != nil {
+		return nil, types.ContainerPathStat{}, err
+	}
+	c, err := f.lookup(containerID)
+	if err != nil {
+		return nil, types.ContainerPathStat{}, err
+	}
+	fs, rel := f.resolvePath(c, srcPath)
+	file, ok := fs.stat(rel)
+	if !ok {
+		return nil, types.ContainerPathStat{}, errdefs.NotFound(fmt.Errorf("Could not find the file %s in container %s", srcPath, containerID))
+	}
+
+	// 与 Docker 一致，归档内的条目以源路径的最后一级名称为根
+	base := path.Base(path.Clean("/" + srcPath))
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	if rel == "" {
+		tw.WriteHeader(&tar.Header{Name: base + "/", Mode: 0o755, Typeflag: tar.TypeDir, ModTime: file.modTime})
+	}
+	fs.walk(rel, func(name string, file *fakeFile) {
+		hdr := &tar.Header{Name: base + strings.TrimPrefix(name, rel), Mode: int64(file.mode.Perm()), ModTime: file.modTime, Typeflag: tar.TypeReg, Size: int64(len(file.data))}
+		if rel == "" {
+			hdr.Name = path.Join(base, name)
+		}
+		if file.mode.IsDir() {
+			hdr.Typeflag, hdr.Size, hdr.Name = tar.TypeDir, 0, hdr.Name+"/"
+		}
+		tw.WriteHeader(hdr)
+		tw.Write(file.data)
+	})
+	tw.Close()
+
+	stat := types.ContainerPathStat{Name: base, Size: int64(len(file.data)), Mode: file.mode, Mtime: file.modTime}
+	return io.NopCloser(&buf), stat, nil
+}
+
+func (f *FakeDockerService) CopyToContainer(ctx context.Context, containerID, dstPath string, content io.Reader, options types.CopyToContainerOptions) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("CopyToContainer"); err != nil {
+		return err
+	}
+	c, err := f.lookup(containerID)
+	if err != nil {
+		return err
+	}
+	dst := path.Clean("/" + dstPath)
+	fs, rel := f.resolvePath(c, dst)
+	file, ok := fs.stat(rel)
+	if !ok {
+		return errdefs.NotFound(fmt.Errorf("Could not find the file %s in container %s", dstPath, containerID))
+	}
+	if !file.mode.IsDir() {
+		return errdefs.InvalidParameter(errors.New("extraction point is not a directory"))
+	}
+
+	tr := tar.NewReader(content)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return errdefs.InvalidParameter(err)
+		}
+		name := path.Clean("/" + hdr.Name)
+		if name == "/" {
+			continue
+		}
+		// 每个条目重新解析，条目可能落在另一个挂载点下
+		entryFS, entryRel := f.resolvePath(c, path.Join(dst, name))
+		if entryRel == "" {
+			continue
+		}
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			entryFS.write(entryRel, &fakeFile{mode: os.ModeDir | os.FileMode(hdr.Mode).Perm(), modTime: hdr.ModTime})
+		case tar.TypeReg:
+			data, err := io.ReadAll(tr)
+			if err != nil {
+				return errdefs.InvalidParameter(err)
+			}
+			entryFS.write(entryRel, &fakeFile{data: data, mode: os.FileMode(hdr.Mode).Perm(), modTime: hdr.ModTime})
+		}
+	}
+}
+
+// ContainerCommit 把容器当前的配置和自身文件（不含数据卷）保存为新镜像
+func (f *FakeDockerService) ContainerCommit(ctx context.Context, containerID string, options types.ContainerCommitOptions) (types.IDResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("ContainerCommit"); err != nil {
+		return types.IDResponse{}, err
+	}
+	c, err := f.lookup(containerID)
+	if err != nil {
+		return types.IDResponse{}, err
+	}
+	id := f.addImage("")
+	img := f.images[id]
+	config := *c.Config
+	img.Config = &config
+	img.Comment = options.Comment
+	img.Author = options.Author
+	img.Container = c.Summary.ID
+	img.Parent = c.Summary.ImageID
+	if options.Reference != "" {
+		f.tagImage(img, options.Reference)
+	}
+	f.imageFiles[id] = c.files.clone()
+	f.emitContainer(c, "commit", map[string]string{"comment": options.Comment})
+	return types.IDResponse{ID: id}, nil
+}
+
+// ContainerWait 内存实现中没有真实进程，等待时直接视为进程已以 ExitCode 退出
+func (f *FakeDockerService) ContainerWait(ctx context.Context, containerID string, condition container.WaitCondition) (<-chan container.ContainerWaitOKBody, <-chan error) {
+	resultC := make(chan container.ContainerWaitOKBody, 1)
+	errC := make(chan error, 1)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("ContainerWait"); err != nil {
+		errC <- err
+		return resultC, errC
+	}
+	c, err := f.lookup(containerID)
+	if err != nil {
+		errC <- err
+		return resultC, errC
+	}
+	if c.Summary.State == "running" {
+		c.Summary.State = "exited"
+		c.Summary.Status = fmt.Sprintf("Exited (%d) Less than a second ago", c.ExitCode)
+		f.emitContainer(c, "die", map[string]string{"exitCode": fmt.Sprint(c.ExitCode)})
+	}
+	resultC <- container.ContainerWaitOKBody{StatusCode: int64(c.ExitCode)}
+	if condition == container.WaitConditionRemoved && c.HostConfig.AutoRemove {
+		delete(f.containers, c.Summary.ID)
+	}
+	return resultC, errC
+}
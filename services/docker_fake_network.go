@@ -0,0 +1,278 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/events"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/errdefs"
+)
+
+// 预置网络不能删除，也不能手动接入/断开
+var fakePredefinedNetworks = map[string]bool{"bridge": true, "host": true, "none": true}
+
+func (f *FakeDockerService) addNetwork(name string, options types.NetworkCreate) *types.NetworkResource {
+	n := &types.NetworkResource{
+		Name:       name,
+		ID:         f.nextID(),
+		Created:    time.Now().UTC(),
+		Scope:      "local",
+		Driver:     options.Driver,
+		EnableIPv6: options.EnableIPv6,
+		Internal:   options.Internal,
+		Attachable: options.Attachable,
+		Options:    options.Options,
+		Labels:     options.Labels,
+	}
+	if options.IPAM != nil {
+		n.IPAM = *options.IPAM
+	}
+	f.networks[n.ID] = n
+	return n
+}
+
+func (f *FakeDockerService) lookupNetwork(idOrName string) (*types.NetworkResource, error) {
+	for id, n := range f.networks {
+		if n.Name == idOrName || id == idOrName {
+			return n, nil
+		}
+	}
+	var found []*types.NetworkResource
+	for id, n := range f.networks {
+		if idOrName != "" && strings.HasPrefix(id, idOrName) {
+			found = append(found, n)
+		}
+	}
+	if len(found) > 1 {
+		return nil, errdefs.InvalidParameter(fmt.Errorf("network %s is ambiguous (%d matches found based on ID prefix)", idOrName, len(found)))
+	}
+	if len(found) == 0 {
+		return nil, errdefs.NotFound(fmt.Errorf("network %s not found", idOrName))
+	}
+	return found[0], nil
+}
+
+// attach 为接入网络的端点分配 IP：指定了静态 IP 时校验是否在子网内，否则从子网中顺序分配
+func (f *FakeDockerService) attach(n *types.NetworkResource, ep *network.EndpointSettings) error {
+	ep.NetworkID = n.ID
+	if len(n.IPAM.Config) == 0 {
+		return nil
+	}
+	_, subnet, err := net.ParseCIDR(n.IPAM.Config[0].Subnet)
+	if err != nil {
+		return nil
+	}
+	ep.Gateway = n.IPAM.Config[0].Gateway
+	ones, _ := subnet.Mask.Size()
+	ep.IPPrefixLen = ones
+
+	if ep.IPAMConfig != nil && ep.IPAMConfig.IPv4Address != "" {
+		if fakePredefinedNetworks[n.Name] {
+			return errdefs.InvalidParameter(errors.New("user specified IP address is supported only for user defined networks"))
+		}
+		ip := net.ParseIP(ep.IPAMConfig.IPv4Address)
+		if ip == nil || !subnet.Contains(ip) {
+			return errdefs.InvalidParameter(fmt.Errorf("invalid address %s: it does not belong to any of this network's subnets", ep.IPAMConfig.IPv4Address))
+		}
+		for _, c := range f.containers {
+			// 与 Docker 一致，停止的容器不再占用 IP
+			if c.Summary.State != "running" && c.Summary.State != "paused" {
+				continue
+			}
+			if other, ok := c.endpoints()[n.Name]; ok && other.IPAddress == ip.String() {
+				return errdefs.Conflict(fmt.Errorf("Address already in use"))
+			}
+		}
+		ep.IPAddress = ip.String()
+		return nil
+	}
+
+	used := 0
+	for _, c := range f.containers {
+		if _, ok := c.endpoints()[n.Name]; ok {
+			used++
+		}
+	}
+	ip := subnet.IP.To4()
+	if ip == nil {
+		return nil
+	}
+	ip = net.IPv4(ip[0], ip[1], ip[2], ip[3]+byte(used+2))
+	ep.IPAddress = ip.String()
+	return nil
+}
+
+func (f *FakeDockerService) NetworkList(ctx context.Context, options types.NetworkListOptions) ([]types.NetworkResource, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("NetworkList"); err != nil {
+		return nil, err
+	}
+	var list []types.NetworkResource
+	for _, n := range f.networks {
+		if options.Filters.Contains("driver") && !options.Filters.ExactMatch("driver", n.Driver) {
+			continue
+		}
+		if options.Filters.Contains("name") && !options.Filters.Match("name", n.Name) {
+			continue
+		}
+		list = append(list, *n)
+	}
+	return list, nil
+}
+
+func (f *FakeDockerService) NetworkInspect(ctx context.Context, networkID string, options types.NetworkInspectOptions) (types.NetworkResource, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("NetworkInspect"); err != nil {
+		return types.NetworkResource{}, err
+	}
+	n, err := f.lookupNetwork(networkID)
+	if err != nil {
+		return types.NetworkResource{}, err
+	}
+	res := *n
+	res.Containers = make(map[string]types.EndpointResource)
+	for id, c := range f.containers {
+		ep, ok := c.endpoints()[n.Name]
+		if !ok {
+			continue
+		}
+		addr := ""
+		if ep.IPAddress != "" {
+			addr = fmt.Sprintf("%s/%d", ep.IPAddress, ep.IPPrefixLen)
+		}
+		res.Containers[id] = types.EndpointResource{
+			Name:        strings.TrimPrefix(c.Summary.Names[0], "/"),
+			EndpointID:  ep.EndpointID,
+			MacAddress:  ep.MacAddress,
+			IPv4Address: addr,
+		}
+	}
+	return res, nil
+}
+
+func (f *FakeDockerService) NetworkCreate(ctx context.Context, name string, options types.NetworkCreate) (types.NetworkCreateResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("NetworkCreate"); err != nil {
+		return types.NetworkCreateResponse{}, err
+	}
+	if _, err := f.lookupNetwork(name); err == nil {
+		return types.NetworkCreateResponse{}, errdefs.Conflict(fmt.Errorf("network with name %s already exists", name))
+	}
+	if options.Driver == "" {
+		options.Driver = "bridge"
+	}
+	if options.IPAM == nil || len(options.IPAM.Config) == 0 {
+		// 与 Docker 一样从 172.18.0.0/16 起为新网络分配子网
+		octet := 18 + len(f.networks) - len(fakePredefinedNetworks)
+		options.IPAM = &network.IPAM{Driver: "default", Config: []network.IPAMConfig{{
+			Subnet:  fmt.Sprintf("172.%d.0.0/16", octet),
+			Gateway: fmt.Sprintf("172.%d.0.1", octet),
+		}}}
+	}
+	n := f.addNetwork(name, options)
+	f.emit(events.NetworkEventType, "create", n.ID, map[string]string{"name": n.Name, "type": n.Driver})
+	return types.NetworkCreateResponse{ID: n.ID}, nil
+}
+
+func (f *FakeDockerService) NetworkRemove(ctx context.Context, networkID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("NetworkRemove"); err != nil {
+		return err
+	}
+	n, err := f.lookupNetwork(networkID)
+	if err != nil {
+		return err
+	}
+	if fakePredefinedNetworks[n.Name] {
+		return errdefs.Forbidden(fmt.Errorf("%s is a pre-defined network and cannot be removed", n.Name))
+	}
+	for _, c := range f.containers {
+		if _, ok := c.endpoints()[n.Name]; ok {
+			return errdefs.Forbidden(fmt.Errorf("error while removing network: network %s id %s has active endpoints", n.Name, n.ID))
+		}
+	}
+	delete(f.networks, n.ID)
+	f.emit(events.NetworkEventType, "destroy", n.ID, map[string]string{"name": n.Name, "type": n.Driver})
+	return nil
+}
+
+func (f *FakeDockerService) NetworkConnect(ctx context.Context, networkID, containerID string, config *network.EndpointSettings) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("NetworkConnect"); err != nil {
+		return err
+	}
+	n, err := f.lookupNetwork(networkID)
+	if err != nil {
+		return err
+	}
+	c, err := f.lookup(containerID)
+	if err != nil {
+		return err
+	}
+	endpoints := c.endpoints()
+	if _, ok := endpoints[n.Name]; ok {
+		return errdefs.Forbidden(fmt.Errorf("endpoint with name %s already exists in network %s", strings.TrimPrefix(c.Summary.Names[0], "/"), n.Name))
+	}
+	if _, ok := endpoints["host"]; ok || n.Name == "host" {
+		return errdefs.Forbidden(errors.New("container sharing network namespace with another container or host cannot be connected to any other network"))
+	}
+	if config == nil {
+		config = &network.EndpointSettings{}
+	}
+	ep := *config
+	if err := f.attach(n, &ep); err != nil {
+		return err
+	}
+	merged := make(map[string]*network.EndpointSettings, len(endpoints)+1)
+	for k, v := range endpoints {
+		merged[k] = v
+	}
+	merged[n.Name] = &ep
+	c.NetworkingConfig = &network.NetworkingConfig{EndpointsConfig: merged}
+	f.emit(events.NetworkEventType, "connect", n.ID, map[string]string{"name": n.Name, "type": n.Driver, "container": c.Summary.ID})
+	return nil
+}
+
+func (f *FakeDockerService) NetworkDisconnect(ctx context.Context, networkID, containerID string, force bool) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("NetworkDisconnect"); err != nil {
+		return err
+	}
+	n, err := f.lookupNetwork(networkID)
+	if err != nil {
+		return err
+	}
+	c, err := f.lookup(containerID)
+	if err != nil {
+		return err
+	}
+	endpoints := c.endpoints()
+	if _, ok := endpoints[n.Name]; !ok {
+		return errdefs.Forbidden(fmt.Errorf("container %s is not connected to network %s", c.Summary.ID[:12], n.Name))
+	}
+	merged := make(map[string]*network.EndpointSettings, len(endpoints))
+	for k, v := range endpoints {
+		if k != n.Name {
+			merged[k] = v
+		}
+	}
+	if len(merged) == 0 {
+		// 断开最后一个网络后容器只剩回环地址，等价于 none
+		merged["none"] = &network.EndpointSettings{}
+	}
+	c.NetworkingConfig = &network.NetworkingConfig{EndpointsConfig: merged}
+	f.emit(events.NetworkEventType, "disconnect", n.ID, map[string]string{"name": n.Name, "type": n.Driver, "container": c.Summary.ID})
+	return nil
+}
@@ -0,0 +1,69 @@
+package services
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/docker/go-connections/nat"
+)
+
+// fakeFirstPort 随机分配宿主机端口的起点，与 Docker 的临时端口范围一致
+const fakeFirstPort = 49153
+
+// publishPorts 按 PortBindings 发布端口：HostPort 为空时分配随机端口，与其他运行中容器占用的端口冲突时
+// 与 Docker 一样启动失败；调用方需持有锁
+func (f *FakeDockerService) publishPorts(c *FakeContainer) error {
+	used := make(map[string]string) // proto/端口 → 占用的容器名
+	for _, other := range f.containers {
+		if other == c || !other.running() {
+			continue
+		}
+		for port, bindings := range other.ports {
+			for _, b := range bindings {
+				used[port.Proto()+"/"+b.HostPort] = other.name()
+			}
+		}
+	}
+
+	ports := nat.PortMap{}
+	for port, bindings := range c.HostConfig.PortBindings {
+		for _, b := range bindings {
+			if b.HostPort == "" {
+				f.lastPort = max(f.lastPort, fakeFirstPort-1)
+				for f.lastPort++; used[port.Proto()+"/"+strconv.Itoa(f.lastPort)] != ""; f.lastPort++ {
+				}
+				b.HostPort = strconv.Itoa(f.lastPort)
+			} else if owner := used[port.Proto()+"/"+b.HostPort]; owner != "" {
+				hostIP := b.HostIP
+				if hostIP == "" {
+					hostIP = "0.0.0.0"
+				}
+				return fmt.Errorf("driver failed programming external connectivity on endpoint %s: Bind for %s:%s failed: port is already allocated (used by %s)",
+					c.name(), hostIP, b.HostPort, owner)
+			}
+			used[port.Proto()+"/"+b.HostPort] = c.name()
+			ports[port] = append(ports[port], b)
+		}
+	}
+	c.ports = ports
+	return nil
+}
+
+// publishedPorts 运行中容器实际发布的端口，与 docker inspect 的 NetworkSettings.Ports 一致
+func (c *FakeContainer) publishedPorts() nat.PortMap {
+	if !c.running() || len(c.ports) == 0 {
+		return nil
+	}
+	return c.ports
+}
+
+func (c *FakeContainer) running() bool {
+	return c.Summary.State == "running" || c.Summary.State == "paused"
+}
+
+func (c *FakeContainer) name() string {
+	if len(c.Summary.Names) == 0 {
+		return c.Summary.ID
+	}
+	return c.Summary.Names[0][1:]
+}
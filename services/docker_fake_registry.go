@@ -0,0 +1,87 @@
+package services
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/registry"
+	"github.com/docker/docker/errdefs"
+	digest "github.com/opencontainers/go-digest"
+	specs "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// PushImage 模拟向镜像仓库推送 ref 的新版本，返回新的清单摘要；
+// 之后 DistributionInspect 返回该摘要，ImagePull 拉取到一个新镜像并把标签从旧镜像上移走
+func (f *FakeDockerService) PushImage(ref string) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	d := "sha256:" + f.nextID()
+	f.remote[NormalizeImageRef(ref)] = d
+	return d
+}
+
+// registryDigest 返回仓库中 ref 当前的摘要，仓库中还没有该标签时登记一个；调用方需持有锁
+func (f *FakeDockerService) registryDigest(ref string) string {
+	tag := NormalizeImageRef(ref)
+	d, ok := f.remote[tag]
+	if !ok {
+		d = "sha256:" + f.nextID()
+		f.remote[tag] = d
+	}
+	return d
+}
+
+// repoDigest 拼出 RepoDigests 中的一项：去掉标签的仓库名@摘要
+func repoDigest(ref, d string) string {
+	name := NormalizeImageRef(ref)
+	if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		name = name[:i]
+	}
+	return name + "@" + d
+}
+
+// pullFromRegistry 按仓库中的当前摘要拉取：本地标签已是该版本时直接返回，否则创建新镜像并移动标签；调用方需持有锁
+func (f *FakeDockerService) pullFromRegistry(ref string) string {
+	tag := NormalizeImageRef(ref)
+	img, err := f.lookupImage(tag)
+	if err != nil {
+		return f.addImage(ref)
+	}
+	want := repoDigest(tag, f.registryDigest(tag))
+	for _, d := range img.RepoDigests {
+		if d == want {
+			return img.ID
+		}
+	}
+	id := f.addImage("")
+	f.tagImage(f.images[id], tag)
+	f.images[id].RepoDigests = []string{want}
+	return id
+}
+
+func (f *FakeDockerService) DistributionInspect(ctx context.Context, image, encodedRegistryAuth string) (registry.DistributionInspect, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("DistributionInspect"); err != nil {
+		return registry.DistributionInspect{}, err
+	}
+	var auth types.AuthConfig
+	if data, err := base64.URLEncoding.DecodeString(encodedRegistryAuth); err == nil {
+		json.Unmarshal(data, &auth)
+	}
+	if err := f.checkRegistryAuth(RegistryHost(image), auth); err != nil {
+		return registry.DistributionInspect{}, err
+	}
+	d, ok := f.remote[NormalizeImageRef(image)]
+	if !ok {
+		return registry.DistributionInspect{}, errdefs.NotFound(fmt.Errorf("manifest unknown: %s", image))
+	}
+	return registry.DistributionInspect{
+		Descriptor: specs.Descriptor{MediaType: "application/vnd.docker.distribution.manifest.list.v2+json", Digest: digest.Digest(d)},
+		Platforms:  []specs.Platform{{Architecture: "amd64", OS: "linux"}},
+	}, nil
+}
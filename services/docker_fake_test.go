@@ -0,0 +1,74 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/errdefs"
+)
+
+func TestFakeLookup(t *testing.T) {
+	f := NewFakeDockerService()
+	web := f.AddContainer(types.Container{ID: "abc111", Names: []string{"/web"}})
+	db := f.AddContainer(types.Container{ID: "abc222", Names: []string{"/db"}})
+	// 名称恰好是另一个容器 ID 的前缀时按名称匹配
+	named := f.AddContainer(types.Container{ID: "fff000", Names: []string{"/abc"}})
+
+	tests := []struct {
+		ref     string
+		want    string
+		errFunc func(error) bool
+	}{
+		{ref: "abc111", want: web},
+		{ref: "web", want: web},
+		{ref: "/db", want: db},
+		{ref: "abc2", want: db},
+		{ref: "abc", want: named},
+		{ref: "ab", errFunc: errdefs.IsSystem},
+		{ref: "", errFunc: errdefs.IsInvalidParameter},
+		{ref: "zzz", errFunc: errdefs.IsNotFound},
+	}
+	for _, tt := range tests {
+		c, err := f.lookup(tt.ref)
+		if tt.errFunc != nil {
+			if err == nil || !tt.errFunc(err) {
+				t.Errorf("lookup(%q) error = %v", tt.ref, err)
+			}
+			continue
+		}
+		if err != nil || c.Summary.ID != tt.want {
+			t.Errorf("lookup(%q) = %v, %v, want %s", tt.ref, c, err, tt.want)
+		}
+	}
+}
+
+func TestFakeAmbiguousPrefix(t *testing.T) {
+	f := NewFakeDockerService()
+	f.AddContainer(types.Container{ID: "abc111", Names: []string{"/web"}})
+	f.AddContainer(types.Container{ID: "abc222", Names: []string{"/db"}})
+
+	_, err := f.ContainerInspect(context.Background(), "abc")
+	if err == nil || !strings.Contains(err.Error(), "multiple IDs found") {
+		t.Errorf("inspect ambiguous prefix: %v", err)
+	}
+	if err := f.ContainerStop(context.Background(), "", nil); !errdefs.IsInvalidParameter(err) {
+		t.Errorf("stop empty ref: %v", err)
+	}
+}
+
+func TestFakeNameConflict(t *testing.T) {
+	f := NewFakeDockerService()
+	f.AddImage("nginx:latest")
+	f.AddContainer(types.Container{ID: "abc111", Names: []string{"/web"}})
+
+	// 与已有 ID 前缀相同的名称不算冲突
+	if err := f.ContainerRename(context.Background(), "web", "abc"); err != nil {
+		t.Errorf("rename to id prefix: %v", err)
+	}
+	f.AddContainer(types.Container{ID: "def222", Names: []string{"/db"}})
+	if err := f.ContainerRename(context.Background(), "db", "abc"); !errdefs.IsConflict(err) {
+		t.Errorf("rename to existing name: %v", err)
+	}
+}
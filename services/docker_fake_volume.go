@@ -0,0 +1,206 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/events"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/volume"
+	"github.com/docker/docker/errdefs"
+)
+
+// AddVolume 预置一个 local 数据卷，size 为占用的磁盘字节数
+func (f *FakeDockerService) AddVolume(name string, labels map[string]string, size int64) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	v := f.addVolume(volume.VolumeCreateBody{Name: name, Labels: labels})
+	v.UsageData.Size = size
+}
+
+func (f *FakeDockerService) addVolume(options volume.VolumeCreateBody) *types.Volume {
+	if options.Name == "" {
+		options.Name = f.nextID()
+	}
+	if options.Driver == "" {
+		options.Driver = "local"
+	}
+	if v, ok := f.volumes[options.Name]; ok {
+		return v
+	}
+	v := &types.Volume{
+		Name:       options.Name,
+		Driver:     options.Driver,
+		Labels:     options.Labels,
+		Options:    options.DriverOpts,
+		Mountpoint: "/var/lib/docker/volumes/" + options.Name + "/_data",
+		Scope:      "local",
+		CreatedAt:  time.Now().UTC().Format(time.RFC3339),
+		UsageData:  &types.VolumeUsageData{RefCount: 0},
+	}
+	f.volumes[v.Name] = v
+	return v
+}
+
+// volumeRefs 返回引用该数据卷的容器数
+func (f *FakeDockerService) volumeRefs(name string) int {
+	n := 0
+	for _, c := range f.containers {
+		for _, m := range c.inspect().Mounts {
+			if m.Type == "volume" && m.Name == name {
+				n++
+				break
+			}
+		}
+	}
+	return n
+}
+
+// volumeSize 预置的大小加上数据卷内文件的大小
+func (f *FakeDockerService) volumeSize(v *types.Volume) int64 {
+	size := v.UsageData.Size
+	for _, file := range f.volumeFiles[v.Name] {
+		size += int64(len(file.data))
+	}
+	return size
+}
+
+// withUsage 复制数据卷并填入引用计数，与 docker system df 一致
+func (f *FakeDockerService) withUsage(v *types.Volume) *types.Volume {
+	cp := *v
+	cp.UsageData = &types.VolumeUsageData{RefCount: int64(f.volumeRefs(v.Name)), Size: f.volumeSize(v)}
+	return &cp
+}
+
+func (f *FakeDockerService) VolumeList(ctx context.Context, filter filters.Args) (volume.VolumeListOKBody, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("VolumeList"); err != nil {
+		return volume.VolumeListOKBody{}, err
+	}
+	body := volume.VolumeListOKBody{Volumes: []*types.Volume{}}
+	for _, v := range f.volumes {
+		if filter.Contains("name") && !filter.Match("name", v.Name) {
+			continue
+		}
+		if filter.Contains("driver") && !filter.ExactMatch("driver", v.Driver) {
+			continue
+		}
+		if !filter.MatchKVList("label", v.Labels) {
+			continue
+		}
+		if filter.Contains("dangling") {
+			dangling := f.volumeRefs(v.Name) == 0
+			if filter.ExactMatch("dangling", "true") != dangling {
+				continue
+			}
+		}
+		cp := *v
+		cp.UsageData = nil // 列表接口不返回用量，需通过 DiskUsage 获取
+		body.Volumes = append(body.Volumes, &cp)
+	}
+	sort.Slice(body.Volumes, func(i, j int) bool { return body.Volumes[i].Name < body.Volumes[j].Name })
+	return body, nil
+}
+
+func (f *FakeDockerService) VolumeInspect(ctx context.Context, volumeID string) (types.Volume, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("VolumeInspect"); err != nil {
+		return types.Volume{}, err
+	}
+	v, ok := f.volumes[volumeID]
+	if !ok {
+		return types.Volume{}, errdefs.NotFound(fmt.Errorf("get %s: no such volume", volumeID))
+	}
+	cp := *v
+	cp.UsageData = nil
+	return cp, nil
+}
+
+func (f *FakeDockerService) VolumeCreate(ctx context.Context, options volume.VolumeCreateBody) (types.Volume, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("VolumeCreate"); err != nil {
+		return types.Volume{}, err
+	}
+	// 与 Docker 一致：同名同驱动的数据卷已存在时直接返回原有数据卷
+	if v, ok := f.volumes[options.Name]; ok && options.Driver != "" && options.Driver != v.Driver {
+		return types.Volume{}, errdefs.Conflict(fmt.Errorf("volume name %s already in use with driver %s", v.Name, v.Driver))
+	}
+	_, exists := f.volumes[options.Name]
+	v := f.addVolume(options)
+	if !exists {
+		f.emit(events.VolumeEventType, "create", v.Name, map[string]string{"driver": v.Driver})
+	}
+	cp := *v
+	cp.UsageData = nil
+	return cp, nil
+}
+
+func (f *FakeDockerService) VolumeRemove(ctx context.Context, volumeID string, force bool) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("VolumeRemove"); err != nil {
+		return err
+	}
+	v, ok := f.volumes[volumeID]
+	if !ok {
+		if force {
+			return nil
+		}
+		return errdefs.NotFound(fmt.Errorf("get %s: no such volume", volumeID))
+	}
+	if f.volumeRefs(v.Name) > 0 {
+		return errdefs.Conflict(fmt.Errorf("remove %s: volume is in use", v.Name))
+	}
+	delete(f.volumes, v.Name)
+	delete(f.volumeFiles, v.Name)
+	f.emit(events.VolumeEventType, "destroy", v.Name, map[string]string{"driver": v.Driver})
+	return nil
+}
+
+func (f *FakeDockerService) VolumesPrune(ctx context.Context, pruneFilter filters.Args) (types.VolumesPruneReport, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("VolumesPrune"); err != nil {
+		return types.VolumesPruneReport{}, err
+	}
+	report := types.VolumesPruneReport{}
+	for name, v := range f.volumes {
+		if f.volumeRefs(name) > 0 || !pruneFilter.MatchKVList("label", v.Labels) {
+			continue
+		}
+		report.VolumesDeleted = append(report.VolumesDeleted, name)
+		report.SpaceReclaimed += uint64(f.volumeSize(v))
+		delete(f.volumes, name)
+		delete(f.volumeFiles, name)
+		f.emit(events.VolumeEventType, "destroy", name, map[string]string{"driver": v.Driver})
+	}
+	sort.Strings(report.VolumesDeleted)
+	return report, nil
+}
+
+func (f *FakeDockerService) DiskUsage(ctx context.Context) (types.DiskUsage, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("DiskUsage"); err != nil {
+		return types.DiskUsage{}, err
+	}
+	du := types.DiskUsage{}
+	for _, img := range f.images {
+		du.Images = append(du.Images, &types.ImageSummary{ID: img.ID, RepoTags: img.RepoTags, Size: img.Size, Containers: int64(f.imageInUse(img.ID))})
+		du.LayersSize += img.Size
+	}
+	for _, c := range f.containers {
+		summary := c.Summary
+		du.Containers = append(du.Containers, &summary)
+	}
+	for _, v := range f.volumes {
+		du.Volumes = append(du.Volumes, f.withUsage(v))
+	}
+	return du, nil
+}
@@ -0,0 +1,101 @@
+package services
+
+import (
+	"auto-deploy-platform/config"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrUnknownHost 请求的 Docker 主机未在配置中登记
+var ErrUnknownHost = errors.New("unknown docker host")
+
+// DockerFactory 根据主机配置创建 DockerService，测试时可返回 FakeDockerService
+type DockerFactory func(host config.DockerHostConfig) (DockerService, error)
+
+// HostRegistry 多主机 Docker 服务注册表，每个主机只创建一个客户端并缓存复用
+type HostRegistry struct {
+	mu          sync.Mutex
+	hosts       []config.DockerHostConfig
+	defaultHost string
+	factory     DockerFactory
+	clients     map[string]DockerService
+}
+
+// NewHostRegistry 根据配置创建注册表，未配置任何主机时自动登记本机 local
+func NewHostRegistry(cfg config.DockerConfig, factory DockerFactory) *HostRegistry {
+	hosts := cfg.Hosts
+	if len(hosts) == 0 {
+		hosts = []config.DockerHostConfig{{ID: "local", Name: "Localhost"}}
+	}
+	defaultHost := cfg.DefaultHost
+	if defaultHost == "" {
+		defaultHost = hosts[0].ID
+	}
+	if factory == nil {
+		factory = NewDockerServiceForHost
+	}
+	return &HostRegistry{
+		hosts:       hosts,
+		defaultHost: defaultHost,
+		factory:     factory,
+		clients:     make(map[string]DockerService),
+	}
+}
+
+// Hosts 返回已登记的主机列表
+func (r *HostRegistry) Hosts() []config.DockerHostConfig {
+	return r.hosts
+}
+
+// DefaultHost 返回默认主机 ID
+func (r *HostRegistry) DefaultHost() string {
+	return r.defaultHost
+}
+
+// Host 按 ID 查找主机配置，ID 为空时返回默认主机
+func (r *HostRegistry) Host(id string) (config.DockerHostConfig, error) {
+	if id == "" {
+		id = r.defaultHost
+	}
+	for _, h := range r.hosts {
+		if h.ID == id {
+			return h, nil
+		}
+	}
+	return config.DockerHostConfig{}, fmt.Errorf("%w: %s", ErrUnknownHost, id)
+}
+
+// Get 返回指定主机的 DockerService，首次访问时创建并缓存
+func (r *HostRegistry) Get(id string) (DockerService, error) {
+	host, err := r.Host(id)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if svc, ok := r.clients[host.ID]; ok {
+		return svc, nil
+	}
+	svc, err := r.factory(host)
+	if err != nil {
+		return nil, fmt.Errorf("connect docker host %s: %w", host.ID, err)
+	}
+	r.clients[host.ID] = svc
+	return svc, nil
+}
+
+// Close 关闭所有已创建的客户端
+func (r *HostRegistry) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var errs []error
+	for id, svc := range r.clients {
+		if err := svc.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", id, err))
+		}
+		delete(r.clients, id)
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,53 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/pkg/jsonmessage"
+)
+
+// NormalizeImageRef 未带标签或摘要的镜像名补全为 :latest
+func NormalizeImageRef(ref string) string {
+	ref = strings.TrimSpace(ref)
+	if strings.Contains(ref, "@") || strings.LastIndex(ref, ":") > strings.LastIndex(ref, "/") {
+		return ref
+	}
+	return ref + ":latest"
+}
+
+// PullImage 拉取镜像并逐条回调 Docker 返回的进度消息，ctx 取消时中止拉取
+func PullImage(ctx context.Context, svc DockerService, ref string, options types.ImagePullOptions, progress func(jsonmessage.JSONMessage)) error {
+	out, err := svc.ImagePull(ctx, ref, options)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	dec := json.NewDecoder(out)
+	for {
+		var msg jsonmessage.JSONMessage
+		if err := dec.Decode(&msg); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		if msg.Error != nil {
+			return msg.Error
+		}
+		if msg.ErrorMessage != "" {
+			return errors.New(msg.ErrorMessage)
+		}
+		if progress != nil {
+			progress(msg)
+		}
+	}
+}
@@ -0,0 +1,329 @@
+package services
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/errdefs"
+	"golang.org/x/crypto/scrypt"
+)
+
+// DefaultRegistry Docker Hub 的规范主机名，未带仓库地址的镜像均从此拉取
+const DefaultRegistry = "docker.io"
+
+// RegistryCredential 单个镜像仓库的登录凭据
+type RegistryCredential struct {
+	Registry  string    `json:"registry"`
+	Username  string    `json:"username"`
+	Password  string    `json:"password"`
+	Token     string    `json:"token"` // 仓库签发的 identity token，设置后优先于密码
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// AuthConfig 转换为 Docker API 使用的认证信息
+func (c RegistryCredential) AuthConfig() types.AuthConfig {
+	return types.AuthConfig{
+		Username:      c.Username,
+		Password:      c.Password,
+		IdentityToken: c.Token,
+		ServerAddress: c.Registry,
+	}
+}
+
+// CredentialStore 镜像仓库凭据存储，整个文件使用 AES-GCM 加密后落盘，密钥由口令经 scrypt 派生
+type CredentialStore struct {
+	mu    sync.RWMutex
+	path  string
+	aead  cipher.AEAD // 未配置口令时为 nil，只能匿名拉取镜像，不能保存凭据
+	salt  []byte
+	creds map[string]RegistryCredential
+}
+
+// 凭据文件格式：credentialsMagic | salt | nonce | 密文
+var credentialsMagic = []byte("ADPCRED2")
+
+const credentialsSaltSize = 16
+
+// ErrNoSecretKey 未配置加密口令时保存凭据返回的错误
+var ErrNoSecretKey = errdefs.Forbidden(errors.New("registry credentials are disabled: set registry.secret_key or ADP_REGISTRY_SECRET_KEY"))
+
+// NewCredentialStore 打开凭据文件，文件不存在时视为空。
+// 口令不与凭据文件放在一起，secret 为空时不能保存凭据；已有凭据文件却没有口令时返回错误
+func NewCredentialStore(path, secret string) (*CredentialStore, error) {
+	s := &CredentialStore{path: path, creds: make(map[string]RegistryCredential)}
+	if secret == "" {
+		if _, err := os.Stat(path); err == nil {
+			return nil, fmt.Errorf("registry credentials file %s exists but no secret key is configured", path)
+		}
+		log.Printf("⚠️ 未配置 registry.secret_key / ADP_REGISTRY_SECRET_KEY，无法保存私有仓库凭据")
+		return s, nil
+	}
+	if err := s.load(secret); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Enabled 是否已配置口令、可以保存凭据
+func (s *CredentialStore) Enabled() bool {
+	return s.aead != nil
+}
+
+// List 返回全部凭据，按仓库地址排序
+func (s *CredentialStore) List() []RegistryCredential {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	list := make([]RegistryCredential, 0, len(s.creds))
+	for _, c := range s.creds {
+		list = append(list, c)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Registry < list[j].Registry })
+	return list
+}
+
+// Get 按仓库地址查找凭据
+func (s *CredentialStore) Get(registry string) (RegistryCredential, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	c, ok := s.creds[NormalizeRegistry(registry)]
+	return c, ok
+}
+
+// Create 新增凭据，同一仓库已存在时返回 Conflict
+func (s *CredentialStore) Create(cred RegistryCredential) (RegistryCredential, error) {
+	return s.save(cred, false)
+}
+
+// Update 更新已有凭据，不存在时返回 NotFound；密码和 token 留空表示保持原值
+func (s *CredentialStore) Update(cred RegistryCredential) (RegistryCredential, error) {
+	return s.save(cred, true)
+}
+
+// Delete 删除凭据
+func (s *CredentialStore) Delete(registry string) error {
+	registry = NormalizeRegistry(registry)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	old, ok := s.creds[registry]
+	if !ok {
+		return errdefs.NotFound(fmt.Errorf("no credential for registry %s", registry))
+	}
+	delete(s.creds, registry)
+	if err := s.flush(); err != nil {
+		s.creds[registry] = old
+		return err
+	}
+	return nil
+}
+
+// AuthFor 按镜像名所在的仓库查找认证信息，没有匹配的凭据时返回匿名认证
+func (s *CredentialStore) AuthFor(ref string) (types.AuthConfig, bool) {
+	if c, ok := s.Get(RegistryHost(ref)); ok {
+		return c.AuthConfig(), true
+	}
+	return types.AuthConfig{}, false
+}
+
+// DockerConfigJSON 生成 docker CLI 格式的 config.json，供 docker-compose 等外部命令拉取私有镜像
+func (s *CredentialStore) DockerConfigJSON() ([]byte, error) {
+	type authEntry struct {
+		Auth          string `json:"auth,omitempty"`
+		IdentityToken string `json:"identitytoken,omitempty"`
+	}
+	auths := make(map[string]authEntry)
+	for _, c := range s.List() {
+		key := c.Registry
+		if key == DefaultRegistry {
+			key = "https://index.docker.io/v1/" // docker CLI 以该地址作为 Docker Hub 的凭据键
+		}
+		entry := authEntry{IdentityToken: c.Token}
+		if c.Password != "" {
+			entry.Auth = base64.StdEncoding.EncodeToString([]byte(c.Username + ":" + c.Password))
+		}
+		auths[key] = entry
+	}
+	return json.Marshal(map[string]interface{}{"auths": auths})
+}
+
+func (s *CredentialStore) save(cred RegistryCredential, update bool) (RegistryCredential, error) {
+	if !s.Enabled() {
+		return RegistryCredential{}, ErrNoSecretKey
+	}
+	cred.Registry = NormalizeRegistry(cred.Registry)
+	if cred.Registry == "" {
+		return RegistryCredential{}, errdefs.InvalidParameter(errors.New("registry is required"))
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	old, exists := s.creds[cred.Registry]
+	switch {
+	case update && !exists:
+		return RegistryCredential{}, errdefs.NotFound(fmt.Errorf("no credential for registry %s", cred.Registry))
+	case !update && exists:
+		return RegistryCredential{}, errdefs.Conflict(fmt.Errorf("credential for registry %s already exists", cred.Registry))
+	}
+	if update {
+		if cred.Password == "" {
+			cred.Password = old.Password
+		}
+		if cred.Token == "" {
+			cred.Token = old.Token
+		}
+	}
+	if cred.Password == "" && cred.Token == "" {
+		return RegistryCredential{}, errdefs.InvalidParameter(errors.New("password or token is required"))
+	}
+	cred.UpdatedAt = time.Now()
+
+	s.creds[cred.Registry] = cred
+	if err := s.flush(); err != nil {
+		if exists {
+			s.creds[cred.Registry] = old
+		} else {
+			delete(s.creds, cred.Registry)
+		}
+		return RegistryCredential{}, err
+	}
+	return cred, nil
+}
+
+// load 读取并解密凭据文件，文件不存在时生成新的 salt
+func (s *CredentialStore) load(secret string) error {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s.deriveKey(secret, nil)
+	}
+	if err != nil {
+		return err
+	}
+
+	if !bytes.HasPrefix(data, credentialsMagic) {
+		return fmt.Errorf("registry credentials file %s has an unknown format", s.path)
+	}
+	data = data[len(credentialsMagic):]
+	if len(data) < credentialsSaltSize {
+		return fmt.Errorf("registry credentials file %s is corrupted", s.path)
+	}
+	if err := s.deriveKey(secret, data[:credentialsSaltSize]); err != nil {
+		return err
+	}
+	data = data[credentialsSaltSize:]
+
+	size := s.aead.NonceSize()
+	if len(data) < size {
+		return fmt.Errorf("registry credentials file %s is corrupted", s.path)
+	}
+	plain, err := s.aead.Open(nil, data[:size], data[size:], nil)
+	if err != nil {
+		return fmt.Errorf("decrypt registry credentials %s (wrong secret key?): %w", s.path, err)
+	}
+	var list []RegistryCredential
+	if err := json.Unmarshal(plain, &list); err != nil {
+		return fmt.Errorf("parse registry credentials %s: %w", s.path, err)
+	}
+	for _, c := range list {
+		s.creds[c.Registry] = c
+	}
+	return nil
+}
+
+// deriveKey 用 scrypt 由口令和 salt 派生 AES-256 密钥，salt 为空时随机生成
+func (s *CredentialStore) deriveKey(secret string, salt []byte) error {
+	if salt == nil {
+		salt = make([]byte, credentialsSaltSize)
+		if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+			return err
+		}
+	}
+	key, err := scrypt.Key([]byte(secret), salt, 1<<15, 8, 1, 32)
+	if err != nil {
+		return err
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return err
+	}
+	s.aead, s.salt = aead, salt
+	return nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// flush 加密后先写临时文件再改名，避免写到一半时进程退出损坏凭据文件；调用方需持有写锁
+func (s *CredentialStore) flush() error {
+	list := make([]RegistryCredential, 0, len(s.creds))
+	for _, c := range s.creds {
+		list = append(list, c)
+	}
+	plain, err := json.Marshal(list)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, s.aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return err
+	}
+	header := append(append([]byte{}, credentialsMagic...), s.salt...)
+	data := s.aead.Seal(append(header, nonce...), nonce, plain, nil)
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return err
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path)
+}
+
+// NormalizeRegistry 统一仓库地址写法：去掉协议和路径，Docker Hub 的各种别名归一为 docker.io
+func NormalizeRegistry(registry string) string {
+	registry = strings.ToLower(strings.TrimSpace(registry))
+	registry = strings.TrimPrefix(registry, "https://")
+	registry = strings.TrimPrefix(registry, "http://")
+	if i := strings.Index(registry, "/"); i >= 0 {
+		registry = registry[:i]
+	}
+	switch registry {
+	case "index.docker.io", "registry-1.docker.io", "registry.hub.docker.com":
+		return DefaultRegistry
+	}
+	return registry
+}
+
+// RegistryHost 返回镜像所在的仓库地址，规则与 docker 一致：
+// 第一段包含 "." 或 ":"，或者为 localhost 时视为仓库地址，否则为 Docker Hub
+func RegistryHost(ref string) string {
+	ref = strings.TrimSpace(ref)
+	i := strings.Index(ref, "/")
+	if i < 0 {
+		return DefaultRegistry
+	}
+	first := ref[:i]
+	if strings.ContainsAny(first, ".:") || first == "localhost" {
+		return NormalizeRegistry(first)
+	}
+	return DefaultRegistry
+}
@@ -0,0 +1,98 @@
+package services
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/docker/docker/errdefs"
+)
+
+func TestCredentialStoreRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "creds.enc")
+	s, err := NewCredentialStore(path, "passphrase")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Create(RegistryCredential{Registry: "https://Registry.example.com/v2", Username: "deploy", Password: "s3cret"}); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.HasPrefix(data, credentialsMagic) || bytes.Contains(data, []byte("s3cret")) || bytes.Contains(data, []byte("deploy")) {
+		t.Errorf("credentials file is not encrypted in the expected format")
+	}
+
+	reopened, err := NewCredentialStore(path, "passphrase")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c, ok := reopened.Get("registry.example.com"); !ok || c.Password != "s3cret" {
+		t.Errorf("reopened credential = %+v, %v", c, ok)
+	}
+	if _, err := NewCredentialStore(path, "wrong"); err == nil {
+		t.Error("opened credentials with the wrong secret")
+	}
+}
+
+func TestCredentialStoreSaltPerFile(t *testing.T) {
+	dir := t.TempDir()
+	var salts [][]byte
+	for _, name := range []string{"a.enc", "b.enc"} {
+		s, err := NewCredentialStore(filepath.Join(dir, name), "same-secret")
+		if err != nil {
+			t.Fatal(err)
+		}
+		salts = append(salts, s.salt)
+	}
+	if bytes.Equal(salts[0], salts[1]) {
+		t.Error("two credential files share the same salt")
+	}
+}
+
+func TestCredentialStoreWithoutSecret(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "creds.enc")
+
+	s, err := NewCredentialStore(path, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Enabled() {
+		t.Error("store without secret reports enabled")
+	}
+	if _, err := s.Create(RegistryCredential{Registry: "docker.io", Username: "u", Password: "p"}); !errdefs.IsForbidden(err) {
+		t.Errorf("create without secret: %v", err)
+	}
+	if auth, ok := s.AuthFor("nginx:latest"); ok || auth.Username != "" {
+		t.Errorf("auth without secret = %+v", auth)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("credentials file created without secret: %v", err)
+	}
+
+	// 已有凭据文件时必须配置口令
+	if err := os.WriteFile(path, []byte("data"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewCredentialStore(path, ""); err == nil {
+		t.Error("opened existing credentials without secret")
+	}
+}
+
+func TestCredentialStoreRejectsUnknownFormat(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "creds.enc")
+	if err := os.WriteFile(path, []byte("not a credentials file"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewCredentialStore(path, "passphrase"); err == nil {
+		t.Error("opened a credentials file without the format header")
+	}
+	if data, _ := os.ReadFile(path); string(data) != "not a credentials file" {
+		t.Error("credentials file was rewritten")
+	}
+}
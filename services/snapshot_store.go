@@ -0,0 +1,137 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/errdefs"
+)
+
+// ContainerSnapshot 容器快照：commit 生成的镜像加上快照时的容器配置，回滚时据此重建容器
+type ContainerSnapshot struct {
+	ID          string                               `json:"id"`
+	Host        string                               `json:"host"` // 快照镜像只存在于该 Docker 主机
+	Container   string                               `json:"container"`
+	ContainerID string                               `json:"container_id"`
+	Image       string                               `json:"image"` // 快照镜像标签
+	ImageID     string                               `json:"image_id"`
+	SourceImage string                               `json:"source_image"` // 快照时容器使用的镜像
+	Note        string                               `json:"note"`
+	Config      *container.Config                    `json:"config"`
+	HostConfig  *container.HostConfig                `json:"host_config"`
+	Networks    map[string]*network.EndpointSettings `json:"networks"`
+	CreatedAt   time.Time                            `json:"created_at"`
+}
+
+// SnapshotStore 容器快照记录，整体保存在一个 JSON 文件中
+type SnapshotStore struct {
+	mu        sync.RWMutex
+	path      string
+	snapshots map[string]ContainerSnapshot
+}
+
+// NewSnapshotStore 打开快照记录文件，文件不存在时视为空
+func NewSnapshotStore(path string) (*SnapshotStore, error) {
+	s := &SnapshotStore{path: path, snapshots: make(map[string]ContainerSnapshot)}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var list []ContainerSnapshot
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, fmt.Errorf("parse snapshots %s: %w", path, err)
+	}
+	for _, snap := range list {
+		s.snapshots[snap.ID] = snap
+	}
+	return s, nil
+}
+
+// List 返回快照，host、container 非空时按其过滤，最新的在前
+func (s *SnapshotStore) List(host, containerName string) []ContainerSnapshot {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	list := []ContainerSnapshot{}
+	for _, snap := range s.snapshots {
+		if (host != "" && snap.Host != host) || (containerName != "" && snap.Container != containerName) {
+			continue
+		}
+		list = append(list, snap)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].CreatedAt.After(list[j].CreatedAt) })
+	return list
+}
+
+// Get 按 ID 查找快照
+func (s *SnapshotStore) Get(id string) (ContainerSnapshot, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	snap, ok := s.snapshots[id]
+	if !ok {
+		return ContainerSnapshot{}, errdefs.NotFound(fmt.Errorf("snapshot %s not found", id))
+	}
+	return snap, nil
+}
+
+// Add 保存快照记录
+func (s *SnapshotStore) Add(snap ContainerSnapshot) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.snapshots[snap.ID]; ok {
+		return errdefs.Conflict(fmt.Errorf("snapshot %s already exists", snap.ID))
+	}
+	s.snapshots[snap.ID] = snap
+	if err := s.flush(); err != nil {
+		delete(s.snapshots, snap.ID)
+		return err
+	}
+	return nil
+}
+
+// Delete 删除快照记录
+func (s *SnapshotStore) Delete(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	old, ok := s.snapshots[id]
+	if !ok {
+		return errdefs.NotFound(fmt.Errorf("snapshot %s not found", id))
+	}
+	delete(s.snapshots, id)
+	if err := s.flush(); err != nil {
+		s.snapshots[id] = old
+		return err
+	}
+	return nil
+}
+
+// flush 先写临时文件再改名；调用方需持有写锁
+func (s *SnapshotStore) flush() error {
+	list := make([]ContainerSnapshot, 0, len(s.snapshots))
+	for _, snap := range s.snapshots {
+		list = append(list, snap)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].CreatedAt.Before(list[j].CreatedAt) })
+	data, err := json.MarshalIndent(list, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o750); err != nil {
+		return err
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o640); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path)
+}
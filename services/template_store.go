@@ -0,0 +1,186 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/errdefs"
+)
+
+// 模板保存的创建容器请求格式
+const (
+	TemplateFormatV1 = "v1" // /api/v1/container/create，逗号分隔字符串
+	TemplateFormatV2 = "v2" // /api/v2/container/create，结构化请求
+)
+
+// ContainerTemplate 容器模板的一个版本：创建容器请求体，字符串中可包含 ${NAME} 占位符
+type ContainerTemplate struct {
+	Name        string              `json:"name"`
+	Version     int                 `json:"version"`
+	Description string              `json:"description"`
+	Format      string              `json:"format"`
+	Payload     json.RawMessage     `json:"payload"`
+	Parameters  []TemplateParameter `json:"parameters"`
+	CreatedAt   time.Time           `json:"created_at"`
+}
+
+// TemplateParameter 模板参数，Required 时实例化必须提供，否则未提供时使用 Default（可以为空）
+type TemplateParameter struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Default     string `json:"default,omitempty"`
+	Required    bool   `json:"required"`
+}
+
+// TemplateStore 容器模板，每个模板保留全部历史版本，整体保存在一个 JSON 文件中
+type TemplateStore struct {
+	mu        sync.RWMutex
+	path      string
+	templates map[string][]ContainerTemplate // 模板名 → 各版本，按版本号升序
+}
+
+// NewTemplateStore 打开模板文件，文件不存在时视为空
+func NewTemplateStore(path string) (*TemplateStore, error) {
+	s := &TemplateStore{path: path, templates: make(map[string][]ContainerTemplate)}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var list []ContainerTemplate
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, fmt.Errorf("parse templates %s: %w", path, err)
+	}
+	for _, t := range list {
+		s.templates[t.Name] = append(s.templates[t.Name], t)
+	}
+	for _, versions := range s.templates {
+		sort.Slice(versions, func(i, j int) bool { return versions[i].Version < versions[j].Version })
+	}
+	return s, nil
+}
+
+// List 返回每个模板的最新版本，按名称排序
+func (s *TemplateStore) List() []ContainerTemplate {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	list := make([]ContainerTemplate, 0, len(s.templates))
+	for _, versions := range s.templates {
+		list = append(list, versions[len(versions)-1])
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list
+}
+
+// Get 返回模板的指定版本，version 为 0 时返回最新版本
+func (s *TemplateStore) Get(name string, version int) (ContainerTemplate, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	versions, ok := s.templates[name]
+	if !ok {
+		return ContainerTemplate{}, errdefs.NotFound(fmt.Errorf("template %s not found", name))
+	}
+	if version == 0 {
+		return versions[len(versions)-1], nil
+	}
+	for _, t := range versions {
+		if t.Version == version {
+			return t, nil
+		}
+	}
+	return ContainerTemplate{}, errdefs.NotFound(fmt.Errorf("template %s has no version %d", name, version))
+}
+
+// Versions 返回模板的全部版本号，升序
+func (s *TemplateStore) Versions(name string) []int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var list []int
+	for _, t := range s.templates[name] {
+		list = append(list, t.Version)
+	}
+	return list
+}
+
+// Create 保存新模板，版本号为 1
+func (s *TemplateStore) Create(t ContainerTemplate) (ContainerTemplate, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.templates[t.Name]; ok {
+		return t, errdefs.Conflict(fmt.Errorf("template %s already exists", t.Name))
+	}
+	t.Version = 1
+	s.templates[t.Name] = []ContainerTemplate{t}
+	if err := s.flush(); err != nil {
+		delete(s.templates, t.Name)
+		return t, err
+	}
+	return t, nil
+}
+
+// Update 为已有模板保存一个新版本，旧版本保留
+func (s *TemplateStore) Update(t ContainerTemplate) (ContainerTemplate, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	versions, ok := s.templates[t.Name]
+	if !ok {
+		return t, errdefs.NotFound(fmt.Errorf("template %s not found", t.Name))
+	}
+	t.Version = versions[len(versions)-1].Version + 1
+	s.templates[t.Name] = append(versions[:len(versions):len(versions)], t)
+	if err := s.flush(); err != nil {
+		s.templates[t.Name] = versions
+		return t, err
+	}
+	return t, nil
+}
+
+// Delete 删除模板及其全部版本
+func (s *TemplateStore) Delete(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	versions, ok := s.templates[name]
+	if !ok {
+		return errdefs.NotFound(fmt.Errorf("template %s not found", name))
+	}
+	delete(s.templates, name)
+	if err := s.flush(); err != nil {
+		s.templates[name] = versions
+		return err
+	}
+	return nil
+}
+
+// flush 先写临时文件再改名；调用方需持有写锁
+func (s *TemplateStore) flush() error {
+	list := []ContainerTemplate{}
+	for _, versions := range s.templates {
+		list = append(list, versions...)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Name != list[j].Name {
+			return list[i].Name < list[j].Name
+		}
+		return list[i].Version < list[j].Version
+	})
+	data, err := json.MarshalIndent(list, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o750); err != nil {
+		return err
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o640); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path)
+}
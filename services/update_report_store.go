@@ -0,0 +1,148 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/errdefs"
+)
+
+// 自动更新中单个容器的结果
+const (
+	UpdateStatusUpToDate  = "up_to_date"       // 本地镜像与仓库一致
+	UpdateStatusAvailable = "update_available" // 仓库有新版本，dry_run 时不重建
+	UpdateStatusUpdated   = "updated"          // 已拉取新镜像并重建
+	UpdateStatusFailed    = "failed"           // 检查或重建失败，重建失败时原容器已恢复
+	UpdateStatusSkipped   = "skipped"          // 镜像按摘要固定、本地构建或维护窗口已结束
+)
+
+// UpdateReport 一次自动更新检查的报告
+type UpdateReport struct {
+	ID         string         `json:"id"`
+	Trigger    string         `json:"trigger"` // schedule / manual
+	DryRun     bool           `json:"dry_run"`
+	StartedAt  time.Time      `json:"started_at"`
+	FinishedAt time.Time      `json:"finished_at"`
+	Results    []UpdateResult `json:"results"`
+}
+
+// UpdateResult 单个容器的检查结果
+type UpdateResult struct {
+	Host           string `json:"host"`
+	Container      string `json:"container"`
+	ContainerID    string `json:"container_id"`
+	Image          string `json:"image"`
+	LocalDigest    string `json:"local_digest,omitempty"`
+	RemoteDigest   string `json:"remote_digest,omitempty"`
+	Status         string `json:"status"`
+	Error          string `json:"error,omitempty"`
+	NewContainerID string `json:"new_container_id,omitempty"`
+}
+
+// Count 按状态统计结果数量
+func (r UpdateReport) Count(status string) int {
+	n := 0
+	for _, res := range r.Results {
+		if res.Status == status {
+			n++
+		}
+	}
+	return n
+}
+
+// UpdateReportStore 自动更新报告，只保留最近 keep 份，整体保存在一个 JSON 文件中
+type UpdateReportStore struct {
+	mu      sync.RWMutex
+	path    string
+	keep    int
+	reports []UpdateReport // 按时间先后
+}
+
+// NewUpdateReportStore 打开报告文件，文件不存在时视为空
+func NewUpdateReportStore(path string, keep int) (*UpdateReportStore, error) {
+	s := &UpdateReportStore{path: path, keep: keep}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &s.reports); err != nil {
+		return nil, fmt.Errorf("parse update reports %s: %w", path, err)
+	}
+	return s, nil
+}
+
+// List 返回全部报告，最新的在前
+func (s *UpdateReportStore) List() []UpdateReport {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	list := make([]UpdateReport, 0, len(s.reports))
+	for i := len(s.reports) - 1; i >= 0; i-- {
+		list = append(list, s.reports[i])
+	}
+	return list
+}
+
+// Get 按 ID 查找报告
+func (s *UpdateReportStore) Get(id string) (UpdateReport, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, r := range s.reports {
+		if r.ID == id {
+			return r, nil
+		}
+	}
+	return UpdateReport{}, errdefs.NotFound(fmt.Errorf("update report %s not found", id))
+}
+
+// Add 保存报告，超出保留数量时删除最早的；ID 与已有报告重复时加序号
+func (s *UpdateReportStore) Add(report *UpdateReport) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	report.ID = s.uniqueID(report.ID)
+	old := s.reports
+	s.reports = append(s.reports[:len(s.reports):len(s.reports)], *report)
+	if s.keep > 0 && len(s.reports) > s.keep {
+		s.reports = s.reports[len(s.reports)-s.keep:]
+	}
+	if err := s.flush(); err != nil {
+		s.reports = old
+		return err
+	}
+	return nil
+}
+
+func (s *UpdateReportStore) uniqueID(id string) string {
+	taken := make(map[string]bool, len(s.reports))
+	for _, r := range s.reports {
+		taken[r.ID] = true
+	}
+	unique := id
+	for n := 2; taken[unique]; n++ {
+		unique = fmt.Sprintf("%s-%d", id, n)
+	}
+	return unique
+}
+
+// flush 先写临时文件再改名；调用方需持有写锁
+func (s *UpdateReportStore) flush() error {
+	data, err := json.MarshalIndent(s.reports, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o750); err != nil {
+		return err
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o640); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path)
+}
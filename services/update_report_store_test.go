@@ -0,0 +1,50 @@
+package services
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/errdefs"
+)
+
+func TestUpdateReportStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "reports", "updates.json")
+	s, err := NewUpdateReportStore(path, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	started := time.Date(2025, 3, 22, 3, 0, 0, 0, time.UTC)
+	for i := 0; i < 4; i++ {
+		r := UpdateReport{ID: "20250322-030000.000", Trigger: "schedule", StartedAt: started,
+			Results: []UpdateResult{{Host: "local", Container: "web", Status: UpdateStatusUpdated}}}
+		if err := s.Add(&r); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// 同一毫秒的报告 ID 加序号，超出保留数量时删除最早的
+	var ids []string
+	for _, r := range s.List() {
+		ids = append(ids, r.ID)
+	}
+	want := []string{"20250322-030000.000-4", "20250322-030000.000-3", "20250322-030000.000-2"}
+	if len(ids) != len(want) || ids[0] != want[0] || ids[1] != want[1] || ids[2] != want[2] {
+		t.Fatalf("ids = %v, want %v", ids, want)
+	}
+
+	reopened, err := NewUpdateReportStore(path, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := reopened.Get("20250322-030000.000-3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Trigger != "schedule" || !r.StartedAt.Equal(started) || r.Count(UpdateStatusUpdated) != 1 {
+		t.Errorf("reopened report = %+v", r)
+	}
+	if _, err := reopened.Get("20250322-030000.000"); !errdefs.IsNotFound(err) {
+		t.Errorf("trimmed report: %v", err)
+	}
+}
@@ -0,0 +1,77 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// UpdateSchedule 自动更新的检查计划：固定间隔，可限定在每天的维护窗口内
+type UpdateSchedule struct {
+	Interval time.Duration
+	Window   string // HH:MM-HH:MM，为空不限
+
+	start, end int // 窗口起止，距零点的分钟数；end <= start 表示跨零点
+}
+
+// NewUpdateSchedule 解析检查间隔和维护窗口，间隔不少于 1 分钟
+func NewUpdateSchedule(interval, window string) (UpdateSchedule, error) {
+	d, err := time.ParseDuration(strings.TrimSpace(interval))
+	if err != nil {
+		return UpdateSchedule{}, fmt.Errorf("invalid interval %q: %v", interval, err)
+	}
+	if d < time.Minute {
+		return UpdateSchedule{}, fmt.Errorf("interval %q must be at least 1m", interval)
+	}
+	s := UpdateSchedule{Interval: d, Window: strings.TrimSpace(window)}
+	if s.Window == "" {
+		return s, nil
+	}
+	from, to, ok := strings.Cut(s.Window, "-")
+	if !ok {
+		return UpdateSchedule{}, fmt.Errorf("invalid window %q, expected HH:MM-HH:MM", window)
+	}
+	if s.start, err = parseClock(from); err != nil {
+		return UpdateSchedule{}, fmt.Errorf("invalid window %q: %v", window, err)
+	}
+	if s.end, err = parseClock(to); err != nil {
+		return UpdateSchedule{}, fmt.Errorf("invalid window %q: %v", window, err)
+	}
+	if s.start == s.end {
+		return UpdateSchedule{}, fmt.Errorf("invalid window %q: start equals end", window)
+	}
+	return s, nil
+}
+
+func parseClock(s string) (int, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(s))
+	if err != nil {
+		return 0, fmt.Errorf("%q is not HH:MM", s)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// InWindow t 是否在维护窗口内，未设置窗口时总是 true
+func (s UpdateSchedule) InWindow(t time.Time) bool {
+	if s.Window == "" {
+		return true
+	}
+	m := t.Hour()*60 + t.Minute()
+	if s.start < s.end {
+		return m >= s.start && m < s.end
+	}
+	return m >= s.start || m < s.end
+}
+
+// Next 返回 after 之后的下一次检查时间：after + 间隔，落在窗口外时推迟到下一个窗口开始
+func (s UpdateSchedule) Next(after time.Time) time.Time {
+	next := after.Add(s.Interval)
+	if s.InWindow(next) {
+		return next
+	}
+	start := time.Date(next.Year(), next.Month(), next.Day(), s.start/60, s.start%60, 0, 0, next.Location())
+	if !start.After(next) {
+		start = start.AddDate(0, 0, 1)
+	}
+	return start
+}
@@ -0,0 +1,106 @@
+package services
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewUpdateSchedule(t *testing.T) {
+	tests := []struct {
+		interval, window string
+		wantErr          bool
+		start, end       int
+	}{
+		{interval: "30m"},
+		{interval: "6h", window: "01:00-05:30", start: 60, end: 330},
+		{interval: "1h", window: " 22:00 - 02:00 ", start: 1320, end: 120},
+		{interval: "1m", window: "00:00-23:59", start: 0, end: 1439},
+		{interval: "30s", wantErr: true},
+		{interval: "soon", wantErr: true},
+		{interval: "", wantErr: true},
+		{interval: "1h", window: "01:00", wantErr: true},
+		{interval: "1h", window: "1:00-5:00", start: 60, end: 300},
+		{interval: "1h", window: "25:00-01:00", wantErr: true},
+		{interval: "1h", window: "01:00-01:60", wantErr: true},
+		{interval: "1h", window: "03:00-03:00", wantErr: true},
+	}
+	for _, tt := range tests {
+		s, err := NewUpdateSchedule(tt.interval, tt.window)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("NewUpdateSchedule(%q, %q) succeeded, want error", tt.interval, tt.window)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("NewUpdateSchedule(%q, %q): %v", tt.interval, tt.window, err)
+			continue
+		}
+		if s.start != tt.start || s.end != tt.end {
+			t.Errorf("NewUpdateSchedule(%q, %q) window = %d-%d, want %d-%d", tt.interval, tt.window, s.start, s.end, tt.start, tt.end)
+		}
+	}
+}
+
+func at(day, hour, minute int) time.Time {
+	return time.Date(2025, 3, day, hour, minute, 0, 0, time.Local)
+}
+
+func TestUpdateScheduleInWindow(t *testing.T) {
+	tests := []struct {
+		window string
+		t      time.Time
+		want   bool
+	}{
+		{"", at(1, 12, 0), true},
+		{"01:00-05:00", at(1, 0, 59), false},
+		{"01:00-05:00", at(1, 1, 0), true},
+		{"01:00-05:00", at(1, 4, 59), true},
+		{"01:00-05:00", at(1, 5, 0), false},
+		// 跨零点的窗口
+		{"22:00-02:00", at(1, 21, 59), false},
+		{"22:00-02:00", at(1, 22, 0), true},
+		{"22:00-02:00", at(1, 23, 59), true},
+		{"22:00-02:00", at(2, 0, 0), true},
+		{"22:00-02:00", at(2, 1, 59), true},
+		{"22:00-02:00", at(2, 2, 0), false},
+		{"22:00-02:00", at(2, 12, 0), false},
+	}
+	for _, tt := range tests {
+		s, err := NewUpdateSchedule("1h", tt.window)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := s.InWindow(tt.t); got != tt.want {
+			t.Errorf("window %q InWindow(%s) = %v, want %v", tt.window, tt.t.Format("15:04"), got, tt.want)
+		}
+	}
+}
+
+func TestUpdateScheduleNext(t *testing.T) {
+	tests := []struct {
+		interval, window string
+		after, want      time.Time
+	}{
+		{"30m", "", at(1, 23, 45), at(2, 0, 15)},
+		{"30m", "01:00-05:00", at(1, 2, 0), at(1, 2, 30)},
+		// 下一次落在窗口外时推迟到下一个窗口开始
+		{"30m", "01:00-05:00", at(1, 4, 45), at(2, 1, 0)},
+		{"6h", "01:00-05:00", at(1, 12, 0), at(2, 1, 0)},
+		{"1h", "01:00-05:00", at(1, 0, 0), at(1, 1, 0)},
+		// 跨零点的窗口
+		{"30m", "22:00-02:00", at(1, 23, 50), at(2, 0, 20)},
+		{"30m", "22:00-02:00", at(2, 1, 45), at(2, 22, 0)},
+		{"1h", "22:00-02:00", at(1, 12, 0), at(1, 22, 0)},
+		{"1h", "22:00-02:00", at(1, 21, 30), at(1, 22, 30)},
+	}
+	for _, tt := range tests {
+		s, err := NewUpdateSchedule(tt.interval, tt.window)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := s.Next(tt.after); !got.Equal(tt.want) {
+			t.Errorf("%s / %q: Next(%s) = %s, want %s", tt.interval, tt.window, tt.after.Format("02 15:04"), got.Format("02 15:04"), tt.want.Format("02 15:04"))
+		}
+	}
+}